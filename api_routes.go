@@ -0,0 +1,595 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Code generated by genroutes. DO NOT EDIT.
+
+package gitlab
+
+// apiRoutes contains the paths of all API routes used by the client,
+// with every path parameter replaced by ":id".
+var apiRoutes = []string{
+	"admin/ci/variables",
+	"admin/ci/variables/:id",
+	"admin/clusters",
+	"admin/clusters/:id",
+	"admin/clusters/add",
+	"application/appearance",
+	"application/plan_limits",
+	"application/settings",
+	"applications",
+	"applications/:id",
+	"audit_events",
+	"audit_events/:id",
+	"avatar",
+	"broadcast_messages",
+	"broadcast_messages/:id",
+	"ci/lint",
+	"dependency_list_exports/:id",
+	"dependency_list_exports/:id/download",
+	"deploy_keys",
+	"deploy_tokens",
+	"events",
+	"features",
+	"features/:id",
+	"geo_nodes",
+	"geo_nodes/:id",
+	"geo_nodes/:id/repair",
+	"geo_nodes/:id/status",
+	"geo_nodes/status",
+	"group_repository_storage_moves",
+	"group_repository_storage_moves/:id",
+	"groups",
+	"groups/:id",
+	"groups/:id/-/search",
+	"groups/:id/access_requests",
+	"groups/:id/access_requests/:id",
+	"groups/:id/access_requests/:id/approve",
+	"groups/:id/access_tokens",
+	"groups/:id/access_tokens/:id",
+	"groups/:id/access_tokens/:id/rotate",
+	"groups/:id/audit_events",
+	"groups/:id/audit_events/:id",
+	"groups/:id/avatar",
+	"groups/:id/badges",
+	"groups/:id/badges/:id",
+	"groups/:id/badges/render",
+	"groups/:id/billable_members",
+	"groups/:id/billable_members/:id",
+	"groups/:id/billable_members/:id/memberships",
+	"groups/:id/boards",
+	"groups/:id/boards/:id",
+	"groups/:id/boards/:id/lists",
+	"groups/:id/boards/:id/lists/:id",
+	"groups/:id/clusters",
+	"groups/:id/clusters/:id",
+	"groups/:id/clusters/user",
+	"groups/:id/deploy_tokens",
+	"groups/:id/deploy_tokens/:id",
+	"groups/:id/descendant_groups",
+	"groups/:id/dora/metrics",
+	"groups/:id/epic_boards",
+	"groups/:id/epic_boards/:id",
+	"groups/:id/epics",
+	"groups/:id/epics/:id",
+	"groups/:id/epics/:id/discussions",
+	"groups/:id/epics/:id/discussions/:id",
+	"groups/:id/epics/:id/discussions/:id/notes",
+	"groups/:id/epics/:id/discussions/:id/notes/:id",
+	"groups/:id/epics/:id/epics",
+	"groups/:id/epics/:id/issues",
+	"groups/:id/epics/:id/issues/:id",
+	"groups/:id/epics/:id/notes",
+	"groups/:id/epics/:id/notes/:id",
+	"groups/:id/epics/:id/resource_label_events",
+	"groups/:id/epics/:id/resource_label_events/:id",
+	"groups/:id/export",
+	"groups/:id/export/download",
+	"groups/:id/hooks",
+	"groups/:id/hooks/:id",
+	"groups/:id/hooks/:id/custom_headers/:id",
+	"groups/:id/hooks/:id/test/:id",
+	"groups/:id/invitations",
+	"groups/:id/issues",
+	"groups/:id/issues_statistics",
+	"groups/:id/iterations",
+	"groups/:id/labels",
+	"groups/:id/labels/:id",
+	"groups/:id/labels/:id/subscribe",
+	"groups/:id/labels/:id/unsubscribe",
+	"groups/:id/ldap_group_links",
+	"groups/:id/ldap_group_links/:id",
+	"groups/:id/ldap_group_links/:id/:id",
+	"groups/:id/member_roles",
+	"groups/:id/member_roles/:id",
+	"groups/:id/members",
+	"groups/:id/members/:id",
+	"groups/:id/members/all",
+	"groups/:id/members/all/:id",
+	"groups/:id/merge_requests",
+	"groups/:id/milestones",
+	"groups/:id/milestones/:id",
+	"groups/:id/milestones/:id/burndown_events",
+	"groups/:id/milestones/:id/issues",
+	"groups/:id/milestones/:id/merge_requests",
+	"groups/:id/notification_settings",
+	"groups/:id/packages",
+	"groups/:id/projects",
+	"groups/:id/projects/:id",
+	"groups/:id/protected_environments",
+	"groups/:id/protected_environments/:id",
+	"groups/:id/provisioned_users",
+	"groups/:id/push_rule",
+	"groups/:id/registry/repositories",
+	"groups/:id/repository_storage_moves",
+	"groups/:id/repository_storage_moves/:id",
+	"groups/:id/restore",
+	"groups/:id/runners",
+	"groups/:id/runners/reset_registration_token",
+	"groups/:id/saml_group_links",
+	"groups/:id/saml_group_links/:id",
+	"groups/:id/service_accounts",
+	"groups/:id/service_accounts/:id",
+	"groups/:id/service_accounts/:id/personal_access_tokens",
+	"groups/:id/service_accounts/:id/personal_access_tokens/:id/rotate",
+	"groups/:id/share",
+	"groups/:id/share/:id",
+	"groups/:id/ssh_certificates",
+	"groups/:id/ssh_certificates/:id",
+	"groups/:id/subgroups",
+	"groups/:id/transfer",
+	"groups/:id/variables",
+	"groups/:id/variables/:id",
+	"groups/:id/wikis",
+	"groups/:id/wikis/:id",
+	"groups/import",
+	"hooks",
+	"hooks/:id",
+	"import/bitbucket",
+	"import/bitbucket_server",
+	"import/github",
+	"import/github/cancel",
+	"import/github/gists",
+	"issues",
+	"issues/:id",
+	"issues_statistics",
+	"job",
+	"keys",
+	"keys/:id",
+	"license",
+	"license/:id",
+	"markdown",
+	"merge_requests",
+	"metadata",
+	"namespaces",
+	"namespaces/:id",
+	"namespaces/:id/exists",
+	"notification_settings",
+	"pages/domains",
+	"personal_access_tokens",
+	"personal_access_tokens/:id",
+	"personal_access_tokens/:id/rotate",
+	"personal_access_tokens/self",
+	"personal_access_tokens/self/rotate",
+	"pipelines/:id/dependency_list_exports",
+	"project_repository_storage_moves",
+	"project_repository_storage_moves/:id",
+	"projects",
+	"projects/:id",
+	"projects/:id/-/search",
+	"projects/:id/:id/:id/add_spent_time",
+	"projects/:id/:id/:id/award_emoji",
+	"projects/:id/:id/:id/award_emoji/:id",
+	"projects/:id/:id/:id/notes/:id/award_emoji",
+	"projects/:id/:id/:id/notes/:id/award_emoji/:id",
+	"projects/:id/:id/:id/reset_spent_time",
+	"projects/:id/:id/:id/reset_time_estimate",
+	"projects/:id/:id/:id/time_estimate",
+	"projects/:id/:id/:id/time_stats",
+	"projects/:id/access_requests",
+	"projects/:id/access_requests/:id",
+	"projects/:id/access_requests/:id/approve",
+	"projects/:id/access_tokens",
+	"projects/:id/access_tokens/:id",
+	"projects/:id/access_tokens/:id/rotate",
+	"projects/:id/approval_rules",
+	"projects/:id/approval_rules/:id",
+	"projects/:id/approvals",
+	"projects/:id/approvers",
+	"projects/:id/archive",
+	"projects/:id/artifacts",
+	"projects/:id/audit_events",
+	"projects/:id/audit_events/:id",
+	"projects/:id/badges",
+	"projects/:id/badges/:id",
+	"projects/:id/badges/render",
+	"projects/:id/boards",
+	"projects/:id/boards/:id",
+	"projects/:id/boards/:id/lists",
+	"projects/:id/boards/:id/lists/:id",
+	"projects/:id/ci/lint",
+	"projects/:id/cluster_agents",
+	"projects/:id/cluster_agents/:id",
+	"projects/:id/cluster_agents/:id/tokens",
+	"projects/:id/cluster_agents/:id/tokens/:id",
+	"projects/:id/clusters",
+	"projects/:id/clusters/:id",
+	"projects/:id/clusters/user",
+	"projects/:id/deploy_keys",
+	"projects/:id/deploy_keys/:id",
+	"projects/:id/deploy_keys/:id/enable",
+	"projects/:id/deploy_tokens",
+	"projects/:id/deploy_tokens/:id",
+	"projects/:id/deployments",
+	"projects/:id/deployments/:id",
+	"projects/:id/deployments/:id/approval",
+	"projects/:id/deployments/:id/merge_requests",
+	"projects/:id/dora/metrics",
+	"projects/:id/environments",
+	"projects/:id/environments/:id",
+	"projects/:id/environments/:id/stop",
+	"projects/:id/error_tracking/client_keys",
+	"projects/:id/error_tracking/client_keys/:id",
+	"projects/:id/error_tracking/settings",
+	"projects/:id/events",
+	"projects/:id/export",
+	"projects/:id/export/download",
+	"projects/:id/external_status_checks",
+	"projects/:id/external_status_checks/:id",
+	"projects/:id/feature_flags",
+	"projects/:id/feature_flags/:id",
+	"projects/:id/fork",
+	"projects/:id/fork/:id",
+	"projects/:id/forks",
+	"projects/:id/freeze_periods",
+	"projects/:id/freeze_periods/:id",
+	"projects/:id/groups",
+	"projects/:id/hooks",
+	"projects/:id/hooks/:id",
+	"projects/:id/hooks/:id/custom_headers/:id",
+	"projects/:id/hooks/:id/test/:id",
+	"projects/:id/housekeeping",
+	"projects/:id/import",
+	"projects/:id/integrations/emails-on-push",
+	"projects/:id/integrations/gitlab-slack-application",
+	"projects/:id/integrations/harbor",
+	"projects/:id/integrations/jira",
+	"projects/:id/integrations/redmine",
+	"projects/:id/invitations",
+	"projects/:id/invited_groups",
+	"projects/:id/issues",
+	"projects/:id/issues/:id",
+	"projects/:id/issues/:id/closed_by",
+	"projects/:id/issues/:id/discussions",
+	"projects/:id/issues/:id/discussions/:id",
+	"projects/:id/issues/:id/discussions/:id/notes",
+	"projects/:id/issues/:id/discussions/:id/notes/:id",
+	"projects/:id/issues/:id/links",
+	"projects/:id/issues/:id/links/:id",
+	"projects/:id/issues/:id/move",
+	"projects/:id/issues/:id/notes",
+	"projects/:id/issues/:id/notes/:id",
+	"projects/:id/issues/:id/participants",
+	"projects/:id/issues/:id/related_merge_requests",
+	"projects/:id/issues/:id/reorder",
+	"projects/:id/issues/:id/resource_iteration_events",
+	"projects/:id/issues/:id/resource_iteration_events/:id",
+	"projects/:id/issues/:id/resource_label_events",
+	"projects/:id/issues/:id/resource_label_events/:id",
+	"projects/:id/issues/:id/resource_milestone_events",
+	"projects/:id/issues/:id/resource_milestone_events/:id",
+	"projects/:id/issues/:id/resource_state_events",
+	"projects/:id/issues/:id/resource_state_events/:id",
+	"projects/:id/issues/:id/resource_weight_events",
+	"projects/:id/issues/:id/subscribe",
+	"projects/:id/issues/:id/todo",
+	"projects/:id/issues/:id/unsubscribe",
+	"projects/:id/issues_statistics",
+	"projects/:id/iterations",
+	"projects/:id/job_token_scope",
+	"projects/:id/job_token_scope/allowlist",
+	"projects/:id/job_token_scope/allowlist/:id",
+	"projects/:id/job_token_scope/groups_allowlist",
+	"projects/:id/job_token_scope/groups_allowlist/:id",
+	"projects/:id/jobs",
+	"projects/:id/jobs/:id",
+	"projects/:id/jobs/:id/artifacts",
+	"projects/:id/jobs/:id/artifacts/:id",
+	"projects/:id/jobs/:id/artifacts/keep",
+	"projects/:id/jobs/:id/cancel",
+	"projects/:id/jobs/:id/erase",
+	"projects/:id/jobs/:id/play",
+	"projects/:id/jobs/:id/retry",
+	"projects/:id/jobs/:id/trace",
+	"projects/:id/jobs/artifacts/:id/download",
+	"projects/:id/jobs/artifacts/:id/raw/:id",
+	"projects/:id/labels",
+	"projects/:id/labels/:id",
+	"projects/:id/labels/:id/promote",
+	"projects/:id/labels/:id/subscribe",
+	"projects/:id/labels/:id/unsubscribe",
+	"projects/:id/languages",
+	"projects/:id/managed_licenses",
+	"projects/:id/managed_licenses/:id",
+	"projects/:id/members",
+	"projects/:id/members/:id",
+	"projects/:id/members/all",
+	"projects/:id/members/all/:id",
+	"projects/:id/merge_requests",
+	"projects/:id/merge_requests/:id",
+	"projects/:id/merge_requests/:id/approval_rules",
+	"projects/:id/merge_requests/:id/approval_rules/:id",
+	"projects/:id/merge_requests/:id/approval_state",
+	"projects/:id/merge_requests/:id/approvals",
+	"projects/:id/merge_requests/:id/approve",
+	"projects/:id/merge_requests/:id/approvers",
+	"projects/:id/merge_requests/:id/cancel_merge_when_pipeline_succeeds",
+	"projects/:id/merge_requests/:id/changes",
+	"projects/:id/merge_requests/:id/closes_issues",
+	"projects/:id/merge_requests/:id/commits",
+	"projects/:id/merge_requests/:id/diffs",
+	"projects/:id/merge_requests/:id/discussions",
+	"projects/:id/merge_requests/:id/discussions/:id",
+	"projects/:id/merge_requests/:id/discussions/:id/notes",
+	"projects/:id/merge_requests/:id/discussions/:id/notes/:id",
+	"projects/:id/merge_requests/:id/draft_notes",
+	"projects/:id/merge_requests/:id/draft_notes/:id",
+	"projects/:id/merge_requests/:id/draft_notes/:id/publish",
+	"projects/:id/merge_requests/:id/draft_notes/bulk_publish",
+	"projects/:id/merge_requests/:id/merge",
+	"projects/:id/merge_requests/:id/notes",
+	"projects/:id/merge_requests/:id/notes/:id",
+	"projects/:id/merge_requests/:id/participants",
+	"projects/:id/merge_requests/:id/pipelines",
+	"projects/:id/merge_requests/:id/rebase",
+	"projects/:id/merge_requests/:id/reset_approvals",
+	"projects/:id/merge_requests/:id/resource_label_events",
+	"projects/:id/merge_requests/:id/resource_label_events/:id",
+	"projects/:id/merge_requests/:id/resource_milestone_events",
+	"projects/:id/merge_requests/:id/resource_milestone_events/:id",
+	"projects/:id/merge_requests/:id/resource_state_events",
+	"projects/:id/merge_requests/:id/resource_state_events/:id",
+	"projects/:id/merge_requests/:id/reviewers",
+	"projects/:id/merge_requests/:id/status_check_responses",
+	"projects/:id/merge_requests/:id/status_checks",
+	"projects/:id/merge_requests/:id/status_checks/:id/retry",
+	"projects/:id/merge_requests/:id/subscribe",
+	"projects/:id/merge_requests/:id/todo",
+	"projects/:id/merge_requests/:id/unapprove",
+	"projects/:id/merge_requests/:id/unsubscribe",
+	"projects/:id/merge_requests/:id/versions",
+	"projects/:id/merge_requests/:id/versions/:id",
+	"projects/:id/merge_trains",
+	"projects/:id/merge_trains/:id",
+	"projects/:id/merge_trains/merge_requests/:id",
+	"projects/:id/milestones",
+	"projects/:id/milestones/:id",
+	"projects/:id/milestones/:id/issues",
+	"projects/:id/milestones/:id/merge_requests",
+	"projects/:id/mirror/pull",
+	"projects/:id/notification_settings",
+	"projects/:id/packages",
+	"projects/:id/packages/:id",
+	"projects/:id/packages/:id/package_files",
+	"projects/:id/packages/:id/package_files/:id",
+	"projects/:id/packages/generic/:id/:id/:id",
+	"projects/:id/pages",
+	"projects/:id/pages/domains",
+	"projects/:id/pages/domains/:id",
+	"projects/:id/pipeline",
+	"projects/:id/pipeline_schedules",
+	"projects/:id/pipeline_schedules/:id",
+	"projects/:id/pipeline_schedules/:id/pipelines",
+	"projects/:id/pipeline_schedules/:id/play",
+	"projects/:id/pipeline_schedules/:id/take_ownership",
+	"projects/:id/pipeline_schedules/:id/variables",
+	"projects/:id/pipeline_schedules/:id/variables/:id",
+	"projects/:id/pipelines",
+	"projects/:id/pipelines/:id",
+	"projects/:id/pipelines/:id/bridges",
+	"projects/:id/pipelines/:id/cancel",
+	"projects/:id/pipelines/:id/jobs",
+	"projects/:id/pipelines/:id/metadata",
+	"projects/:id/pipelines/:id/retry",
+	"projects/:id/pipelines/:id/test_report",
+	"projects/:id/pipelines/:id/variables",
+	"projects/:id/pipelines/latest",
+	"projects/:id/protected_branches",
+	"projects/:id/protected_branches/:id",
+	"projects/:id/protected_environments",
+	"projects/:id/protected_environments/:id",
+	"projects/:id/protected_tags",
+	"projects/:id/protected_tags/:id",
+	"projects/:id/push_rule",
+	"projects/:id/registry/repositories",
+	"projects/:id/registry/repositories/:id",
+	"projects/:id/registry/repositories/:id/tags",
+	"projects/:id/registry/repositories/:id/tags/:id",
+	"projects/:id/releases",
+	"projects/:id/releases/:id",
+	"projects/:id/releases/:id/assets/links",
+	"projects/:id/releases/:id/assets/links/:id",
+	"projects/:id/releases/permalink/latest",
+	"projects/:id/remote_mirrors",
+	"projects/:id/remote_mirrors/:id",
+	"projects/:id/repository/archive",
+	"projects/:id/repository/blobs/:id",
+	"projects/:id/repository/blobs/:id/raw",
+	"projects/:id/repository/branches",
+	"projects/:id/repository/branches/:id",
+	"projects/:id/repository/branches/:id/protect",
+	"projects/:id/repository/branches/:id/unprotect",
+	"projects/:id/repository/changelog",
+	"projects/:id/repository/commits",
+	"projects/:id/repository/commits/:id",
+	"projects/:id/repository/commits/:id/cherry_pick",
+	"projects/:id/repository/commits/:id/comments",
+	"projects/:id/repository/commits/:id/diff",
+	"projects/:id/repository/commits/:id/discussions",
+	"projects/:id/repository/commits/:id/discussions/:id",
+	"projects/:id/repository/commits/:id/discussions/:id/notes",
+	"projects/:id/repository/commits/:id/discussions/:id/notes/:id",
+	"projects/:id/repository/commits/:id/merge_requests",
+	"projects/:id/repository/commits/:id/refs",
+	"projects/:id/repository/commits/:id/revert",
+	"projects/:id/repository/commits/:id/signature",
+	"projects/:id/repository/commits/:id/statuses",
+	"projects/:id/repository/compare",
+	"projects/:id/repository/contributors",
+	"projects/:id/repository/files/:id",
+	"projects/:id/repository/files/:id/blame",
+	"projects/:id/repository/files/:id/raw",
+	"projects/:id/repository/merge_base",
+	"projects/:id/repository/merged_branches",
+	"projects/:id/repository/submodules/:id",
+	"projects/:id/repository/tags",
+	"projects/:id/repository/tags/:id",
+	"projects/:id/repository/tags/:id/release",
+	"projects/:id/repository/tree",
+	"projects/:id/repository_storage_moves",
+	"projects/:id/repository_storage_moves/:id",
+	"projects/:id/resource_groups",
+	"projects/:id/resource_groups/:id",
+	"projects/:id/resource_groups/:id/upcoming_jobs",
+	"projects/:id/runners",
+	"projects/:id/runners/:id",
+	"projects/:id/runners/reset_registration_token",
+	"projects/:id/services",
+	"projects/:id/services/custom-issue-tracker",
+	"projects/:id/services/datadog",
+	"projects/:id/services/discord",
+	"projects/:id/services/drone-ci",
+	"projects/:id/services/external-wiki",
+	"projects/:id/services/github",
+	"projects/:id/services/gitlab-ci",
+	"projects/:id/services/hipchat",
+	"projects/:id/services/jenkins",
+	"projects/:id/services/mattermost",
+	"projects/:id/services/mattermost-slash-commands",
+	"projects/:id/services/microsoft-teams",
+	"projects/:id/services/pipelines-email",
+	"projects/:id/services/prometheus",
+	"projects/:id/services/slack",
+	"projects/:id/services/slack-slash-commands",
+	"projects/:id/services/telegram",
+	"projects/:id/services/youtrack",
+	"projects/:id/share",
+	"projects/:id/share/:id",
+	"projects/:id/snippets",
+	"projects/:id/snippets/:id",
+	"projects/:id/snippets/:id/discussions",
+	"projects/:id/snippets/:id/discussions/:id",
+	"projects/:id/snippets/:id/discussions/:id/notes",
+	"projects/:id/snippets/:id/discussions/:id/notes/:id",
+	"projects/:id/snippets/:id/notes",
+	"projects/:id/snippets/:id/notes/:id",
+	"projects/:id/snippets/:id/raw",
+	"projects/:id/star",
+	"projects/:id/statuses/:id",
+	"projects/:id/storage",
+	"projects/:id/templates/:id",
+	"projects/:id/templates/:id/:id",
+	"projects/:id/transfer",
+	"projects/:id/trigger/pipeline",
+	"projects/:id/triggers",
+	"projects/:id/triggers/:id",
+	"projects/:id/triggers/:id/take_ownership",
+	"projects/:id/unarchive",
+	"projects/:id/unstar",
+	"projects/:id/uploads",
+	"projects/:id/uploads/:id",
+	"projects/:id/uploads/:id/:id",
+	"projects/:id/users",
+	"projects/:id/variables",
+	"projects/:id/variables/:id",
+	"projects/:id/vulnerabilities",
+	"projects/:id/wikis",
+	"projects/:id/wikis/:id",
+	"projects/import",
+	"projects/user/:id",
+	"registry/repositories/:id",
+	"runners",
+	"runners/:id",
+	"runners/:id/jobs",
+	"runners/:id/reset_authentication_token",
+	"runners/all",
+	"runners/reset_registration_token",
+	"runners/verify",
+	"search",
+	"service_accounts",
+	"snippet_repository_storage_moves",
+	"snippet_repository_storage_moves/:id",
+	"snippets",
+	"snippets/:id",
+	"snippets/:id/files/:id/:id/raw",
+	"snippets/:id/raw",
+	"snippets/:id/repository_storage_moves",
+	"snippets/:id/repository_storage_moves/:id",
+	"snippets/all",
+	"snippets/public",
+	"templates/dockerfiles",
+	"templates/dockerfiles/:id",
+	"templates/gitignores",
+	"templates/gitignores/:id",
+	"templates/gitlab_ci_ymls",
+	"templates/gitlab_ci_ymls/:id",
+	"templates/licenses",
+	"templates/licenses/:id",
+	"todos",
+	"todos/:id/mark_as_done",
+	"todos/mark_as_done",
+	"topics",
+	"topics/:id",
+	"user",
+	"user/activities",
+	"user/avatar",
+	"user/emails",
+	"user/emails/:id",
+	"user/gpg_keys",
+	"user/gpg_keys/:id",
+	"user/keys",
+	"user/keys/:id",
+	"user/personal_access_tokens",
+	"user/runners",
+	"user/status",
+	"users",
+	"users/:id",
+	"users/:id/activate",
+	"users/:id/approve",
+	"users/:id/associations_count",
+	"users/:id/ban",
+	"users/:id/block",
+	"users/:id/contributed_projects",
+	"users/:id/deactivate",
+	"users/:id/disable_two_factor",
+	"users/:id/emails",
+	"users/:id/emails/:id",
+	"users/:id/events",
+	"users/:id/gpg_keys",
+	"users/:id/gpg_keys/:id",
+	"users/:id/impersonation_tokens",
+	"users/:id/impersonation_tokens/:id",
+	"users/:id/keys",
+	"users/:id/keys/:id",
+	"users/:id/memberships",
+	"users/:id/personal_access_tokens",
+	"users/:id/projects",
+	"users/:id/reject",
+	"users/:id/starred_projects",
+	"users/:id/status",
+	"users/:id/unban",
+	"users/:id/unblock",
+	"version",
+}
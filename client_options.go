@@ -125,6 +125,25 @@ func WithResponseLogHook(hook retryablehttp.ResponseLogHook) ClientOptionFunc {
 	}
 }
 
+// WithResponseCache can be used to configure a response cache. When set, GET
+// requests are made conditional using the ETag of a cached response and the
+// cached response is used when GitLab responds with 304 Not Modified.
+func WithResponseCache(cache ResponseCache) ClientOptionFunc {
+	return func(c *Client) error {
+		c.responseCache = cache
+		return nil
+	}
+}
+
+// WithInstrumentation can be used to configure instrumentation, which is
+// notified about every API call and can be used for tracing and metrics.
+func WithInstrumentation(instrumentation Instrumentation) ClientOptionFunc {
+	return func(c *Client) error {
+		c.instrumentation = instrumentation
+		return nil
+	}
+}
+
 // WithoutRetries disables the default retry logic.
 func WithoutRetries() ClientOptionFunc {
 	return func(c *Client) error {
@@ -0,0 +1,401 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultCommitMaxPayloadSize is the default maximum size of the file
+// contents sent in a single commit by a CommitBuilder.
+const DefaultCommitMaxPayloadSize = 50 << 20
+
+// CommitBuilder stages file operations and commits them to a branch using
+// CreateCommit.
+//
+// When committing, the builder checks which files already exist to choose
+// between creating and updating them, and pins the last commit ID of every
+// existing file it touches. If one of those files is changed by someone else
+// before the commit is created, the commit fails with a *ConflictError.
+//
+// Example usage:
+//
+//	b := client.Commits.NewCommitBuilder("group/project", "main")
+//	b.Put("README.md", []byte("# Project\n"))
+//	b.Move("docs/old.md", "docs/new.md")
+//	b.Delete("obsolete.txt")
+//	commits, _, err := b.Commit(&gitlab.CommitBuilderOptions{
+//	    CommitMessage: gitlab.Ptr("Update docs"),
+//	})
+type CommitBuilder struct {
+	commits *CommitsService
+	pid     interface{}
+	branch  string
+	ops     []*commitOperation
+}
+
+// commitOperation is a single staged file operation.
+type commitOperation struct {
+	action       FileActionValue
+	path         string
+	previousPath string
+	content      []byte
+	executable   *bool
+
+	// discard is set when a delete replaced a staged put, so nothing needs
+	// to be committed if the file doesn't exist yet.
+	discard bool
+}
+
+// CommitBuilderOptions represents the available options for committing the
+// operations staged in a CommitBuilder.
+type CommitBuilderOptions struct {
+	// CommitMessage is the message of the commit. When the operations are
+	// split into multiple commits, the number of each commit is appended.
+	CommitMessage *string
+
+	// StartBranch is the branch to create the branch from if it doesn't
+	// exist yet.
+	StartBranch *string
+
+	AuthorEmail *string
+	AuthorName  *string
+
+	// MaxPayloadSize is the maximum size of the file contents sent in a
+	// single commit. Larger change sets are split into sequential commits.
+	// Defaults to DefaultCommitMaxPayloadSize.
+	MaxPayloadSize *int
+}
+
+// NewCommitBuilder returns a new CommitBuilder committing to the given branch
+// of the given project.
+func (s *CommitsService) NewCommitBuilder(pid interface{}, branch string) *CommitBuilder {
+	return &CommitBuilder{commits: s, pid: pid, branch: branch}
+}
+
+// Put stages writing the content to the file at the given path, creating the
+// file if it doesn't exist. Writing the same file again replaces the staged
+// content.
+func (b *CommitBuilder) Put(path string, content []byte) *CommitBuilder {
+	content = append([]byte(nil), content...)
+
+	if op := b.pending(path); op != nil {
+		if op.action == FileDelete {
+			op.executable = nil
+		}
+		op.action = FileUpdate
+		op.content = content
+		op.discard = false
+		return b
+	}
+
+	b.ops = append(b.ops, &commitOperation{
+		action:  FileUpdate,
+		path:    path,
+		content: content,
+	})
+	return b
+}
+
+// Move stages moving the file at the given path to a new path.
+func (b *CommitBuilder) Move(from, to string) *CommitBuilder {
+	b.ops = append(b.ops, &commitOperation{
+		action:       FileMove,
+		path:         to,
+		previousPath: from,
+	})
+	return b
+}
+
+// Delete stages deleting the file at the given path. Deleting a file that was
+// only staged using Put cancels it out.
+func (b *CommitBuilder) Delete(path string) *CommitBuilder {
+	if op := b.pending(path); op != nil {
+		if op.action != FileDelete {
+			op.discard = op.action == FileUpdate
+			op.action = FileDelete
+			op.content = nil
+			op.executable = nil
+		}
+		return b
+	}
+
+	b.ops = append(b.ops, &commitOperation{
+		action: FileDelete,
+		path:   path,
+	})
+	return b
+}
+
+// Chmod stages changing the execute flag of the file at the given path.
+func (b *CommitBuilder) Chmod(path string, executable bool) *CommitBuilder {
+	if op := b.pending(path); op != nil && op.action != FileDelete {
+		op.executable = Ptr(executable)
+		return b
+	}
+
+	b.ops = append(b.ops, &commitOperation{
+		action:     FileChmod,
+		path:       path,
+		executable: Ptr(executable),
+	})
+	return b
+}
+
+// pending returns the staged operation of the file at the given path, so it
+// can be replaced by a later operation on the same file. It returns nil if
+// the file isn't staged yet, or was last staged by a move, which is always
+// committed as a separate operation.
+func (b *CommitBuilder) pending(path string) *commitOperation {
+	for i := len(b.ops) - 1; i >= 0; i-- {
+		op := b.ops[i]
+		if op.path != path && op.previousPath != path {
+			continue
+		}
+		if op.action == FileMove {
+			return nil
+		}
+		return op
+	}
+	return nil
+}
+
+// Len returns the number of staged operations.
+func (b *CommitBuilder) Len() int {
+	return len(b.ops)
+}
+
+// Commit commits the staged operations and returns the created commits. Most
+// change sets result in a single commit, but change sets exceeding the
+// maximum payload size are split into sequential commits.
+//
+// If one of the commits fails, the commits created so far are returned
+// together with the error. The staged operations are cleared once all
+// commits are created. No commit is created if the staged operations
+// cancel each other out.
+func (b *CommitBuilder) Commit(opt *CommitBuilderOptions, options ...RequestOptionFunc) ([]*Commit, *Response, error) {
+	if len(b.ops) == 0 {
+		return nil, nil, errors.New("no operations to commit")
+	}
+	if opt == nil {
+		opt = &CommitBuilderOptions{}
+	}
+
+	ref := b.branch
+	if opt.StartBranch != nil {
+		ref = *opt.StartBranch
+	}
+
+	actions, resp, err := b.actions(ref, options)
+	if err != nil {
+		return nil, resp, err
+	}
+	if len(actions) == 0 {
+		// The staged operations cancelled each other out.
+		b.ops = nil
+		return nil, resp, nil
+	}
+
+	commits, resp, err := createCommits(b.commits, b.pid, b.branch, actions, opt, options)
+	if err != nil {
+		return commits, resp, err
+	}
+	b.ops = nil
+
+	return commits, resp, nil
+}
+
+// createCommits creates one or more commits containing the given actions,
+// splitting them when they exceed the maximum payload size.
+func createCommits(s CommitsServiceInterface, pid interface{}, branch string, actions []*CommitActionOptions, opt *CommitBuilderOptions, options []RequestOptionFunc) ([]*Commit, *Response, error) {
+	maxSize := DefaultCommitMaxPayloadSize
+	if opt.MaxPayloadSize != nil {
+		maxSize = *opt.MaxPayloadSize
+	}
+	batches := splitCommitActions(actions, maxSize)
+
+	var commits []*Commit
+	var commit *Commit
+	var resp *Response
+	var err error
+
+	touched := make(map[string]bool)
+	for i, batch := range batches {
+		copt := &CreateCommitOptions{
+			Branch:        Ptr(branch),
+			CommitMessage: opt.CommitMessage,
+			AuthorEmail:   opt.AuthorEmail,
+			AuthorName:    opt.AuthorName,
+			Actions:       batch,
+		}
+		if i == 0 {
+			copt.StartBranch = opt.StartBranch
+		}
+		if len(batches) > 1 {
+			msg := fmt.Sprintf("(%d/%d)", i+1, len(batches))
+			if opt.CommitMessage != nil {
+				msg = *opt.CommitMessage + " " + msg
+			}
+			copt.CommitMessage = Ptr(msg)
+		}
+
+		// Files touched by a previous commit were last changed by that commit.
+		for _, action := range batch {
+			if touched[commitActionSource(action)] && action.LastCommitID != nil {
+				action.LastCommitID = Ptr(commits[len(commits)-1].ID)
+			}
+		}
+
+		commit, resp, err = s.CreateCommit(pid, copt, options...)
+		if err != nil {
+			return commits, resp, commitConflictError(err)
+		}
+		commits = append(commits, commit)
+
+		for _, action := range batch {
+			touched[*action.FilePath] = true
+			touched[commitActionSource(action)] = true
+		}
+	}
+
+	return commits, resp, nil
+}
+
+// commitActionSource returns the path of the file an action applies to,
+// which is the previous path for moves.
+func commitActionSource(action *CommitActionOptions) string {
+	if action.PreviousPath != nil {
+		return *action.PreviousPath
+	}
+	return *action.FilePath
+}
+
+// actions converts the staged operations into commit actions, using the
+// metadata of the files at the given ref.
+func (b *CommitBuilder) actions(ref string, options []RequestOptionFunc) ([]*CommitActionOptions, *Response, error) {
+	lastCommitIDs := make(map[string]string)
+	lastCommitID := func(path string) (string, *Response, error) {
+		if id, ok := lastCommitIDs[path]; ok {
+			return id, nil, nil
+		}
+
+		f, resp, err := b.commits.client.RepositoryFiles.GetFileMetaData(
+			b.pid,
+			path,
+			&GetFileMetaDataOptions{Ref: Ptr(ref)},
+			options...,
+		)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return "", resp, err
+		}
+		if f != nil {
+			lastCommitIDs[path] = f.LastCommitID
+		} else {
+			lastCommitIDs[path] = ""
+		}
+
+		return lastCommitIDs[path], resp, nil
+	}
+
+	actions := make([]*CommitActionOptions, 0, len(b.ops))
+	for _, op := range b.ops {
+		path := op.path
+		if op.previousPath != "" {
+			path = op.previousPath
+		}
+
+		id, resp, err := lastCommitID(path)
+		if err != nil {
+			return nil, resp, err
+		}
+		if id == "" && op.discard {
+			continue
+		}
+
+		action := &CommitActionOptions{
+			Action:          FileAction(op.action),
+			FilePath:        Ptr(op.path),
+			ExecuteFilemode: op.executable,
+		}
+		if op.previousPath != "" {
+			action.PreviousPath = Ptr(op.previousPath)
+		}
+		if id != "" {
+			action.LastCommitID = Ptr(id)
+		} else if op.action == FileUpdate {
+			action.Action = FileAction(FileCreate)
+		}
+		if op.content != nil {
+			content, encoding := encodeCommitContent(op.content)
+			action.Content = Ptr(content)
+			action.Encoding = encoding
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil, nil
+}
+
+// encodeCommitContent returns the content as text if possible, or base64
+// encoded otherwise.
+func encodeCommitContent(content []byte) (string, *string) {
+	if utf8.Valid(content) && !bytes.ContainsRune(content, 0) {
+		return string(content), nil
+	}
+	return base64.StdEncoding.EncodeToString(content), Ptr("base64")
+}
+
+// splitCommitActions splits the actions into batches of which the content
+// doesn't exceed the given size. Actions exceeding the size on their own get
+// a batch of their own.
+func splitCommitActions(actions []*CommitActionOptions, maxSize int) [][]*CommitActionOptions {
+	var batches [][]*CommitActionOptions
+	var batch []*CommitActionOptions
+	var size int
+
+	for _, action := range actions {
+		actionSize := len(*action.FilePath)
+		if action.Content != nil {
+			actionSize += len(*action.Content)
+		}
+
+		if len(batch) > 0 && size+actionSize > maxSize {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		batch = append(batch, action)
+		size += actionSize
+	}
+
+	return append(batches, batch)
+}
+
+// commitConflictError returns a *ConflictError if the error is caused by a
+// file that changed since its last commit ID was pinned.
+func commitConflictError(err error) error {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) && strings.Contains(validationErr.Message, "has changed since") {
+		return &ConflictError{ErrorResponse: validationErr.ErrorResponse}
+	}
+	return err
+}
@@ -0,0 +1,242 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handleFileMetaData serves the metadata of the given files, mapping their
+// paths to their last commit IDs.
+func handleFileMetaData(t *testing.T, mux *http.ServeMux, files map[string]string) {
+	mux.HandleFunc("/api/v4/projects/1/repository/files/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodHead)
+		assert.Equal(t, "main", r.URL.Query().Get("ref"))
+
+		path := strings.TrimPrefix(r.URL.Path, "/api/v4/projects/1/repository/files/")
+		id, ok := files[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Gitlab-File-Path", path)
+		w.Header().Set("X-Gitlab-Last-Commit-Id", id)
+	})
+}
+
+func TestCommitBuilder(t *testing.T) {
+	mux, client := setup(t)
+
+	handleFileMetaData(t, mux, map[string]string{
+		"README.md":    "aaa",
+		"docs/old.md":  "bbb",
+		"obsolete.txt": "ccc",
+		"run.sh":       "ddd",
+	})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var opt CreateCommitOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opt))
+
+		assert.Equal(t, "main", *opt.Branch)
+		assert.Equal(t, "Update files", *opt.CommitMessage)
+		assert.Equal(t, []*CommitActionOptions{
+			{
+				Action:       FileAction(FileUpdate),
+				FilePath:     Ptr("README.md"),
+				Content:      Ptr("# Project\n"),
+				LastCommitID: Ptr("aaa"),
+			},
+			{
+				Action:   FileAction(FileCreate),
+				FilePath: Ptr("logo.png"),
+				Content:  Ptr("iVBORw0KGgo="),
+				Encoding: Ptr("base64"),
+			},
+			{
+				Action:       FileAction(FileMove),
+				FilePath:     Ptr("docs/new.md"),
+				PreviousPath: Ptr("docs/old.md"),
+				LastCommitID: Ptr("bbb"),
+			},
+			{
+				Action:       FileAction(FileDelete),
+				FilePath:     Ptr("obsolete.txt"),
+				LastCommitID: Ptr("ccc"),
+			},
+			{
+				Action:          FileAction(FileChmod),
+				FilePath:        Ptr("run.sh"),
+				LastCommitID:    Ptr("ddd"),
+				ExecuteFilemode: Ptr(true),
+			},
+		}, opt.Actions)
+
+		fmt.Fprint(w, `{"id":"eee"}`)
+	})
+
+	b := client.Commits.NewCommitBuilder(1, "main").
+		Put("README.md", []byte("# Project\n")).
+		Put("logo.png", []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}).
+		Move("docs/old.md", "docs/new.md").
+		Delete("obsolete.txt").
+		Chmod("run.sh", true)
+	assert.Equal(t, 5, b.Len())
+
+	commits, _, err := b.Commit(&CommitBuilderOptions{CommitMessage: Ptr("Update files")})
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, "eee", commits[0].ID)
+	assert.Equal(t, 0, b.Len())
+
+	_, _, err = b.Commit(nil)
+	assert.Error(t, err)
+}
+
+func TestCommitBuilderSplitsPayload(t *testing.T) {
+	mux, client := setup(t)
+
+	handleFileMetaData(t, mux, map[string]string{"a.txt": "aaa"})
+
+	var messages []string
+	var actions [][]*CommitActionOptions
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		var opt CreateCommitOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opt))
+
+		messages = append(messages, *opt.CommitMessage)
+		actions = append(actions, opt.Actions)
+
+		fmt.Fprintf(w, `{"id":"commit-%d"}`, len(messages))
+	})
+
+	commits, _, err := client.Commits.NewCommitBuilder(1, "main").
+		Put("a.txt", []byte(strings.Repeat("a", 60))).
+		Put("b.txt", []byte(strings.Repeat("b", 30))).
+		Put("c.txt", []byte(strings.Repeat("c", 30))).
+		Move("a.txt", "d.txt").
+		Commit(&CommitBuilderOptions{
+			CommitMessage:  Ptr("Add files"),
+			MaxPayloadSize: Ptr(100),
+		})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	assert.Equal(t, []string{"Add files (1/2)", "Add files (2/2)"}, messages)
+	require.Len(t, actions[0], 2)
+	require.Len(t, actions[1], 2)
+	assert.Equal(t, "aaa", *actions[0][0].LastCommitID)
+
+	// The move applies to a file changed by the first commit.
+	assert.Equal(t, "a.txt", *actions[1][1].PreviousPath)
+	assert.Equal(t, "commit-1", *actions[1][1].LastCommitID)
+}
+
+func TestCommitBuilderOnePendingOperationPerPath(t *testing.T) {
+	mux, client := setup(t)
+
+	handleFileMetaData(t, mux, map[string]string{"README.md": "aaa"})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		var opt CreateCommitOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opt))
+
+		assert.Equal(t, []*CommitActionOptions{
+			{
+				Action:   FileAction(FileCreate),
+				FilePath: Ptr("new.txt"),
+				Content:  Ptr("second\n"),
+			},
+			{
+				Action:       FileAction(FileDelete),
+				FilePath:     Ptr("README.md"),
+				LastCommitID: Ptr("aaa"),
+			},
+			{
+				Action:          FileAction(FileCreate),
+				FilePath:        Ptr("run.sh"),
+				Content:         Ptr("#!/bin/sh\n"),
+				ExecuteFilemode: Ptr(true),
+			},
+		}, opt.Actions)
+
+		fmt.Fprint(w, `{"id":"bbb"}`)
+	})
+
+	// The last write wins, and a put followed by a delete of an existing
+	// file still deletes it.
+	b := client.Commits.NewCommitBuilder(1, "main").
+		Put("new.txt", []byte("first\n")).
+		Put("new.txt", []byte("second\n")).
+		Put("README.md", []byte("# Project\n")).
+		Delete("README.md").
+		Put("run.sh", []byte("#!/bin/sh\n")).
+		Chmod("run.sh", true)
+	assert.Equal(t, 3, b.Len())
+
+	commits, _, err := b.Commit(nil)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+}
+
+func TestCommitBuilderPutDeleteNewFile(t *testing.T) {
+	mux, client := setup(t)
+
+	handleFileMetaData(t, mux, map[string]string{})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unexpected commit")
+	})
+
+	// Putting and deleting a new file cancels out.
+	b := client.Commits.NewCommitBuilder(1, "main").
+		Put("new.txt", []byte("new\n")).
+		Delete("new.txt")
+	assert.Equal(t, 1, b.Len())
+
+	commits, _, err := b.Commit(nil)
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestCommitBuilderConflict(t *testing.T) {
+	mux, client := setup(t)
+
+	handleFileMetaData(t, mux, map[string]string{"README.md": "aaa"})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"You are attempting to update a file that has changed since you started editing it."}`)
+	})
+
+	_, _, err := client.Commits.NewCommitBuilder(1, "main").
+		Put("README.md", []byte("# Project\n")).
+		Commit(nil)
+
+	var conflictErr *ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+}
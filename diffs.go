@@ -0,0 +1,294 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiffLineTypeValue represents the type of a line in a diff.
+type DiffLineTypeValue string
+
+// The available diff line types.
+const (
+	DiffLineContext DiffLineTypeValue = "context"
+	DiffLineAdded   DiffLineTypeValue = "added"
+	DiffLineRemoved DiffLineTypeValue = "removed"
+)
+
+// DiffHunk represents a hunk of a unified diff.
+type DiffHunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+
+	// Section is the text following the hunk header, usually the enclosing
+	// function.
+	Section string
+
+	Lines []*DiffLine
+}
+
+// DiffLine represents a line of a unified diff.
+type DiffLine struct {
+	Type    DiffLineTypeValue
+	Content string
+
+	// OldLine is the line number in the old file, or 0 for added lines.
+	OldLine int
+
+	// NewLine is the line number in the new file, or 0 for removed lines.
+	NewLine int
+
+	// oldPos and newPos are the positions of the line in the old and new
+	// file, also for lines that don't exist in one of them. They are used to
+	// build line codes.
+	oldPos int
+	newPos int
+}
+
+var diffHunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// ParseDiff parses the diff of a single file, as returned in the Diff field
+// of Diff and MergeRequestDiff, into hunks. Any file headers preceding the
+// first hunk are ignored.
+func ParseDiff(diff string) ([]*DiffHunk, error) {
+	var hunks []*DiffHunk
+	var hunk *DiffHunk
+	var oldPos, newPos, oldLeft, newLeft int
+
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "@@") {
+			if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+				return nil, fmt.Errorf("line %d: hunk ends prematurely", i+1)
+			}
+
+			m := diffHunkHeader.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: invalid hunk header %q", i+1, line)
+			}
+
+			hunk = &DiffHunk{
+				OldStart: atoiDefault(m[1], 1),
+				OldLines: atoiDefault(m[2], 1),
+				NewStart: atoiDefault(m[3], 1),
+				NewLines: atoiDefault(m[4], 1),
+				Section:  m[5],
+			}
+			hunks = append(hunks, hunk)
+
+			oldPos, newPos = hunk.OldStart, hunk.NewStart
+			if hunk.OldLines == 0 {
+				oldPos++
+			}
+			if hunk.NewLines == 0 {
+				newPos++
+			}
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+
+			continue
+		}
+
+		// Skip file headers and markers like "\ No newline at end of file".
+		if hunk == nil || strings.HasPrefix(line, `\`) {
+			continue
+		}
+		if oldLeft == 0 && newLeft == 0 {
+			return nil, fmt.Errorf("line %d: unexpected line outside of hunk", i+1)
+		}
+
+		l := &DiffLine{oldPos: oldPos, newPos: newPos}
+		switch {
+		case strings.HasPrefix(line, "+"):
+			l.Type = DiffLineAdded
+			l.NewLine = newPos
+			newPos++
+			newLeft--
+		case strings.HasPrefix(line, "-"):
+			l.Type = DiffLineRemoved
+			l.OldLine = oldPos
+			oldPos++
+			oldLeft--
+		case line == "" || strings.HasPrefix(line, " "):
+			// Some tools strip the space of empty context lines.
+			l.Type = DiffLineContext
+			l.OldLine, l.NewLine = oldPos, newPos
+			oldPos++
+			newPos++
+			oldLeft--
+			newLeft--
+		default:
+			return nil, fmt.Errorf("line %d: invalid diff line %q", i+1, line)
+		}
+		if oldLeft < 0 || newLeft < 0 {
+			return nil, fmt.Errorf("line %d: hunk contains more lines than its header", i+1)
+		}
+		if line != "" {
+			l.Content = line[1:]
+		}
+
+		hunk.Lines = append(hunk.Lines, l)
+	}
+
+	if oldLeft > 0 || newLeft > 0 {
+		return nil, errors.New("hunk ends prematurely")
+	}
+
+	return hunks, nil
+}
+
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// findDiffLine returns the line with the given number in the old or new file.
+// Lines that are not part of a hunk are unchanged, and are returned as
+// context lines.
+func findDiffLine(hunks []*DiffHunk, line int, inNew bool) *DiffLine {
+	offset := 0
+	for _, h := range hunks {
+		oldFirst, newFirst := h.OldStart, h.NewStart
+		if h.OldLines == 0 {
+			oldFirst++
+		}
+		if h.NewLines == 0 {
+			newFirst++
+		}
+
+		first, count := oldFirst, h.OldLines
+		if inNew {
+			first, count = newFirst, h.NewLines
+		}
+		if line < first {
+			break
+		}
+		if line < first+count {
+			for _, l := range h.Lines {
+				if (inNew && l.NewLine == line) || (!inNew && l.OldLine == line) {
+					return l
+				}
+			}
+			return nil
+		}
+
+		offset = (newFirst + h.NewLines) - (oldFirst + h.OldLines)
+	}
+
+	oldLine, newLine := line, line+offset
+	if inNew {
+		oldLine, newLine = line-offset, line
+	}
+
+	return &DiffLine{
+		Type:    DiffLineContext,
+		OldLine: oldLine,
+		NewLine: newLine,
+		oldPos:  oldLine,
+		newPos:  newLine,
+	}
+}
+
+// DiffLineCode returns the line code GitLab uses to identify a line of the
+// file at the given path in a diff.
+func DiffLineCode(path string, oldLine, newLine int) string {
+	sum := sha1.Sum([]byte(path))
+	return fmt.Sprintf("%s_%d_%d", hex.EncodeToString(sum[:]), oldLine, newLine)
+}
+
+// NewLinePosition returns the position of a diff note on the given line of
+// the new version of the file at the given path. The line doesn't need to be
+// part of a hunk, as long as it exists in the file.
+//
+// Example usage:
+//
+//	version, _, err := client.MergeRequests.GetSingleMergeRequestDiffVersion("group/project", 1, 2, nil)
+//	position, err := version.NewLinePosition("main.go", 42)
+//	_, _, err = client.Discussions.CreateMergeRequestDiscussion("group/project", 1, &gitlab.CreateMergeRequestDiscussionOptions{
+//	    Body:     gitlab.Ptr("Consider handling this error."),
+//	    Position: position,
+//	})
+func (v *MergeRequestDiffVersion) NewLinePosition(path string, line int) (*PositionOptions, error) {
+	return v.linePosition(path, line, true)
+}
+
+// OldLinePosition returns the position of a diff note on the given line of
+// the old version of the file at the given path, which is needed to comment
+// on removed lines.
+func (v *MergeRequestDiffVersion) OldLinePosition(path string, line int) (*PositionOptions, error) {
+	return v.linePosition(path, line, false)
+}
+
+func (v *MergeRequestDiffVersion) linePosition(path string, line int, inNew bool) (*PositionOptions, error) {
+	for _, d := range v.Diffs {
+		if (inNew && d.NewPath != path) || (!inNew && d.OldPath != path) {
+			continue
+		}
+		if (inNew && d.DeletedFile) || (!inNew && d.NewFile) || line < 1 {
+			return nil, fmt.Errorf("line %d of %s is not part of diff version %d", line, path, v.ID)
+		}
+
+		hunks, err := ParseDiff(d.Diff)
+		if err != nil {
+			return nil, fmt.Errorf("parsing diff of %s: %v", path, err)
+		}
+
+		l := findDiffLine(hunks, line, inNew)
+		if l == nil {
+			return nil, fmt.Errorf("line %d of %s is not part of diff version %d", line, path, v.ID)
+		}
+
+		position := &PositionOptions{
+			BaseSHA:      Ptr(v.BaseCommitSHA),
+			HeadSHA:      Ptr(v.HeadCommitSHA),
+			StartSHA:     Ptr(v.StartCommitSHA),
+			OldPath:      Ptr(d.OldPath),
+			NewPath:      Ptr(d.NewPath),
+			PositionType: Ptr("text"),
+		}
+		if l.OldLine > 0 {
+			position.OldLine = Ptr(l.OldLine)
+		}
+		if l.NewLine > 0 {
+			position.NewLine = Ptr(l.NewLine)
+		}
+
+		lp := &LinePositionOptions{LineCode: Ptr(DiffLineCode(d.NewPath, l.oldPos, l.newPos))}
+		switch l.Type {
+		case DiffLineAdded:
+			lp.Type = Ptr("new")
+		case DiffLineRemoved:
+			lp.Type = Ptr("old")
+		}
+		position.LineRange = &LineRangeOptions{Start: lp, End: lp}
+
+		return position, nil
+	}
+
+	return nil, fmt.Errorf("%s is not part of diff version %d", path, v.ID)
+}
@@ -0,0 +1,165 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDiff = `--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@ package main
+ import "fmt"
+-func old() {}
++func a() {}
++func b() {}
+
+ func main() {
+@@ -10,2 +11,3 @@ func main() {
+ 	fmt.Println("hello")
++	fmt.Println("world")
+ }
+\ No newline at end of file
+`
+
+func TestParseDiff(t *testing.T) {
+	hunks, err := ParseDiff(testDiff)
+	require.NoError(t, err)
+	require.Len(t, hunks, 2)
+
+	assert.Equal(t, 1, hunks[0].OldStart)
+	assert.Equal(t, 4, hunks[0].OldLines)
+	assert.Equal(t, 1, hunks[0].NewStart)
+	assert.Equal(t, 5, hunks[0].NewLines)
+	assert.Equal(t, "package main", hunks[0].Section)
+
+	type line struct {
+		Type    DiffLineTypeValue
+		Content string
+		OldLine int
+		NewLine int
+	}
+	var lines []line
+	for _, l := range hunks[0].Lines {
+		lines = append(lines, line{l.Type, l.Content, l.OldLine, l.NewLine})
+	}
+	assert.Equal(t, []line{
+		{DiffLineContext, `import "fmt"`, 1, 1},
+		{DiffLineRemoved, "func old() {}", 2, 0},
+		{DiffLineAdded, "func a() {}", 0, 2},
+		{DiffLineAdded, "func b() {}", 0, 3},
+		{DiffLineContext, "", 3, 4},
+		{DiffLineContext, "func main() {", 4, 5},
+	}, lines)
+
+	require.Len(t, hunks[1].Lines, 3)
+	assert.Equal(t, 12, hunks[1].Lines[1].NewLine)
+	assert.Equal(t, 11, hunks[1].Lines[2].OldLine)
+	assert.Equal(t, 13, hunks[1].Lines[2].NewLine)
+}
+
+func TestParseDiffInvalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid header": "@@ -1 +1 @\n-a\n+b\n",
+		"too short":      "@@ -1,2 +1,2 @@\n a\n",
+		"too long":       "@@ -1 +1 @@\n a\n b\n",
+		"invalid line":   "@@ -1 +1 @@\n*a\n",
+	}
+
+	for name, diff := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseDiff(diff)
+			assert.Error(t, err)
+		})
+	}
+
+	hunks, err := ParseDiff("")
+	require.NoError(t, err)
+	assert.Empty(t, hunks)
+}
+
+func TestMergeRequestDiffVersionPosition(t *testing.T) {
+	version := &MergeRequestDiffVersion{
+		ID:             2,
+		BaseCommitSHA:  "base",
+		HeadCommitSHA:  "head",
+		StartCommitSHA: "start",
+		Diffs: []*Diff{
+			{OldPath: "main.go", NewPath: "main.go", Diff: testDiff},
+			{OldPath: "new.go", NewPath: "new.go", Diff: "@@ -0,0 +1 @@\n+package main\n", NewFile: true},
+		},
+	}
+	code := func(oldLine, newLine int) *string {
+		return Ptr(DiffLineCode("main.go", oldLine, newLine))
+	}
+
+	// An added line.
+	position, err := version.NewLinePosition("main.go", 3)
+	require.NoError(t, err)
+	assert.Equal(t, &PositionOptions{
+		BaseSHA:      Ptr("base"),
+		HeadSHA:      Ptr("head"),
+		StartSHA:     Ptr("start"),
+		OldPath:      Ptr("main.go"),
+		NewPath:      Ptr("main.go"),
+		PositionType: Ptr("text"),
+		NewLine:      Ptr(3),
+		LineRange: &LineRangeOptions{
+			Start: &LinePositionOptions{LineCode: code(3, 3), Type: Ptr("new")},
+			End:   &LinePositionOptions{LineCode: code(3, 3), Type: Ptr("new")},
+		},
+	}, position)
+
+	// A removed line.
+	position, err = version.OldLinePosition("main.go", 2)
+	require.NoError(t, err)
+	assert.Nil(t, position.NewLine)
+	assert.Equal(t, 2, *position.OldLine)
+	assert.Equal(t, code(2, 2), position.LineRange.Start.LineCode)
+	assert.Equal(t, "old", *position.LineRange.Start.Type)
+
+	// A context line.
+	position, err = version.NewLinePosition("main.go", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 4, *position.OldLine)
+	assert.Equal(t, 5, *position.NewLine)
+	assert.Nil(t, position.LineRange.Start.Type)
+
+	// Unchanged lines between and after the hunks.
+	position, err = version.NewLinePosition("main.go", 8)
+	require.NoError(t, err)
+	assert.Equal(t, 7, *position.OldLine)
+	assert.Equal(t, 8, *position.NewLine)
+
+	position, err = version.OldLinePosition("main.go", 20)
+	require.NoError(t, err)
+	assert.Equal(t, 20, *position.OldLine)
+	assert.Equal(t, 22, *position.NewLine)
+
+	position, err = version.NewLinePosition("new.go", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, *position.NewLine)
+
+	_, err = version.OldLinePosition("new.go", 1)
+	assert.Error(t, err)
+
+	_, err = version.NewLinePosition("missing.go", 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NotFoundError is returned when the requested resource does not exist or
+// is not visible to the authenticated user. It satisfies
+// errors.Is(err, ErrNotFound).
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/rest/index.html#status-codes
+type NotFoundError struct {
+	*ErrorResponse
+}
+
+func (e *NotFoundError) Error() string {
+	return ErrNotFound.Error()
+}
+
+// Is reports whether the target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Unwrap returns the underlying *ErrorResponse.
+func (e *NotFoundError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// ValidationError is returned when GitLab rejects a request because of
+// invalid or missing attributes.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/rest/index.html#data-validation-and-error-reporting
+type ValidationError struct {
+	*ErrorResponse
+
+	// Fields contains the error messages per attribute. Attributes of
+	// embedded entities are joined using a dot, for example "namespace.path".
+	Fields map[string][]string
+}
+
+// Unwrap returns the underlying *ErrorResponse.
+func (e *ValidationError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// ForbiddenError is returned when the authenticated user is not allowed to
+// perform the request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/rest/index.html#status-codes
+type ForbiddenError struct {
+	*ErrorResponse
+}
+
+// Unwrap returns the underlying *ErrorResponse.
+func (e *ForbiddenError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// ConflictError is returned when the request conflicts with the current state
+// of the resource, for example when it already exists.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/rest/index.html#status-codes
+type ConflictError struct {
+	*ErrorResponse
+}
+
+// Unwrap returns the underlying *ErrorResponse.
+func (e *ConflictError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// RateLimitError is returned when the request was rate limited and all
+// retries (if any) were exhausted.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/administration/settings/user_and_ip_rate_limits.html#response-headers
+type RateLimitError struct {
+	*ErrorResponse
+
+	// Limit is the number of requests allowed per minute, if known.
+	Limit int
+
+	// ResetAt is the time at which the rate limit resets, if known.
+	ResetAt time.Time
+}
+
+// Unwrap returns the underlying *ErrorResponse.
+func (e *RateLimitError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// newTypedError wraps the given error response in a typed error based on
+// the status code of the response. The raw value is the decoded JSON body.
+func newTypedError(errResp *ErrorResponse, raw interface{}) error {
+	switch errResp.Response.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{
+			ErrorResponse: errResp,
+			Fields:        parseValidationFields(raw),
+		}
+	case http.StatusForbidden:
+		return &ForbiddenError{ErrorResponse: errResp}
+	case http.StatusNotFound:
+		return &NotFoundError{ErrorResponse: errResp}
+	case http.StatusConflict:
+		return &ConflictError{ErrorResponse: errResp}
+	case http.StatusTooManyRequests:
+		e := &RateLimitError{ErrorResponse: errResp}
+		header := errResp.Response.Header
+		if v := header.Get(headerRateLimit); v != "" {
+			e.Limit, _ = strconv.Atoi(v)
+		}
+		if v := header.Get(headerRateReset); v != "" {
+			if reset, _ := strconv.ParseInt(v, 10, 64); reset > 0 {
+				e.ResetAt = time.Unix(reset, 0)
+			}
+		} else if v := header.Get("Retry-After"); v != "" {
+			if seconds, _ := strconv.Atoi(v); seconds > 0 {
+				e.ResetAt = time.Now().Add(time.Duration(seconds) * time.Second)
+			}
+		}
+		return e
+	default:
+		return errResp
+	}
+}
+
+// parseValidationFields collects the per attribute error messages from the
+// "message" object of an error response.
+func parseValidationFields(raw interface{}) map[string][]string {
+	fields := make(map[string][]string)
+
+	if body, ok := raw.(map[string]interface{}); ok {
+		if message, ok := body["message"].(map[string]interface{}); ok {
+			collectValidationFields(fields, "", message)
+		}
+	}
+
+	return fields
+}
+
+func collectValidationFields(fields map[string][]string, prefix string, raw map[string]interface{}) {
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		name := k
+		if prefix != "" {
+			name = strings.Join([]string{prefix, k}, ".")
+		}
+
+		switch v := raw[k].(type) {
+		case map[string]interface{}:
+			collectValidationFields(fields, name, v)
+		case []interface{}:
+			for _, msg := range v {
+				fields[name] = append(fields[name], parseError(msg))
+			}
+		default:
+			fields[name] = append(fields[name], parseError(v))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotFoundError(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "01HXYZ")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"404 Project Not Found"}`)
+	})
+
+	_, _, err := client.Projects.GetProject(1, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, "404 Not Found", err.Error())
+
+	var notFound *NotFoundError
+	require.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "{message: 404 Project Not Found}", notFound.Message)
+	assert.Equal(t, "01HXYZ", notFound.RequestID)
+
+	var errResp *ErrorResponse
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, http.StatusNotFound, errResp.Response.StatusCode)
+	assert.Equal(t, []byte(`{"message":"404 Project Not Found"}`), errResp.Body)
+}
+
+func TestValidationError(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{
+			"message": {
+				"name": ["has already been taken"],
+				"path": ["has already been taken", "is reserved"],
+				"namespace": {"path": ["is invalid"]}
+			}
+		}`)
+	})
+
+	_, _, err := client.Projects.CreateProject(&CreateProjectOptions{Name: Ptr("test")})
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, map[string][]string{
+		"name":           {"has already been taken"},
+		"namespace.path": {"is invalid"},
+		"path":           {"has already been taken", "is reserved"},
+	}, validationErr.Fields)
+	assert.Contains(t, err.Error(), "400 {message: {name: [has already been taken]}")
+}
+
+func TestCheckResponseTypedErrors(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+
+	tests := []struct {
+		status int
+		header http.Header
+		check  func(t *testing.T, err error)
+	}{
+		{
+			status: http.StatusForbidden,
+			check: func(t *testing.T, err error) {
+				var e *ForbiddenError
+				assert.True(t, errors.As(err, &e))
+			},
+		},
+		{
+			status: http.StatusConflict,
+			check: func(t *testing.T, err error) {
+				var e *ConflictError
+				assert.True(t, errors.As(err, &e))
+			},
+		},
+		{
+			status: http.StatusTooManyRequests,
+			header: http.Header{
+				"Ratelimit-Limit": []string{"600"},
+				"Ratelimit-Reset": []string{fmt.Sprint(reset.Unix())},
+			},
+			check: func(t *testing.T, err error) {
+				var e *RateLimitError
+				require.True(t, errors.As(err, &e))
+				assert.Equal(t, 600, e.Limit)
+				assert.True(t, reset.Equal(e.ResetAt))
+			},
+		},
+		{
+			status: http.StatusInternalServerError,
+			check: func(t *testing.T, err error) {
+				_, ok := err.(*ErrorResponse)
+				assert.True(t, ok)
+			},
+		},
+	}
+
+	c, err := NewClient("")
+	require.NoError(t, err)
+
+	req, err := c.NewRequest(http.MethodGet, "test", nil, nil)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			header := tt.header
+			if header == nil {
+				header = make(http.Header)
+			}
+			err := CheckResponse(&http.Response{
+				Request:    req.Request,
+				StatusCode: tt.status,
+				Header:     header,
+			})
+			require.Error(t, err)
+			tt.check(t, err)
+		})
+	}
+}
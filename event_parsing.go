@@ -18,7 +18,6 @@ package gitlab
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -31,6 +30,7 @@ const (
 	EventConfidentialNote        EventType = "Confidential Note Hook"
 	EventTypeBuild               EventType = "Build Hook"
 	EventTypeDeployment          EventType = "Deployment Hook"
+	EventTypeEmoji               EventType = "Emoji Hook"
 	EventTypeFeatureFlag         EventType = "Feature Flag Hook"
 	EventTypeIssue               EventType = "Issue Hook"
 	EventTypeJob                 EventType = "Job Hook"
@@ -45,6 +45,7 @@ const (
 	EventTypeSubGroup            EventType = "Subgroup Hook"
 	EventTypeSystemHook          EventType = "System Hook"
 	EventTypeTagPush             EventType = "Tag Push Hook"
+	EventTypeVulnerability       EventType = "Vulnerability Hook"
 	EventTypeWikiPage            EventType = "Wiki Page Hook"
 )
 
@@ -113,8 +114,8 @@ func ParseHook(eventType EventType, payload []byte) (event interface{}, err erro
 }
 
 // ParseSystemhook parses the event payload. For recognized event types, a
-// value of the corresponding struct type will be returned. An error will be
-// returned for unrecognized event types.
+// value of the corresponding struct type will be returned. An *UnknownEvent
+// will be returned for unrecognized event types.
 //
 // Example usage:
 //
@@ -180,7 +181,11 @@ func ParseSystemhook(payload []byte) (event interface{}, err error) {
 		case string(MergeRequestEventTargetType):
 			event = &MergeEvent{}
 		default:
-			return nil, fmt.Errorf("unexpected system hook type %s", e.EventName)
+			kind := e.ObjectKind
+			if kind == "" {
+				kind = e.EventName
+			}
+			return newUnknownEvent(EventTypeSystemHook, kind, payload), nil
 		}
 	}
 
@@ -197,8 +202,8 @@ func WebhookEventType(r *http.Request) EventType {
 }
 
 // ParseWebhook parses the event payload. For recognized event types, a
-// value of the corresponding struct type will be returned. An error will
-// be returned for unrecognized event types.
+// value of the corresponding struct type will be returned. An *UnknownEvent
+// will be returned for unrecognized event types.
 //
 // Example usage:
 //
@@ -221,6 +226,8 @@ func ParseWebhook(eventType EventType, payload []byte) (event interface{}, err e
 		event = &BuildEvent{}
 	case EventTypeDeployment:
 		event = &DeploymentEvent{}
+	case EventTypeEmoji:
+		event = &EmojiEvent{}
 	case EventTypeFeatureFlag:
 		event = &FeatureFlagEvent{}
 	case EventTypeIssue, EventConfidentialIssue:
@@ -239,7 +246,7 @@ func ParseWebhook(eventType EventType, payload []byte) (event interface{}, err e
 		}
 
 		if note.ObjectKind != string(NoteEventTargetType) {
-			return nil, fmt.Errorf("unexpected object kind %s", note.ObjectKind)
+			return newUnknownEvent(eventType, note.ObjectKind, payload), nil
 		}
 
 		switch note.ObjectAttributes.NoteableType {
@@ -252,7 +259,7 @@ func ParseWebhook(eventType EventType, payload []byte) (event interface{}, err e
 		case noteableTypeSnippet:
 			event = &SnippetCommentEvent{}
 		default:
-			return nil, fmt.Errorf("unexpected noteable type %s", note.ObjectAttributes.NoteableType)
+			return newUnknownEvent(eventType, note.ObjectKind, payload), nil
 		}
 	case EventTypePipeline:
 		event = &PipelineEvent{}
@@ -276,7 +283,7 @@ func ParseWebhook(eventType EventType, payload []byte) (event interface{}, err e
 		case projectEvent:
 			event = &ProjectResourceAccessTokenEvent{}
 		default:
-			return nil, fmt.Errorf("unexpected resource access token payload")
+			return parseUnknownEvent(eventType, payload)
 		}
 	case EventTypeServiceHook:
 		service := &serviceEvent{}
@@ -292,16 +299,18 @@ func ParseWebhook(eventType EventType, payload []byte) (event interface{}, err e
 		case eventObjectKindMergeRequest:
 			event = &MergeEvent{}
 		default:
-			return nil, fmt.Errorf("unexpected service type %s", service.ObjectKind)
+			return newUnknownEvent(eventType, service.ObjectKind, payload), nil
 		}
 	case EventTypeSubGroup:
 		event = &SubGroupEvent{}
 	case EventTypeTagPush:
 		event = &TagEvent{}
+	case EventTypeVulnerability:
+		event = &VulnerabilityEvent{}
 	case EventTypeWikiPage:
 		event = &WikiPageEvent{}
 	default:
-		return nil, fmt.Errorf("unexpected event type: %s", eventType)
+		return parseUnknownEvent(eventType, payload)
 	}
 
 	if err := json.Unmarshal(payload, event); err != nil {
@@ -310,3 +319,22 @@ func ParseWebhook(eventType EventType, payload []byte) (event interface{}, err e
 
 	return event, nil
 }
+
+// parseUnknownEvent returns an *UnknownEvent for the given payload, or an
+// error if the payload is not valid JSON.
+func parseUnknownEvent(eventType EventType, payload []byte) (*UnknownEvent, error) {
+	e := &serviceEvent{}
+	if err := json.Unmarshal(payload, e); err != nil {
+		return nil, err
+	}
+	return newUnknownEvent(eventType, e.ObjectKind, payload), nil
+}
+
+// newUnknownEvent returns an *UnknownEvent for the given payload.
+func newUnknownEvent(eventType EventType, objectKind string, payload []byte) *UnknownEvent {
+	return &UnknownEvent{
+		Type:       eventType,
+		ObjectKind: objectKind,
+		Raw:        append(json.RawMessage(nil), payload...),
+	}
+}
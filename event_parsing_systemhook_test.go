@@ -216,3 +216,21 @@ func TestParseHookSystemHook(t *testing.T) {
 	}
 	assert.Equal(t, parsedEvent1, parsedEvent2)
 }
+
+func TestParseSystemhookUnknown(t *testing.T) {
+	raw := []byte(`{"event_name":"gpg_key_create","id":1}`)
+
+	parsedEvent, err := ParseSystemhook(raw)
+	if err != nil {
+		t.Errorf("Error parsing unknown system hook: %s", err)
+	}
+
+	event, ok := parsedEvent.(*UnknownEvent)
+	if !ok {
+		t.Errorf("Expected UnknownEvent, but parsing produced %T", parsedEvent)
+	}
+
+	assert.Equal(t, EventTypeSystemHook, event.Type)
+	assert.Equal(t, "gpg_key_create", event.ObjectKind)
+	assert.JSONEq(t, string(raw), string(event.Raw))
+}
@@ -114,6 +114,29 @@ func TestParseCommitCommentHook(t *testing.T) {
 	}
 }
 
+func TestParseEmojiHook(t *testing.T) {
+	raw := loadFixture(t, "testdata/webhooks/emoji.json")
+
+	parsedEvent, err := ParseWebhook("Emoji Hook", raw)
+	if err != nil {
+		t.Errorf("Error parsing emoji hook: %s", err)
+	}
+
+	event, ok := parsedEvent.(*EmojiEvent)
+	if !ok {
+		t.Errorf("Expected EmojiEvent, but parsing produced %T", parsedEvent)
+	}
+
+	assert.Equal(t, "emoji", event.ObjectKind)
+	assert.Equal(t, "award", event.EventType)
+	assert.Equal(t, "thumbsup", event.ObjectAttributes.Name)
+	assert.Equal(t, "Note", event.ObjectAttributes.AwardableType)
+	assert.Equal(t, 363, event.ObjectAttributes.AwardableID)
+	assert.Equal(t, "Testing 123", event.Note.Note)
+	assert.Equal(t, 42, event.Issue.IID)
+	assert.Nil(t, event.MergeRequest)
+}
+
 func TestParseFeatureFlagHook(t *testing.T) {
 	raw := loadFixture(t, "testdata/webhooks/feature_flag.json")
 
@@ -565,6 +588,54 @@ func TestParseTagHook(t *testing.T) {
 	}
 }
 
+func TestParseUnknownHook(t *testing.T) {
+	raw := []byte(`{"object_kind":"milestone","object_attributes":{"id":1}}`)
+
+	parsedEvent, err := ParseWebhook("Milestone Hook", raw)
+	if err != nil {
+		t.Errorf("Error parsing unknown hook: %s", err)
+	}
+
+	event, ok := parsedEvent.(*UnknownEvent)
+	if !ok {
+		t.Errorf("Expected UnknownEvent, but parsing produced %T", parsedEvent)
+	}
+
+	assert.Equal(t, EventType("Milestone Hook"), event.Type)
+	assert.Equal(t, "milestone", event.ObjectKind)
+	assert.JSONEq(t, string(raw), string(event.Raw))
+
+	parsedEvent, err = ParseWebhook("Service Hook", []byte(`{"object_kind":"unknown"}`))
+	assert.NoError(t, err)
+	assert.IsType(t, &UnknownEvent{}, parsedEvent)
+
+	_, err = ParseWebhook("Milestone Hook", []byte(`{"object_kind":`))
+	assert.Error(t, err)
+}
+
+func TestParseVulnerabilityHook(t *testing.T) {
+	raw := loadFixture(t, "testdata/webhooks/vulnerability.json")
+
+	parsedEvent, err := ParseWebhook("Vulnerability Hook", raw)
+	if err != nil {
+		t.Errorf("Error parsing vulnerability hook: %s", err)
+	}
+
+	event, ok := parsedEvent.(*VulnerabilityEvent)
+	if !ok {
+		t.Errorf("Expected VulnerabilityEvent, but parsing produced %T", parsedEvent)
+	}
+
+	assert.Equal(t, "vulnerability", event.ObjectKind)
+	assert.Equal(t, "REXML DoS vulnerability", event.ObjectAttributes.Title)
+	assert.Equal(t, "high", event.ObjectAttributes.Severity)
+	assert.Equal(t, "rexml", event.ObjectAttributes.Location.Dependency.Package.Name)
+	assert.Len(t, event.ObjectAttributes.Identifiers, 2)
+	assert.Equal(t, "CVE-2024-41123", event.ObjectAttributes.Identifiers[1].ExternalID)
+	assert.Equal(t, 1, event.ObjectAttributes.ConfirmedByID)
+	assert.Nil(t, event.ObjectAttributes.DismissedAt)
+}
+
 func TestParseWikiPageHook(t *testing.T) {
 	raw := loadFixture(t, "testdata/webhooks/wiki_page.json")
 
@@ -172,6 +172,105 @@ type DeploymentEvent struct {
 	CommitTitle string     `json:"commit_title"`
 }
 
+// EmojiEvent represents an emoji event.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#emoji-events
+type EmojiEvent struct {
+	ObjectKind string     `json:"object_kind"`
+	EventType  string     `json:"event_type"`
+	User       *EventUser `json:"user"`
+	ProjectID  int        `json:"project_id"`
+	Project    struct {
+		ID                int     `json:"id"`
+		Name              string  `json:"name"`
+		Description       string  `json:"description"`
+		WebURL            string  `json:"web_url"`
+		AvatarURL         *string `json:"avatar_url"`
+		GitSSHURL         string  `json:"git_ssh_url"`
+		GitHTTPURL        string  `json:"git_http_url"`
+		Namespace         string  `json:"namespace"`
+		VisibilityLevel   int     `json:"visibility_level"`
+		PathWithNamespace string  `json:"path_with_namespace"`
+		DefaultBranch     string  `json:"default_branch"`
+		CIConfigPath      string  `json:"ci_config_path"`
+		Homepage          string  `json:"homepage"`
+		URL               string  `json:"url"`
+		SSHURL            string  `json:"ssh_url"`
+		HTTPURL           string  `json:"http_url"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		ID            int    `json:"id"`
+		UserID        int    `json:"user_id"`
+		Name          string `json:"name"`
+		AwardableType string `json:"awardable_type"`
+		AwardableID   int    `json:"awardable_id"`
+		CreatedAt     string `json:"created_at"` // Should be *time.Time (see Gitlab issue #21468)
+		UpdatedAt     string `json:"updated_at"` // Should be *time.Time (see Gitlab issue #21468)
+	} `json:"object_attributes"`
+	Note *struct {
+		ID           int    `json:"id"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+		NoteableID   int    `json:"noteable_id"`
+		AuthorID     int    `json:"author_id"`
+		ProjectID    int    `json:"project_id"`
+		CommitID     string `json:"commit_id"`
+		DiscussionID string `json:"discussion_id"`
+		System       bool   `json:"system"`
+		Description  string `json:"description"`
+		URL          string `json:"url"`
+		CreatedAt    string `json:"created_at"` // Should be *time.Time (see Gitlab issue #21468)
+		UpdatedAt    string `json:"updated_at"` // Should be *time.Time (see Gitlab issue #21468)
+	} `json:"note"`
+	Issue *struct {
+		ID          int    `json:"id"`
+		IID         int    `json:"iid"`
+		ProjectID   int    `json:"project_id"`
+		AuthorID    int    `json:"author_id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		State       string `json:"state"`
+		URL         string `json:"url"`
+		CreatedAt   string `json:"created_at"` // Should be *time.Time (see Gitlab issue #21468)
+		UpdatedAt   string `json:"updated_at"` // Should be *time.Time (see Gitlab issue #21468)
+	} `json:"issue"`
+	MergeRequest *struct {
+		ID           int    `json:"id"`
+		IID          int    `json:"iid"`
+		TargetBranch string `json:"target_branch"`
+		SourceBranch string `json:"source_branch"`
+		AuthorID     int    `json:"author_id"`
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		State        string `json:"state"`
+		URL          string `json:"url"`
+		CreatedAt    string `json:"created_at"` // Should be *time.Time (see Gitlab issue #21468)
+		UpdatedAt    string `json:"updated_at"` // Should be *time.Time (see Gitlab issue #21468)
+	} `json:"merge_request"`
+	Snippet *struct {
+		ID          int    `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		AuthorID    int    `json:"author_id"`
+		ProjectID   int    `json:"project_id"`
+		URL         string `json:"url"`
+		CreatedAt   string `json:"created_at"` // Should be *time.Time (see Gitlab issue #21468)
+		UpdatedAt   string `json:"updated_at"` // Should be *time.Time (see Gitlab issue #21468)
+	} `json:"snippet"`
+	Commit *struct {
+		ID        string     `json:"id"`
+		Title     string     `json:"title"`
+		Message   string     `json:"message"`
+		Timestamp *time.Time `json:"timestamp"`
+		URL       string     `json:"url"`
+		Author    struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
 // FeatureFlagEvent represents a feature flag event.
 //
 // GitLab API docs:
@@ -1209,6 +1308,69 @@ type TagEvent struct {
 	TotalCommitsCount int `json:"total_commits_count"`
 }
 
+// UnknownEvent represents an event of a type this package doesn't know
+// about (yet). It is returned by the parse functions instead of an error, so
+// receivers can ignore or handle new events without failing.
+type UnknownEvent struct {
+	Type       EventType       `json:"type"`
+	ObjectKind string          `json:"object_kind"`
+	Raw        json.RawMessage `json:"raw"`
+}
+
+// VulnerabilityEvent represents a vulnerability event.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#vulnerability-events
+type VulnerabilityEvent struct {
+	ObjectKind       string `json:"object_kind"`
+	ObjectAttributes struct {
+		URL       string `json:"url"`
+		Title     string `json:"title"`
+		State     string `json:"state"`
+		ProjectID int    `json:"project_id"`
+		Location  struct {
+			File       string `json:"file"`
+			StartLine  int    `json:"start_line"`
+			EndLine    int    `json:"end_line"`
+			Class      string `json:"class"`
+			Method     string `json:"method"`
+			Image      string `json:"image"`
+			Dependency struct {
+				Package struct {
+					Name string `json:"name"`
+				} `json:"package"`
+				Version string `json:"version"`
+			} `json:"dependency"`
+		} `json:"location"`
+		CVSS []struct {
+			Vector string `json:"vector"`
+			Vendor string `json:"vendor"`
+		} `json:"cvss"`
+		Severity           string `json:"severity"`
+		SeverityOverridden bool   `json:"severity_overridden"`
+		Identifiers        []struct {
+			Name         string `json:"name"`
+			ExternalID   string `json:"external_id"`
+			ExternalType string `json:"external_type"`
+			URL          string `json:"url"`
+		} `json:"identifiers"`
+		Issues []struct {
+			Title     string     `json:"title"`
+			URL       string     `json:"url"`
+			CreatedAt *time.Time `json:"created_at"`
+			UpdatedAt *time.Time `json:"updated_at"`
+		} `json:"issues"`
+		ReportType              string     `json:"report_type"`
+		ConfirmedAt             *time.Time `json:"confirmed_at"`
+		ConfirmedByID           int        `json:"confirmed_by_id"`
+		DismissedAt             *time.Time `json:"dismissed_at"`
+		DismissedByID           int        `json:"dismissed_by_id"`
+		ResolvedOnDefaultBranch bool       `json:"resolved_on_default_branch"`
+		CreatedAt               *time.Time `json:"created_at"`
+		UpdatedAt               *time.Time `json:"updated_at"`
+	} `json:"object_attributes"`
+}
+
 // WikiPageEvent represents a wiki page event.
 //
 // GitLab API docs:
@@ -17,6 +17,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/xanzy/go-gitlab"
@@ -99,3 +100,31 @@ func keysetPagination() {
 		}
 	}
 }
+
+func scanPagination() {
+	git, err := gitlab.NewClient("yourtokengoeshere")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opt := &gitlab.ListProjectsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: 10,
+		},
+		Owned: gitlab.Bool(true),
+	}
+
+	// Scan takes care of requesting the next page (using either offset-based
+	// or keyset-based pagination) until all pages are consumed.
+	projects := gitlab.Scan(context.Background(), func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+		return git.Projects.ListProjects(opt, options...)
+	})
+
+	projects(func(p *gitlab.Project, err error) bool {
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Found project: %s", p.Name)
+		return true
+	})
+}
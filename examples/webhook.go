@@ -1,29 +1,32 @@
 package main
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
+	"context"
 	"log"
 	"net/http"
-	"strings"
 
 	"github.com/xanzy/go-gitlab"
 )
 
-// webhook is a HTTP Handler for Gitlab Webhook events.
-type webhook struct {
-	Secret         string
-	EventsToAccept []gitlab.EventType
-}
-
-// webhookExample shows how to create a Webhook server to parse Gitlab events.
+// webhookExample shows how to create a Webhook server to handle Gitlab events.
 func webhookExample() {
-	wh := webhook{
-		Secret:         "your-gitlab-secret",
-		EventsToAccept: []gitlab.EventType{gitlab.EventTypeMergeRequest, gitlab.EventTypePipeline},
-	}
+	wh := gitlab.NewWebhookHandler(
+		"your-gitlab-secret",
+		gitlab.WithWebhookEvents(gitlab.EventTypeMergeRequest, gitlab.EventTypePipeline),
+		gitlab.WithWebhookErrorHandler(func(r *http.Request, err error) {
+			log.Printf("error handling the webhook event: %v", err)
+		}),
+	)
+
+	wh.OnMergeRequest(func(ctx context.Context, event *gitlab.MergeEvent) error {
+		log.Printf("merge request !%d was %s", event.ObjectAttributes.IID, event.ObjectAttributes.Action)
+		return nil
+	})
+
+	wh.OnPipeline(func(ctx context.Context, event *gitlab.PipelineEvent) error {
+		log.Printf("pipeline #%d is %s", event.ObjectAttributes.ID, event.ObjectAttributes.Status)
+		return nil
+	})
 
 	mux := http.NewServeMux()
 	mux.Handle("/webhook", wh)
@@ -31,87 +34,3 @@ func webhookExample() {
 		log.Fatalf("HTTP server ListenAndServe: %v", err)
 	}
 }
-
-// ServeHTTP tries to parse Gitlab events sent and calls handle function
-// with the successfully parsed events.
-func (hook webhook) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
-	event, err := hook.parse(request)
-	if err != nil {
-		writer.WriteHeader(500)
-		fmt.Fprintf(writer, "could parse the webhook event: %v", err)
-		return
-	}
-
-	// Handle the event before we return.
-	if err := hook.handle(event); err != nil {
-		writer.WriteHeader(500)
-		fmt.Fprintf(writer, "error handling the event: %v", err)
-		return
-	}
-
-	// Write a response when were done.
-	writer.WriteHeader(204)
-}
-
-func (hook webhook) handle(event interface{}) error {
-	str, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("could not marshal json event for logging: %v", err)
-	}
-
-	// Just write the event for this example.
-	fmt.Println(string(str))
-
-	return nil
-}
-
-// parse verifies and parses the events specified in the request and
-// returns the parsed event or an error.
-func (hook webhook) parse(r *http.Request) (interface{}, error) {
-	defer func() {
-		if _, err := io.Copy(io.Discard, r.Body); err != nil {
-			log.Printf("could discard request body: %v", err)
-		}
-		if err := r.Body.Close(); err != nil {
-			log.Printf("could not close request body: %v", err)
-		}
-	}()
-
-	if r.Method != http.MethodPost {
-		return nil, errors.New("invalid HTTP Method")
-	}
-
-	// If we have a secret set, we should check if the request matches it.
-	if len(hook.Secret) > 0 {
-		signature := r.Header.Get("X-Gitlab-Token")
-		if signature != hook.Secret {
-			return nil, errors.New("token validation failed")
-		}
-	}
-
-	event := r.Header.Get("X-Gitlab-Event")
-	if strings.TrimSpace(event) == "" {
-		return nil, errors.New("missing X-Gitlab-Event Header")
-	}
-
-	eventType := gitlab.EventType(event)
-	if !isEventSubscribed(eventType, hook.EventsToAccept) {
-		return nil, errors.New("event not defined to be parsed")
-	}
-
-	payload, err := io.ReadAll(r.Body)
-	if err != nil || len(payload) == 0 {
-		return nil, errors.New("error reading request body")
-	}
-
-	return gitlab.ParseWebhook(eventType, payload)
-}
-
-func isEventSubscribed(event gitlab.EventType, events []gitlab.EventType) bool {
-	for _, e := range events {
-		if event == e {
-			return true
-		}
-	}
-	return false
-}
@@ -43,6 +43,10 @@ import (
 	"golang.org/x/time/rate"
 )
 
+//go:generate go run ./scripts/geninterfaces
+//go:generate go run ./scripts/genroutes
+//go:generate mockgen -source=service_interfaces.go -destination=gitlabmock/mocks.go -package=gitlabmock
+
 const (
 	defaultBaseURL = "https://gitlab.com/"
 	apiVersionPath = "api/v4/"
@@ -50,6 +54,7 @@ const (
 
 	headerRateLimit = "RateLimit-Limit"
 	headerRateReset = "RateLimit-Reset"
+	headerRequestID = "X-Request-Id"
 )
 
 // AuthType represents an authentication type within GitLab.
@@ -106,139 +111,148 @@ type Client struct {
 	// Protects the token field from concurrent read/write accesses.
 	tokenLock sync.RWMutex
 
+	// Token source used to get (and refresh) OAuth tokens.
+	tokenSource *cachingTokenSource
+
 	// Default request options applied to every request.
 	defaultRequestOptions []RequestOptionFunc
 
+	// Cache used to store responses and make conditional requests.
+	responseCache ResponseCache
+
+	// Instrumentation used to trace and measure API calls.
+	instrumentation Instrumentation
+
 	// User agent used when communicating with the GitLab API.
 	UserAgent string
 
 	// Services used for talking to different parts of the GitLab API.
-	AccessRequests               *AccessRequestsService
-	Appearance                   *AppearanceService
-	Applications                 *ApplicationsService
-	AuditEvents                  *AuditEventsService
-	Avatar                       *AvatarRequestsService
-	AwardEmoji                   *AwardEmojiService
-	Boards                       *IssueBoardsService
-	Branches                     *BranchesService
-	BroadcastMessage             *BroadcastMessagesService
-	CIYMLTemplate                *CIYMLTemplatesService
-	ClusterAgents                *ClusterAgentsService
-	Commits                      *CommitsService
-	ContainerRegistry            *ContainerRegistryService
-	CustomAttribute              *CustomAttributesService
-	DependencyListExport         *DependencyListExportService
-	DeployKeys                   *DeployKeysService
-	DeployTokens                 *DeployTokensService
-	DeploymentMergeRequests      *DeploymentMergeRequestsService
-	Deployments                  *DeploymentsService
-	Discussions                  *DiscussionsService
-	DockerfileTemplate           *DockerfileTemplatesService
-	DORAMetrics                  *DORAMetricsService
-	DraftNotes                   *DraftNotesService
-	Environments                 *EnvironmentsService
-	EpicIssues                   *EpicIssuesService
-	Epics                        *EpicsService
-	ErrorTracking                *ErrorTrackingService
-	Events                       *EventsService
-	ExternalStatusChecks         *ExternalStatusChecksService
-	Features                     *FeaturesService
-	FreezePeriods                *FreezePeriodsService
-	GenericPackages              *GenericPackagesService
-	GeoNodes                     *GeoNodesService
-	GitIgnoreTemplates           *GitIgnoreTemplatesService
-	GroupAccessTokens            *GroupAccessTokensService
-	GroupBadges                  *GroupBadgesService
-	GroupCluster                 *GroupClustersService
-	GroupEpicBoards              *GroupEpicBoardsService
-	GroupImportExport            *GroupImportExportService
-	GroupIssueBoards             *GroupIssueBoardsService
-	GroupIterations              *GroupIterationsService
-	GroupLabels                  *GroupLabelsService
-	GroupMembers                 *GroupMembersService
-	GroupMilestones              *GroupMilestonesService
-	GroupProtectedEnvironments   *GroupProtectedEnvironmentsService
-	GroupRepositoryStorageMove   *GroupRepositoryStorageMoveService
-	GroupSSHCertificates         *GroupSSHCertificatesService
-	GroupVariables               *GroupVariablesService
-	GroupWikis                   *GroupWikisService
-	Groups                       *GroupsService
-	Import                       *ImportService
-	InstanceCluster              *InstanceClustersService
-	InstanceVariables            *InstanceVariablesService
-	Invites                      *InvitesService
-	IssueLinks                   *IssueLinksService
-	Issues                       *IssuesService
-	IssuesStatistics             *IssuesStatisticsService
-	Jobs                         *JobsService
-	JobTokenScope                *JobTokenScopeService
-	Keys                         *KeysService
-	Labels                       *LabelsService
-	License                      *LicenseService
-	LicenseTemplates             *LicenseTemplatesService
-	ManagedLicenses              *ManagedLicensesService
-	Markdown                     *MarkdownService
-	MemberRolesService           *MemberRolesService
-	MergeRequestApprovals        *MergeRequestApprovalsService
-	MergeRequests                *MergeRequestsService
-	MergeTrains                  *MergeTrainsService
-	Metadata                     *MetadataService
-	Milestones                   *MilestonesService
-	Namespaces                   *NamespacesService
-	Notes                        *NotesService
-	NotificationSettings         *NotificationSettingsService
-	Packages                     *PackagesService
-	Pages                        *PagesService
-	PagesDomains                 *PagesDomainsService
-	PersonalAccessTokens         *PersonalAccessTokensService
-	PipelineSchedules            *PipelineSchedulesService
-	PipelineTriggers             *PipelineTriggersService
-	Pipelines                    *PipelinesService
-	PlanLimits                   *PlanLimitsService
-	ProjectAccessTokens          *ProjectAccessTokensService
-	ProjectBadges                *ProjectBadgesService
-	ProjectCluster               *ProjectClustersService
-	ProjectFeatureFlags          *ProjectFeatureFlagService
-	ProjectImportExport          *ProjectImportExportService
-	ProjectIterations            *ProjectIterationsService
-	ProjectMarkdownUploads       *ProjectMarkdownUploadsService
-	ProjectMembers               *ProjectMembersService
-	ProjectMirrors               *ProjectMirrorService
-	ProjectRepositoryStorageMove *ProjectRepositoryStorageMoveService
-	ProjectSnippets              *ProjectSnippetsService
-	ProjectTemplates             *ProjectTemplatesService
-	ProjectVariables             *ProjectVariablesService
-	ProjectVulnerabilities       *ProjectVulnerabilitiesService
-	Projects                     *ProjectsService
-	ProtectedBranches            *ProtectedBranchesService
-	ProtectedEnvironments        *ProtectedEnvironmentsService
-	ProtectedTags                *ProtectedTagsService
-	ReleaseLinks                 *ReleaseLinksService
-	Releases                     *ReleasesService
-	Repositories                 *RepositoriesService
-	RepositoryFiles              *RepositoryFilesService
-	RepositorySubmodules         *RepositorySubmodulesService
-	ResourceGroup                *ResourceGroupService
-	ResourceIterationEvents      *ResourceIterationEventsService
-	ResourceLabelEvents          *ResourceLabelEventsService
-	ResourceMilestoneEvents      *ResourceMilestoneEventsService
-	ResourceStateEvents          *ResourceStateEventsService
-	ResourceWeightEvents         *ResourceWeightEventsService
-	Runners                      *RunnersService
-	Search                       *SearchService
-	Services                     *ServicesService
-	Settings                     *SettingsService
-	Sidekiq                      *SidekiqService
-	SnippetRepositoryStorageMove *SnippetRepositoryStorageMoveService
-	Snippets                     *SnippetsService
-	SystemHooks                  *SystemHooksService
-	Tags                         *TagsService
-	Todos                        *TodosService
-	Topics                       *TopicsService
-	Users                        *UsersService
-	Validate                     *ValidateService
-	Version                      *VersionService
-	Wikis                        *WikisService
+	AccessRequests               AccessRequestsServiceInterface
+	Appearance                   AppearanceServiceInterface
+	Applications                 ApplicationsServiceInterface
+	AuditEvents                  AuditEventsServiceInterface
+	Avatar                       AvatarRequestsServiceInterface
+	AwardEmoji                   AwardEmojiServiceInterface
+	Boards                       IssueBoardsServiceInterface
+	Branches                     BranchesServiceInterface
+	BroadcastMessage             BroadcastMessagesServiceInterface
+	CIYMLTemplate                CIYMLTemplatesServiceInterface
+	ClusterAgents                ClusterAgentsServiceInterface
+	Commits                      CommitsServiceInterface
+	ContainerRegistry            ContainerRegistryServiceInterface
+	CustomAttribute              CustomAttributesServiceInterface
+	DependencyListExport         DependencyListExportServiceInterface
+	DeployKeys                   DeployKeysServiceInterface
+	DeployTokens                 DeployTokensServiceInterface
+	DeploymentMergeRequests      DeploymentMergeRequestsServiceInterface
+	Deployments                  DeploymentsServiceInterface
+	Discussions                  DiscussionsServiceInterface
+	DockerfileTemplate           DockerfileTemplatesServiceInterface
+	DORAMetrics                  DORAMetricsServiceInterface
+	DraftNotes                   DraftNotesServiceInterface
+	Environments                 EnvironmentsServiceInterface
+	EpicIssues                   EpicIssuesServiceInterface
+	Epics                        EpicsServiceInterface
+	ErrorTracking                ErrorTrackingServiceInterface
+	Events                       EventsServiceInterface
+	ExternalStatusChecks         ExternalStatusChecksServiceInterface
+	Features                     FeaturesServiceInterface
+	FreezePeriods                FreezePeriodsServiceInterface
+	GenericPackages              GenericPackagesServiceInterface
+	GeoNodes                     GeoNodesServiceInterface
+	GitIgnoreTemplates           GitIgnoreTemplatesServiceInterface
+	GroupAccessTokens            GroupAccessTokensServiceInterface
+	GroupBadges                  GroupBadgesServiceInterface
+	GroupCluster                 GroupClustersServiceInterface
+	GroupEpicBoards              GroupEpicBoardsServiceInterface
+	GroupImportExport            GroupImportExportServiceInterface
+	GroupIssueBoards             GroupIssueBoardsServiceInterface
+	GroupIterations              GroupIterationsServiceInterface
+	GroupLabels                  GroupLabelsServiceInterface
+	GroupMembers                 GroupMembersServiceInterface
+	GroupMilestones              GroupMilestonesServiceInterface
+	GroupProtectedEnvironments   GroupProtectedEnvironmentsServiceInterface
+	GroupRepositoryStorageMove   GroupRepositoryStorageMoveServiceInterface
+	GroupSSHCertificates         GroupSSHCertificatesServiceInterface
+	GroupVariables               GroupVariablesServiceInterface
+	GroupWikis                   GroupWikisServiceInterface
+	Groups                       GroupsServiceInterface
+	Import                       ImportServiceInterface
+	InstanceCluster              InstanceClustersServiceInterface
+	InstanceVariables            InstanceVariablesServiceInterface
+	Invites                      InvitesServiceInterface
+	IssueLinks                   IssueLinksServiceInterface
+	Issues                       IssuesServiceInterface
+	IssuesStatistics             IssuesStatisticsServiceInterface
+	Jobs                         JobsServiceInterface
+	JobTokenScope                JobTokenScopeServiceInterface
+	Keys                         KeysServiceInterface
+	Labels                       LabelsServiceInterface
+	License                      LicenseServiceInterface
+	LicenseTemplates             LicenseTemplatesServiceInterface
+	ManagedLicenses              ManagedLicensesServiceInterface
+	Markdown                     MarkdownServiceInterface
+	MemberRolesService           MemberRolesServiceInterface
+	MergeRequestApprovals        MergeRequestApprovalsServiceInterface
+	MergeRequests                MergeRequestsServiceInterface
+	MergeTrains                  MergeTrainsServiceInterface
+	Metadata                     MetadataServiceInterface
+	Milestones                   MilestonesServiceInterface
+	Namespaces                   NamespacesServiceInterface
+	Notes                        NotesServiceInterface
+	NotificationSettings         NotificationSettingsServiceInterface
+	Packages                     PackagesServiceInterface
+	Pages                        PagesServiceInterface
+	PagesDomains                 PagesDomainsServiceInterface
+	PersonalAccessTokens         PersonalAccessTokensServiceInterface
+	PipelineSchedules            PipelineSchedulesServiceInterface
+	PipelineTriggers             PipelineTriggersServiceInterface
+	Pipelines                    PipelinesServiceInterface
+	PlanLimits                   PlanLimitsServiceInterface
+	ProjectAccessTokens          ProjectAccessTokensServiceInterface
+	ProjectBadges                ProjectBadgesServiceInterface
+	ProjectCluster               ProjectClustersServiceInterface
+	ProjectFeatureFlags          ProjectFeatureFlagServiceInterface
+	ProjectImportExport          ProjectImportExportServiceInterface
+	ProjectIterations            ProjectIterationsServiceInterface
+	ProjectMarkdownUploads       ProjectMarkdownUploadsServiceInterface
+	ProjectMembers               ProjectMembersServiceInterface
+	ProjectMirrors               ProjectMirrorServiceInterface
+	ProjectRepositoryStorageMove ProjectRepositoryStorageMoveServiceInterface
+	ProjectSnippets              ProjectSnippetsServiceInterface
+	ProjectTemplates             ProjectTemplatesServiceInterface
+	ProjectVariables             ProjectVariablesServiceInterface
+	ProjectVulnerabilities       ProjectVulnerabilitiesServiceInterface
+	Projects                     ProjectsServiceInterface
+	ProtectedBranches            ProtectedBranchesServiceInterface
+	ProtectedEnvironments        ProtectedEnvironmentsServiceInterface
+	ProtectedTags                ProtectedTagsServiceInterface
+	ReleaseLinks                 ReleaseLinksServiceInterface
+	Releases                     ReleasesServiceInterface
+	Repositories                 RepositoriesServiceInterface
+	RepositoryFiles              RepositoryFilesServiceInterface
+	RepositorySubmodules         RepositorySubmodulesServiceInterface
+	ResourceGroup                ResourceGroupServiceInterface
+	ResourceIterationEvents      ResourceIterationEventsServiceInterface
+	ResourceLabelEvents          ResourceLabelEventsServiceInterface
+	ResourceMilestoneEvents      ResourceMilestoneEventsServiceInterface
+	ResourceStateEvents          ResourceStateEventsServiceInterface
+	ResourceWeightEvents         ResourceWeightEventsServiceInterface
+	Runners                      RunnersServiceInterface
+	Search                       SearchServiceInterface
+	Services                     ServicesServiceInterface
+	Settings                     SettingsServiceInterface
+	Sidekiq                      SidekiqServiceInterface
+	SnippetRepositoryStorageMove SnippetRepositoryStorageMoveServiceInterface
+	Snippets                     SnippetsServiceInterface
+	SystemHooks                  SystemHooksServiceInterface
+	Tags                         TagsServiceInterface
+	Todos                        TodosServiceInterface
+	Topics                       TopicsServiceInterface
+	Users                        UsersServiceInterface
+	Validate                     ValidateServiceInterface
+	Version                      VersionServiceInterface
+	Wikis                        WikisServiceInterface
 }
 
 // ListOptions specifies the optional parameters to various List methods that
@@ -334,6 +348,23 @@ func NewOAuthClient(token string, options ...ClientOptionFunc) (*Client, error)
 	return client, nil
 }
 
+// NewOAuthTokenSourceClient returns a new GitLab API client that uses the
+// given token source to get the OAuth tokens used to authenticate. Tokens are
+// cached until they expire, or until they are rejected by GitLab in which
+// case a new token is requested and the request is retried once.
+//
+// See NewOAuthRefreshTokenSource and NewOAuthClientCredentialsTokenSource
+// for token sources that request new tokens from GitLab.
+func NewOAuthTokenSourceClient(ts oauth2.TokenSource, options ...ClientOptionFunc) (*Client, error) {
+	client, err := newClient(options...)
+	if err != nil {
+		return nil, err
+	}
+	client.authType = OAuthToken
+	client.tokenSource = &cachingTokenSource{src: ts}
+	return client, nil
+}
+
 func newClient(options ...ClientOptionFunc) (*Client, error) {
 	c := &Client{UserAgent: userAgent}
 
@@ -361,6 +392,12 @@ func newClient(options ...ClientOptionFunc) (*Client, error) {
 		}
 	}
 
+	// Count the retries of instrumented requests. This is done after applying
+	// the client options, so it also works with a custom retry policy.
+	if c.instrumentation != nil {
+		c.instrumentRetries()
+	}
+
 	// If no custom limiter was set using a client option, configure
 	// the default rate limiter with values that implicitly disable
 	// rate limiting until an initial HTTP call is done and we can
@@ -865,6 +902,13 @@ func (r *Response) populateLinkValues() {
 // interface, the raw response body will be written to v, without attempting to
 // first decode it.
 func (c *Client) Do(req *retryablehttp.Request, v interface{}) (*Response, error) {
+	if c.instrumentation != nil {
+		return c.instrumentedDo(req, v)
+	}
+	return c.do(req, v)
+}
+
+func (c *Client) do(req *retryablehttp.Request, v interface{}) (*Response, error) {
 	// Wait will block until the limiter can obtain a new token.
 	err := c.limiter.Wait(req.Context())
 	if err != nil {
@@ -874,6 +918,7 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}) (*Response, error
 	// Set the correct authentication header. If using basic auth, then check
 	// if we already have a token and if not first authenticate and get one.
 	var basicAuthToken string
+	var oauthToken *oauth2.Token
 	switch c.authType {
 	case BasicAuth:
 		c.tokenLock.RLock()
@@ -893,7 +938,15 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}) (*Response, error
 		}
 	case OAuthToken:
 		if values := req.Header.Values("Authorization"); len(values) == 0 {
-			req.Header.Set("Authorization", "Bearer "+c.token)
+			if c.tokenSource != nil {
+				oauthToken, err = c.tokenSource.Token()
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set("Authorization", oauthToken.Type()+" "+oauthToken.AccessToken)
+			} else {
+				req.Header.Set("Authorization", "Bearer "+c.token)
+			}
 		}
 	case PrivateToken:
 		if values := req.Header.Values("PRIVATE-TOKEN"); len(values) == 0 {
@@ -901,6 +954,15 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}) (*Response, error
 		}
 	}
 
+	// When using a response cache, make the request conditional if we have
+	// a cached response for it. Raw responses (written to an io.Writer) are
+	// never cached, as those can be very large.
+	var cacheKey string
+	var cached *CachedResponse
+	if _, raw := v.(io.Writer); c.responseCache != nil && !raw && req.Method == http.MethodGet && req.Header.Get("Range") == "" {
+		cacheKey, cached = c.conditionalRequest(req, nil)
+	}
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -912,8 +974,42 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}) (*Response, error
 		if _, err := c.requestOAuthToken(req.Context(), basicAuthToken); err != nil {
 			return nil, err
 		}
-		return c.Do(req, v)
+		// The retry uses the cache key of the new token.
+		if cached != nil && req.Header.Get("If-None-Match") == cached.ETag {
+			req.Header.Del("If-None-Match")
+		}
+		return c.do(req, v)
 	}
+	if resp.StatusCode == http.StatusUnauthorized && oauthToken != nil {
+		// The token was most likely revoked or expired early, so invalidate it
+		// and try again once if the token source gives us a different token.
+		c.tokenSource.invalidate(oauthToken)
+		newToken, err := c.tokenSource.Token()
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		if newToken.AccessToken != oauthToken.AccessToken {
+			resp.Body.Close()
+			req.Header.Set("Authorization", newToken.Type()+" "+newToken.AccessToken)
+			if cacheKey != "" {
+				// The cache key depends on the credentials, so use the one
+				// of the request that is actually sent.
+				cacheKey, cached = c.conditionalRequest(req, cached)
+			}
+			resp, err = c.client.Do(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if cacheKey != "" {
+		if err := c.applyResponseCache(req.Context(), cacheKey, cached, resp); err != nil {
+			return nil, err
+		}
+	}
+
 	defer resp.Body.Close()
 	defer io.Copy(io.Discard, resp.Body)
 
@@ -933,6 +1029,9 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}) (*Response, error
 
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
+			if rw, ok := w.(*resumableWriter); ok {
+				rw.setResponse(response)
+			}
 			_, err = io.Copy(w, resp.Body)
 		} else {
 			err = json.NewDecoder(resp.Body).Decode(v)
@@ -942,6 +1041,110 @@ func (c *Client) Do(req *retryablehttp.Request, v interface{}) (*Response, error
 	return response, err
 }
 
+// stream sends an API request and writes the raw response body to w. When
+// the connection is interrupted while the body is being copied, the download
+// is resumed using a HTTP Range request starting at the number of bytes that
+// were already written to w. The number of resume attempts is bounded by the
+// maximum number of retries configured for the client.
+func (c *Client) stream(req *retryablehttp.Request, w io.Writer) (*Response, error) {
+	rw := &resumableWriter{w: w}
+
+	resp, err := c.Do(req, rw)
+	for attempt := 0; err != nil && rw.canResume() && !c.disableRetries && attempt < c.client.RetryMax; attempt++ {
+		if req.Context().Err() != nil {
+			break
+		}
+
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", rw.written))
+		if rw.validator != "" {
+			req.Header.Set("If-Range", rw.validator)
+		}
+
+		rw.copying = false
+		resp, err = c.Do(req, rw)
+	}
+
+	return resp, err
+}
+
+// errContentChanged is returned when a download cannot be resumed because
+// the content changed since the download was started.
+var errContentChanged = errors.New("content changed while resuming download")
+
+// resumableWriter keeps track of the number of bytes written to the
+// underlying writer, so an interrupted download can be resumed.
+type resumableWriter struct {
+	w io.Writer
+
+	// validator holds the ETag or Last-Modified value of the first response.
+	validator string
+
+	// acceptRanges reports whether the server supports range requests.
+	acceptRanges bool
+
+	// copying reports whether the body of the last response was being copied.
+	copying bool
+
+	written  int64
+	skip     int64
+	writeErr error
+}
+
+// setResponse is called by Client.Do before the response body is copied.
+func (rw *resumableWriter) setResponse(resp *Response) {
+	rw.skip = 0
+
+	switch {
+	case !rw.copying && rw.written == 0:
+		// This is the first response, so remember how to resume it.
+		rw.acceptRanges = resp.Header.Get("Accept-Ranges") == "bytes"
+		if rw.validator = resp.Header.Get("ETag"); rw.validator == "" {
+			rw.validator = resp.Header.Get("Last-Modified")
+		}
+	case resp.StatusCode != http.StatusPartialContent && rw.validator != "":
+		// The If-Range validator no longer matches, so the content changed.
+		rw.writeErr = errContentChanged
+	case resp.StatusCode != http.StatusPartialContent:
+		// The server ignored the Range header and sends the full content again.
+		rw.skip = rw.written
+	}
+
+	rw.copying = true
+}
+
+func (rw *resumableWriter) Write(p []byte) (int, error) {
+	if rw.writeErr != nil {
+		return 0, rw.writeErr
+	}
+
+	n := len(p)
+	if rw.skip > 0 {
+		if int64(n) <= rw.skip {
+			rw.skip -= int64(n)
+			return n, nil
+		}
+		p = p[rw.skip:]
+		rw.skip = 0
+	}
+
+	written, err := rw.w.Write(p)
+	rw.written += int64(written)
+	if err != nil {
+		rw.writeErr = err
+		return n - len(p) + written, err
+	}
+
+	return n, nil
+}
+
+// canResume reports whether the download can be resumed after a failure. This
+// is only the case when the failure happened while copying the body, the
+// server supports range requests and the failure was not caused by writing
+// to the underlying writer.
+func (rw *resumableWriter) canResume() bool {
+	return rw.copying && rw.acceptRanges && rw.writeErr == nil
+}
+
 func (c *Client) requestOAuthToken(ctx context.Context, token string) (string, error) {
 	c.tokenLock.Lock()
 	defer c.tokenLock.Unlock()
@@ -991,9 +1194,10 @@ func PathEscape(s string) string {
 // GitLab API docs:
 // https://docs.gitlab.com/ee/api/index.html#data-validation-and-error-reporting
 type ErrorResponse struct {
-	Body     []byte
-	Response *http.Response
-	Message  string
+	Body      []byte
+	Response  *http.Response
+	Message   string
+	RequestID string
 }
 
 func (e *ErrorResponse) Error() string {
@@ -1008,29 +1212,37 @@ func (e *ErrorResponse) Error() string {
 }
 
 // CheckResponse checks the API response for errors, and returns them if present.
+//
+// The returned error is one of the typed errors (like *NotFoundError or
+// *ValidationError) when the status code has a specific meaning, otherwise it
+// is an *ErrorResponse. All typed errors wrap the underlying *ErrorResponse,
+// so errors.As can be used to get access to the response and its body.
 func CheckResponse(r *http.Response) error {
 	switch r.StatusCode {
-	case 200, 201, 202, 204, 304:
+	case 200, 201, 202, 204, 206, 304:
 		return nil
-	case 404:
-		return ErrNotFound
 	}
 
-	errorResponse := &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{
+		Response:  r,
+		RequestID: r.Header.Get(headerRequestID),
+	}
 
-	data, err := io.ReadAll(r.Body)
-	if err == nil && strings.TrimSpace(string(data)) != "" {
-		errorResponse.Body = data
+	var raw interface{}
+	if r.Body != nil {
+		data, err := io.ReadAll(r.Body)
+		if err == nil && strings.TrimSpace(string(data)) != "" {
+			errorResponse.Body = data
 
-		var raw interface{}
-		if err := json.Unmarshal(data, &raw); err != nil {
-			errorResponse.Message = fmt.Sprintf("failed to parse unknown error format: %s", data)
-		} else {
-			errorResponse.Message = parseError(raw)
+			if err := json.Unmarshal(data, &raw); err != nil {
+				errorResponse.Message = fmt.Sprintf("failed to parse unknown error format: %s", data)
+			} else {
+				errorResponse.Message = parseError(raw)
+			}
 		}
 	}
 
-	return errorResponse
+	return newTypedError(errorResponse, raw)
 }
 
 // Format:
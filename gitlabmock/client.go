@@ -0,0 +1,423 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Code generated by geninterfaces. DO NOT EDIT.
+
+package gitlabmock
+
+import (
+	"github.com/xanzy/go-gitlab"
+	"go.uber.org/mock/gomock"
+)
+
+// Client is a gitlab.Client with all services replaced by mocks. Use the
+// mocks to set expectations and pass the embedded *gitlab.Client to the
+// code under test.
+type Client struct {
+	*gitlab.Client
+
+	AccessRequests               *MockAccessRequestsServiceInterface
+	Appearance                   *MockAppearanceServiceInterface
+	Applications                 *MockApplicationsServiceInterface
+	AuditEvents                  *MockAuditEventsServiceInterface
+	Avatar                       *MockAvatarRequestsServiceInterface
+	AwardEmoji                   *MockAwardEmojiServiceInterface
+	Boards                       *MockIssueBoardsServiceInterface
+	Branches                     *MockBranchesServiceInterface
+	BroadcastMessage             *MockBroadcastMessagesServiceInterface
+	CIYMLTemplate                *MockCIYMLTemplatesServiceInterface
+	ClusterAgents                *MockClusterAgentsServiceInterface
+	Commits                      *MockCommitsServiceInterface
+	ContainerRegistry            *MockContainerRegistryServiceInterface
+	CustomAttribute              *MockCustomAttributesServiceInterface
+	DependencyListExport         *MockDependencyListExportServiceInterface
+	DeployKeys                   *MockDeployKeysServiceInterface
+	DeployTokens                 *MockDeployTokensServiceInterface
+	DeploymentMergeRequests      *MockDeploymentMergeRequestsServiceInterface
+	Deployments                  *MockDeploymentsServiceInterface
+	Discussions                  *MockDiscussionsServiceInterface
+	DockerfileTemplate           *MockDockerfileTemplatesServiceInterface
+	DORAMetrics                  *MockDORAMetricsServiceInterface
+	DraftNotes                   *MockDraftNotesServiceInterface
+	Environments                 *MockEnvironmentsServiceInterface
+	EpicIssues                   *MockEpicIssuesServiceInterface
+	Epics                        *MockEpicsServiceInterface
+	ErrorTracking                *MockErrorTrackingServiceInterface
+	Events                       *MockEventsServiceInterface
+	ExternalStatusChecks         *MockExternalStatusChecksServiceInterface
+	Features                     *MockFeaturesServiceInterface
+	FreezePeriods                *MockFreezePeriodsServiceInterface
+	GenericPackages              *MockGenericPackagesServiceInterface
+	GeoNodes                     *MockGeoNodesServiceInterface
+	GitIgnoreTemplates           *MockGitIgnoreTemplatesServiceInterface
+	GroupAccessTokens            *MockGroupAccessTokensServiceInterface
+	GroupBadges                  *MockGroupBadgesServiceInterface
+	GroupCluster                 *MockGroupClustersServiceInterface
+	GroupEpicBoards              *MockGroupEpicBoardsServiceInterface
+	GroupImportExport            *MockGroupImportExportServiceInterface
+	GroupIssueBoards             *MockGroupIssueBoardsServiceInterface
+	GroupIterations              *MockGroupIterationsServiceInterface
+	GroupLabels                  *MockGroupLabelsServiceInterface
+	GroupMembers                 *MockGroupMembersServiceInterface
+	GroupMilestones              *MockGroupMilestonesServiceInterface
+	GroupProtectedEnvironments   *MockGroupProtectedEnvironmentsServiceInterface
+	GroupRepositoryStorageMove   *MockGroupRepositoryStorageMoveServiceInterface
+	GroupSSHCertificates         *MockGroupSSHCertificatesServiceInterface
+	GroupVariables               *MockGroupVariablesServiceInterface
+	GroupWikis                   *MockGroupWikisServiceInterface
+	Groups                       *MockGroupsServiceInterface
+	Import                       *MockImportServiceInterface
+	InstanceCluster              *MockInstanceClustersServiceInterface
+	InstanceVariables            *MockInstanceVariablesServiceInterface
+	Invites                      *MockInvitesServiceInterface
+	IssueLinks                   *MockIssueLinksServiceInterface
+	Issues                       *MockIssuesServiceInterface
+	IssuesStatistics             *MockIssuesStatisticsServiceInterface
+	Jobs                         *MockJobsServiceInterface
+	JobTokenScope                *MockJobTokenScopeServiceInterface
+	Keys                         *MockKeysServiceInterface
+	Labels                       *MockLabelsServiceInterface
+	License                      *MockLicenseServiceInterface
+	LicenseTemplates             *MockLicenseTemplatesServiceInterface
+	ManagedLicenses              *MockManagedLicensesServiceInterface
+	Markdown                     *MockMarkdownServiceInterface
+	MemberRolesService           *MockMemberRolesServiceInterface
+	MergeRequestApprovals        *MockMergeRequestApprovalsServiceInterface
+	MergeRequests                *MockMergeRequestsServiceInterface
+	MergeTrains                  *MockMergeTrainsServiceInterface
+	Metadata                     *MockMetadataServiceInterface
+	Milestones                   *MockMilestonesServiceInterface
+	Namespaces                   *MockNamespacesServiceInterface
+	Notes                        *MockNotesServiceInterface
+	NotificationSettings         *MockNotificationSettingsServiceInterface
+	Packages                     *MockPackagesServiceInterface
+	Pages                        *MockPagesServiceInterface
+	PagesDomains                 *MockPagesDomainsServiceInterface
+	PersonalAccessTokens         *MockPersonalAccessTokensServiceInterface
+	PipelineSchedules            *MockPipelineSchedulesServiceInterface
+	PipelineTriggers             *MockPipelineTriggersServiceInterface
+	Pipelines                    *MockPipelinesServiceInterface
+	PlanLimits                   *MockPlanLimitsServiceInterface
+	ProjectAccessTokens          *MockProjectAccessTokensServiceInterface
+	ProjectBadges                *MockProjectBadgesServiceInterface
+	ProjectCluster               *MockProjectClustersServiceInterface
+	ProjectFeatureFlags          *MockProjectFeatureFlagServiceInterface
+	ProjectImportExport          *MockProjectImportExportServiceInterface
+	ProjectIterations            *MockProjectIterationsServiceInterface
+	ProjectMarkdownUploads       *MockProjectMarkdownUploadsServiceInterface
+	ProjectMembers               *MockProjectMembersServiceInterface
+	ProjectMirrors               *MockProjectMirrorServiceInterface
+	ProjectRepositoryStorageMove *MockProjectRepositoryStorageMoveServiceInterface
+	ProjectSnippets              *MockProjectSnippetsServiceInterface
+	ProjectTemplates             *MockProjectTemplatesServiceInterface
+	ProjectVariables             *MockProjectVariablesServiceInterface
+	ProjectVulnerabilities       *MockProjectVulnerabilitiesServiceInterface
+	Projects                     *MockProjectsServiceInterface
+	ProtectedBranches            *MockProtectedBranchesServiceInterface
+	ProtectedEnvironments        *MockProtectedEnvironmentsServiceInterface
+	ProtectedTags                *MockProtectedTagsServiceInterface
+	ReleaseLinks                 *MockReleaseLinksServiceInterface
+	Releases                     *MockReleasesServiceInterface
+	Repositories                 *MockRepositoriesServiceInterface
+	RepositoryFiles              *MockRepositoryFilesServiceInterface
+	RepositorySubmodules         *MockRepositorySubmodulesServiceInterface
+	ResourceGroup                *MockResourceGroupServiceInterface
+	ResourceIterationEvents      *MockResourceIterationEventsServiceInterface
+	ResourceLabelEvents          *MockResourceLabelEventsServiceInterface
+	ResourceMilestoneEvents      *MockResourceMilestoneEventsServiceInterface
+	ResourceStateEvents          *MockResourceStateEventsServiceInterface
+	ResourceWeightEvents         *MockResourceWeightEventsServiceInterface
+	Runners                      *MockRunnersServiceInterface
+	Search                       *MockSearchServiceInterface
+	Services                     *MockServicesServiceInterface
+	Settings                     *MockSettingsServiceInterface
+	Sidekiq                      *MockSidekiqServiceInterface
+	SnippetRepositoryStorageMove *MockSnippetRepositoryStorageMoveServiceInterface
+	Snippets                     *MockSnippetsServiceInterface
+	SystemHooks                  *MockSystemHooksServiceInterface
+	Tags                         *MockTagsServiceInterface
+	Todos                        *MockTodosServiceInterface
+	Topics                       *MockTopicsServiceInterface
+	Users                        *MockUsersServiceInterface
+	Validate                     *MockValidateServiceInterface
+	Version                      *MockVersionServiceInterface
+	Wikis                        *MockWikisServiceInterface
+}
+
+// NewClient returns a new Client using mocks created with the given
+// controller.
+func NewClient(ctrl *gomock.Controller) *Client {
+	client, err := gitlab.NewClient("")
+	if err != nil {
+		panic(err)
+	}
+
+	c := &Client{Client: client}
+	c.AccessRequests = NewMockAccessRequestsServiceInterface(ctrl)
+	c.Client.AccessRequests = c.AccessRequests
+	c.Appearance = NewMockAppearanceServiceInterface(ctrl)
+	c.Client.Appearance = c.Appearance
+	c.Applications = NewMockApplicationsServiceInterface(ctrl)
+	c.Client.Applications = c.Applications
+	c.AuditEvents = NewMockAuditEventsServiceInterface(ctrl)
+	c.Client.AuditEvents = c.AuditEvents
+	c.Avatar = NewMockAvatarRequestsServiceInterface(ctrl)
+	c.Client.Avatar = c.Avatar
+	c.AwardEmoji = NewMockAwardEmojiServiceInterface(ctrl)
+	c.Client.AwardEmoji = c.AwardEmoji
+	c.Boards = NewMockIssueBoardsServiceInterface(ctrl)
+	c.Client.Boards = c.Boards
+	c.Branches = NewMockBranchesServiceInterface(ctrl)
+	c.Client.Branches = c.Branches
+	c.BroadcastMessage = NewMockBroadcastMessagesServiceInterface(ctrl)
+	c.Client.BroadcastMessage = c.BroadcastMessage
+	c.CIYMLTemplate = NewMockCIYMLTemplatesServiceInterface(ctrl)
+	c.Client.CIYMLTemplate = c.CIYMLTemplate
+	c.ClusterAgents = NewMockClusterAgentsServiceInterface(ctrl)
+	c.Client.ClusterAgents = c.ClusterAgents
+	c.Commits = NewMockCommitsServiceInterface(ctrl)
+	c.Client.Commits = c.Commits
+	c.ContainerRegistry = NewMockContainerRegistryServiceInterface(ctrl)
+	c.Client.ContainerRegistry = c.ContainerRegistry
+	c.CustomAttribute = NewMockCustomAttributesServiceInterface(ctrl)
+	c.Client.CustomAttribute = c.CustomAttribute
+	c.DependencyListExport = NewMockDependencyListExportServiceInterface(ctrl)
+	c.Client.DependencyListExport = c.DependencyListExport
+	c.DeployKeys = NewMockDeployKeysServiceInterface(ctrl)
+	c.Client.DeployKeys = c.DeployKeys
+	c.DeployTokens = NewMockDeployTokensServiceInterface(ctrl)
+	c.Client.DeployTokens = c.DeployTokens
+	c.DeploymentMergeRequests = NewMockDeploymentMergeRequestsServiceInterface(ctrl)
+	c.Client.DeploymentMergeRequests = c.DeploymentMergeRequests
+	c.Deployments = NewMockDeploymentsServiceInterface(ctrl)
+	c.Client.Deployments = c.Deployments
+	c.Discussions = NewMockDiscussionsServiceInterface(ctrl)
+	c.Client.Discussions = c.Discussions
+	c.DockerfileTemplate = NewMockDockerfileTemplatesServiceInterface(ctrl)
+	c.Client.DockerfileTemplate = c.DockerfileTemplate
+	c.DORAMetrics = NewMockDORAMetricsServiceInterface(ctrl)
+	c.Client.DORAMetrics = c.DORAMetrics
+	c.DraftNotes = NewMockDraftNotesServiceInterface(ctrl)
+	c.Client.DraftNotes = c.DraftNotes
+	c.Environments = NewMockEnvironmentsServiceInterface(ctrl)
+	c.Client.Environments = c.Environments
+	c.EpicIssues = NewMockEpicIssuesServiceInterface(ctrl)
+	c.Client.EpicIssues = c.EpicIssues
+	c.Epics = NewMockEpicsServiceInterface(ctrl)
+	c.Client.Epics = c.Epics
+	c.ErrorTracking = NewMockErrorTrackingServiceInterface(ctrl)
+	c.Client.ErrorTracking = c.ErrorTracking
+	c.Events = NewMockEventsServiceInterface(ctrl)
+	c.Client.Events = c.Events
+	c.ExternalStatusChecks = NewMockExternalStatusChecksServiceInterface(ctrl)
+	c.Client.ExternalStatusChecks = c.ExternalStatusChecks
+	c.Features = NewMockFeaturesServiceInterface(ctrl)
+	c.Client.Features = c.Features
+	c.FreezePeriods = NewMockFreezePeriodsServiceInterface(ctrl)
+	c.Client.FreezePeriods = c.FreezePeriods
+	c.GenericPackages = NewMockGenericPackagesServiceInterface(ctrl)
+	c.Client.GenericPackages = c.GenericPackages
+	c.GeoNodes = NewMockGeoNodesServiceInterface(ctrl)
+	c.Client.GeoNodes = c.GeoNodes
+	c.GitIgnoreTemplates = NewMockGitIgnoreTemplatesServiceInterface(ctrl)
+	c.Client.GitIgnoreTemplates = c.GitIgnoreTemplates
+	c.GroupAccessTokens = NewMockGroupAccessTokensServiceInterface(ctrl)
+	c.Client.GroupAccessTokens = c.GroupAccessTokens
+	c.GroupBadges = NewMockGroupBadgesServiceInterface(ctrl)
+	c.Client.GroupBadges = c.GroupBadges
+	c.GroupCluster = NewMockGroupClustersServiceInterface(ctrl)
+	c.Client.GroupCluster = c.GroupCluster
+	c.GroupEpicBoards = NewMockGroupEpicBoardsServiceInterface(ctrl)
+	c.Client.GroupEpicBoards = c.GroupEpicBoards
+	c.GroupImportExport = NewMockGroupImportExportServiceInterface(ctrl)
+	c.Client.GroupImportExport = c.GroupImportExport
+	c.GroupIssueBoards = NewMockGroupIssueBoardsServiceInterface(ctrl)
+	c.Client.GroupIssueBoards = c.GroupIssueBoards
+	c.GroupIterations = NewMockGroupIterationsServiceInterface(ctrl)
+	c.Client.GroupIterations = c.GroupIterations
+	c.GroupLabels = NewMockGroupLabelsServiceInterface(ctrl)
+	c.Client.GroupLabels = c.GroupLabels
+	c.GroupMembers = NewMockGroupMembersServiceInterface(ctrl)
+	c.Client.GroupMembers = c.GroupMembers
+	c.GroupMilestones = NewMockGroupMilestonesServiceInterface(ctrl)
+	c.Client.GroupMilestones = c.GroupMilestones
+	c.GroupProtectedEnvironments = NewMockGroupProtectedEnvironmentsServiceInterface(ctrl)
+	c.Client.GroupProtectedEnvironments = c.GroupProtectedEnvironments
+	c.GroupRepositoryStorageMove = NewMockGroupRepositoryStorageMoveServiceInterface(ctrl)
+	c.Client.GroupRepositoryStorageMove = c.GroupRepositoryStorageMove
+	c.GroupSSHCertificates = NewMockGroupSSHCertificatesServiceInterface(ctrl)
+	c.Client.GroupSSHCertificates = c.GroupSSHCertificates
+	c.GroupVariables = NewMockGroupVariablesServiceInterface(ctrl)
+	c.Client.GroupVariables = c.GroupVariables
+	c.GroupWikis = NewMockGroupWikisServiceInterface(ctrl)
+	c.Client.GroupWikis = c.GroupWikis
+	c.Groups = NewMockGroupsServiceInterface(ctrl)
+	c.Client.Groups = c.Groups
+	c.Import = NewMockImportServiceInterface(ctrl)
+	c.Client.Import = c.Import
+	c.InstanceCluster = NewMockInstanceClustersServiceInterface(ctrl)
+	c.Client.InstanceCluster = c.InstanceCluster
+	c.InstanceVariables = NewMockInstanceVariablesServiceInterface(ctrl)
+	c.Client.InstanceVariables = c.InstanceVariables
+	c.Invites = NewMockInvitesServiceInterface(ctrl)
+	c.Client.Invites = c.Invites
+	c.IssueLinks = NewMockIssueLinksServiceInterface(ctrl)
+	c.Client.IssueLinks = c.IssueLinks
+	c.Issues = NewMockIssuesServiceInterface(ctrl)
+	c.Client.Issues = c.Issues
+	c.IssuesStatistics = NewMockIssuesStatisticsServiceInterface(ctrl)
+	c.Client.IssuesStatistics = c.IssuesStatistics
+	c.Jobs = NewMockJobsServiceInterface(ctrl)
+	c.Client.Jobs = c.Jobs
+	c.JobTokenScope = NewMockJobTokenScopeServiceInterface(ctrl)
+	c.Client.JobTokenScope = c.JobTokenScope
+	c.Keys = NewMockKeysServiceInterface(ctrl)
+	c.Client.Keys = c.Keys
+	c.Labels = NewMockLabelsServiceInterface(ctrl)
+	c.Client.Labels = c.Labels
+	c.License = NewMockLicenseServiceInterface(ctrl)
+	c.Client.License = c.License
+	c.LicenseTemplates = NewMockLicenseTemplatesServiceInterface(ctrl)
+	c.Client.LicenseTemplates = c.LicenseTemplates
+	c.ManagedLicenses = NewMockManagedLicensesServiceInterface(ctrl)
+	c.Client.ManagedLicenses = c.ManagedLicenses
+	c.Markdown = NewMockMarkdownServiceInterface(ctrl)
+	c.Client.Markdown = c.Markdown
+	c.MemberRolesService = NewMockMemberRolesServiceInterface(ctrl)
+	c.Client.MemberRolesService = c.MemberRolesService
+	c.MergeRequestApprovals = NewMockMergeRequestApprovalsServiceInterface(ctrl)
+	c.Client.MergeRequestApprovals = c.MergeRequestApprovals
+	c.MergeRequests = NewMockMergeRequestsServiceInterface(ctrl)
+	c.Client.MergeRequests = c.MergeRequests
+	c.MergeTrains = NewMockMergeTrainsServiceInterface(ctrl)
+	c.Client.MergeTrains = c.MergeTrains
+	c.Metadata = NewMockMetadataServiceInterface(ctrl)
+	c.Client.Metadata = c.Metadata
+	c.Milestones = NewMockMilestonesServiceInterface(ctrl)
+	c.Client.Milestones = c.Milestones
+	c.Namespaces = NewMockNamespacesServiceInterface(ctrl)
+	c.Client.Namespaces = c.Namespaces
+	c.Notes = NewMockNotesServiceInterface(ctrl)
+	c.Client.Notes = c.Notes
+	c.NotificationSettings = NewMockNotificationSettingsServiceInterface(ctrl)
+	c.Client.NotificationSettings = c.NotificationSettings
+	c.Packages = NewMockPackagesServiceInterface(ctrl)
+	c.Client.Packages = c.Packages
+	c.Pages = NewMockPagesServiceInterface(ctrl)
+	c.Client.Pages = c.Pages
+	c.PagesDomains = NewMockPagesDomainsServiceInterface(ctrl)
+	c.Client.PagesDomains = c.PagesDomains
+	c.PersonalAccessTokens = NewMockPersonalAccessTokensServiceInterface(ctrl)
+	c.Client.PersonalAccessTokens = c.PersonalAccessTokens
+	c.PipelineSchedules = NewMockPipelineSchedulesServiceInterface(ctrl)
+	c.Client.PipelineSchedules = c.PipelineSchedules
+	c.PipelineTriggers = NewMockPipelineTriggersServiceInterface(ctrl)
+	c.Client.PipelineTriggers = c.PipelineTriggers
+	c.Pipelines = NewMockPipelinesServiceInterface(ctrl)
+	c.Client.Pipelines = c.Pipelines
+	c.PlanLimits = NewMockPlanLimitsServiceInterface(ctrl)
+	c.Client.PlanLimits = c.PlanLimits
+	c.ProjectAccessTokens = NewMockProjectAccessTokensServiceInterface(ctrl)
+	c.Client.ProjectAccessTokens = c.ProjectAccessTokens
+	c.ProjectBadges = NewMockProjectBadgesServiceInterface(ctrl)
+	c.Client.ProjectBadges = c.ProjectBadges
+	c.ProjectCluster = NewMockProjectClustersServiceInterface(ctrl)
+	c.Client.ProjectCluster = c.ProjectCluster
+	c.ProjectFeatureFlags = NewMockProjectFeatureFlagServiceInterface(ctrl)
+	c.Client.ProjectFeatureFlags = c.ProjectFeatureFlags
+	c.ProjectImportExport = NewMockProjectImportExportServiceInterface(ctrl)
+	c.Client.ProjectImportExport = c.ProjectImportExport
+	c.ProjectIterations = NewMockProjectIterationsServiceInterface(ctrl)
+	c.Client.ProjectIterations = c.ProjectIterations
+	c.ProjectMarkdownUploads = NewMockProjectMarkdownUploadsServiceInterface(ctrl)
+	c.Client.ProjectMarkdownUploads = c.ProjectMarkdownUploads
+	c.ProjectMembers = NewMockProjectMembersServiceInterface(ctrl)
+	c.Client.ProjectMembers = c.ProjectMembers
+	c.ProjectMirrors = NewMockProjectMirrorServiceInterface(ctrl)
+	c.Client.ProjectMirrors = c.ProjectMirrors
+	c.ProjectRepositoryStorageMove = NewMockProjectRepositoryStorageMoveServiceInterface(ctrl)
+	c.Client.ProjectRepositoryStorageMove = c.ProjectRepositoryStorageMove
+	c.ProjectSnippets = NewMockProjectSnippetsServiceInterface(ctrl)
+	c.Client.ProjectSnippets = c.ProjectSnippets
+	c.ProjectTemplates = NewMockProjectTemplatesServiceInterface(ctrl)
+	c.Client.ProjectTemplates = c.ProjectTemplates
+	c.ProjectVariables = NewMockProjectVariablesServiceInterface(ctrl)
+	c.Client.ProjectVariables = c.ProjectVariables
+	c.ProjectVulnerabilities = NewMockProjectVulnerabilitiesServiceInterface(ctrl)
+	c.Client.ProjectVulnerabilities = c.ProjectVulnerabilities
+	c.Projects = NewMockProjectsServiceInterface(ctrl)
+	c.Client.Projects = c.Projects
+	c.ProtectedBranches = NewMockProtectedBranchesServiceInterface(ctrl)
+	c.Client.ProtectedBranches = c.ProtectedBranches
+	c.ProtectedEnvironments = NewMockProtectedEnvironmentsServiceInterface(ctrl)
+	c.Client.ProtectedEnvironments = c.ProtectedEnvironments
+	c.ProtectedTags = NewMockProtectedTagsServiceInterface(ctrl)
+	c.Client.ProtectedTags = c.ProtectedTags
+	c.ReleaseLinks = NewMockReleaseLinksServiceInterface(ctrl)
+	c.Client.ReleaseLinks = c.ReleaseLinks
+	c.Releases = NewMockReleasesServiceInterface(ctrl)
+	c.Client.Releases = c.Releases
+	c.Repositories = NewMockRepositoriesServiceInterface(ctrl)
+	c.Client.Repositories = c.Repositories
+	c.RepositoryFiles = NewMockRepositoryFilesServiceInterface(ctrl)
+	c.Client.RepositoryFiles = c.RepositoryFiles
+	c.RepositorySubmodules = NewMockRepositorySubmodulesServiceInterface(ctrl)
+	c.Client.RepositorySubmodules = c.RepositorySubmodules
+	c.ResourceGroup = NewMockResourceGroupServiceInterface(ctrl)
+	c.Client.ResourceGroup = c.ResourceGroup
+	c.ResourceIterationEvents = NewMockResourceIterationEventsServiceInterface(ctrl)
+	c.Client.ResourceIterationEvents = c.ResourceIterationEvents
+	c.ResourceLabelEvents = NewMockResourceLabelEventsServiceInterface(ctrl)
+	c.Client.ResourceLabelEvents = c.ResourceLabelEvents
+	c.ResourceMilestoneEvents = NewMockResourceMilestoneEventsServiceInterface(ctrl)
+	c.Client.ResourceMilestoneEvents = c.ResourceMilestoneEvents
+	c.ResourceStateEvents = NewMockResourceStateEventsServiceInterface(ctrl)
+	c.Client.ResourceStateEvents = c.ResourceStateEvents
+	c.ResourceWeightEvents = NewMockResourceWeightEventsServiceInterface(ctrl)
+	c.Client.ResourceWeightEvents = c.ResourceWeightEvents
+	c.Runners = NewMockRunnersServiceInterface(ctrl)
+	c.Client.Runners = c.Runners
+	c.Search = NewMockSearchServiceInterface(ctrl)
+	c.Client.Search = c.Search
+	c.Services = NewMockServicesServiceInterface(ctrl)
+	c.Client.Services = c.Services
+	c.Settings = NewMockSettingsServiceInterface(ctrl)
+	c.Client.Settings = c.Settings
+	c.Sidekiq = NewMockSidekiqServiceInterface(ctrl)
+	c.Client.Sidekiq = c.Sidekiq
+	c.SnippetRepositoryStorageMove = NewMockSnippetRepositoryStorageMoveServiceInterface(ctrl)
+	c.Client.SnippetRepositoryStorageMove = c.SnippetRepositoryStorageMove
+	c.Snippets = NewMockSnippetsServiceInterface(ctrl)
+	c.Client.Snippets = c.Snippets
+	c.SystemHooks = NewMockSystemHooksServiceInterface(ctrl)
+	c.Client.SystemHooks = c.SystemHooks
+	c.Tags = NewMockTagsServiceInterface(ctrl)
+	c.Client.Tags = c.Tags
+	c.Todos = NewMockTodosServiceInterface(ctrl)
+	c.Client.Todos = c.Todos
+	c.Topics = NewMockTopicsServiceInterface(ctrl)
+	c.Client.Topics = c.Topics
+	c.Users = NewMockUsersServiceInterface(ctrl)
+	c.Client.Users = c.Users
+	c.Validate = NewMockValidateServiceInterface(ctrl)
+	c.Client.Validate = c.Validate
+	c.Version = NewMockVersionServiceInterface(ctrl)
+	c.Client.Version = c.Version
+	c.Wikis = NewMockWikisServiceInterface(ctrl)
+	c.Client.Wikis = c.Wikis
+
+	return c
+}
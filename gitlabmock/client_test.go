@@ -0,0 +1,55 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabmock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+	"go.uber.org/mock/gomock"
+)
+
+// projectName is an example of code under test, which only depends on the
+// *gitlab.Client.
+func projectName(client *gitlab.Client, pid int) (string, error) {
+	project, _, err := client.Projects.GetProject(pid, nil)
+	if err != nil {
+		return "", err
+	}
+	return project.Name, nil
+}
+
+func TestClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewClient(ctrl)
+
+	client.Projects.EXPECT().
+		GetProject(1, gomock.Nil()).
+		Return(&gitlab.Project{ID: 1, Name: "test"}, &gitlab.Response{}, nil)
+	client.Projects.EXPECT().
+		GetProject(2, gomock.Nil()).
+		Return(nil, nil, gitlab.ErrNotFound)
+
+	name, err := projectName(client.Client, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "test", name)
+
+	_, err = projectName(client.Client, 2)
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+}
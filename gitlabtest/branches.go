@@ -0,0 +1,219 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// AddBranch adds a new branch to the given project, pointing to a new commit
+// with the given title on top of ref. It returns nil if the project or ref
+// doesn't exist.
+func (s *Server) AddBranch(pid interface{}, branch, ref, title string) *gitlab.Branch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	parent := resolveRef(p, ref)
+	if parent == nil {
+		return nil
+	}
+
+	b := s.addBranch(p, branch, s.newCommit(p, title, parent))
+	result := *b
+
+	return &result
+}
+
+// Branch returns a copy of the current state of the given branch, or nil if
+// it doesn't exist.
+func (s *Server) Branch(pid interface{}, branch string) *gitlab.Branch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	b := findBranch(p, branch)
+	if b == nil {
+		return nil
+	}
+	result := *b
+
+	return &result
+}
+
+// newCommit returns a new commit on top of parent. Must be called with the
+// lock held.
+func (s *Server) newCommit(p *project, title string, parent *gitlab.Commit) *gitlab.Commit {
+	id := sha(p.ID, s.nextID(), title)
+
+	c := &gitlab.Commit{
+		ID:             id,
+		ShortID:        id[:8],
+		Title:          title,
+		Message:        title,
+		AuthorName:     s.user.Name,
+		AuthorEmail:    s.user.Username + "@example.com",
+		AuthoredDate:   now(),
+		CommitterName:  s.user.Name,
+		CommitterEmail: s.user.Username + "@example.com",
+		CommittedDate:  now(),
+		CreatedAt:      now(),
+		ParentIDs:      []string{},
+		ProjectID:      p.ID,
+		WebURL:         p.WebURL + "/-/commit/" + id,
+	}
+	if parent != nil {
+		c.ParentIDs = []string{parent.ID}
+	}
+
+	return c
+}
+
+// addBranch adds a branch pointing to the given commit, replacing any
+// existing branch with the same name. Must be called with the lock held.
+func (s *Server) addBranch(p *project, name string, commit *gitlab.Commit) *gitlab.Branch {
+	p.branches = filter(p.branches, func(b *gitlab.Branch) bool { return b.Name != name })
+
+	b := &gitlab.Branch{
+		Name:               name,
+		Commit:             commit,
+		Default:            name == p.DefaultBranch,
+		CanPush:            true,
+		DevelopersCanPush:  true,
+		DevelopersCanMerge: true,
+		WebURL:             p.WebURL + "/-/tree/" + name,
+	}
+	p.branches = append(p.branches, b)
+
+	return b
+}
+
+// findBranch returns the branch with the given name.
+func findBranch(p *project, name string) *gitlab.Branch {
+	for _, b := range p.branches {
+		if b.Name == name {
+			return b
+		}
+	}
+	return nil
+}
+
+// resolveRef returns the commit the given branch name or commit SHA refers to.
+func resolveRef(p *project, ref string) *gitlab.Commit {
+	if b := findBranch(p, ref); b != nil {
+		return b.Commit
+	}
+	if len(ref) >= 8 {
+		for _, b := range p.branches {
+			if strings.HasPrefix(b.Commit.ID, ref) {
+				return b.Commit
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) listBranches(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	search := r.URL.Query().Get("search")
+	branches := filter(p.branches, func(b *gitlab.Branch) bool {
+		return strings.Contains(b.Name, search)
+	})
+
+	paginate(w, r, branches)
+}
+
+func (s *Server) createBranch(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.CreateBranchOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+	if opt.Branch == nil || *opt.Branch == "" {
+		writeMissingError(w, "branch")
+		return
+	}
+	if opt.Ref == nil || *opt.Ref == "" {
+		writeMissingError(w, "ref")
+		return
+	}
+
+	if findBranch(p, *opt.Branch) != nil {
+		writeError(w, http.StatusBadRequest, "Branch already exists")
+		return
+	}
+	commit := resolveRef(p, *opt.Ref)
+	if commit == nil {
+		writeError(w, http.StatusBadRequest, "Invalid reference name: "+*opt.Ref)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.addBranch(p, *opt.Branch, commit))
+}
+
+func (s *Server) getBranch(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	b := findBranch(p, params["branch"])
+	if b == nil {
+		writeError(w, http.StatusNotFound, "404 Branch Not Found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, b)
+}
+
+func (s *Server) deleteBranch(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	b := findBranch(p, params["branch"])
+	if b == nil {
+		writeError(w, http.StatusNotFound, "404 Branch Not Found")
+		return
+	}
+	if b.Default {
+		writeError(w, http.StatusMethodNotAllowed, "405 Method Not Allowed")
+		return
+	}
+
+	p.branches = filter(p.branches, func(other *gitlab.Branch) bool { return other != b })
+
+	w.WriteHeader(http.StatusNoContent)
+}
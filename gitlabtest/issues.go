@@ -0,0 +1,204 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Issue returns a copy of the current state of the given issue, or nil if it
+// doesn't exist.
+func (s *Server) Issue(pid interface{}, issue int) *gitlab.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	i := findIssue(p, issue)
+	if i == nil {
+		return nil
+	}
+	result := *i
+
+	return &result
+}
+
+// findIssue returns the issue with the given IID.
+func findIssue(p *project, iid int) *gitlab.Issue {
+	for _, i := range p.issues {
+		if i.IID == iid {
+			return i
+		}
+	}
+	return nil
+}
+
+// lookupIssue returns the issue from the request parameters, or writes a 404
+// response if it doesn't exist.
+func (s *Server) lookupIssue(w http.ResponseWriter, params map[string]string) (*project, *gitlab.Issue, bool) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	iid, ok := intParam(w, params, "iid", "404 Not found")
+	if !ok {
+		return nil, nil, false
+	}
+	i := findIssue(p, iid)
+	if i == nil {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return nil, nil, false
+	}
+	return p, i, true
+}
+
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	issues := make([]*gitlab.Issue, 0, len(p.issues))
+	for i := len(p.issues) - 1; i >= 0; i-- {
+		issue := p.issues[i]
+		switch {
+		case q.Get("state") != "" && q.Get("state") != "all" && q.Get("state") != issue.State:
+		case !hasLabels(issue.Labels, q.Get("labels")):
+		default:
+			issues = append(issues, issue)
+		}
+	}
+
+	paginate(w, r, issues)
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.CreateIssueOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+	if opt.Title == nil || *opt.Title == "" {
+		writeMissingError(w, "title")
+		return
+	}
+
+	iid := 1
+	for _, other := range p.issues {
+		if other.IID >= iid {
+			iid = other.IID + 1
+		}
+	}
+	issue := &gitlab.Issue{
+		ID:        s.nextID(),
+		IID:       iid,
+		ProjectID: p.ID,
+		Title:     *opt.Title,
+		State:     "opened",
+		Labels:    splitLabels(opt.Labels),
+		Author: &gitlab.IssueAuthor{
+			ID:       s.user.ID,
+			Username: s.user.Username,
+			Name:     s.user.Name,
+			State:    s.user.State,
+		},
+		WebURL:    p.WebURL + "/-/issues/" + strconv.Itoa(iid),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	if opt.Description != nil {
+		issue.Description = *opt.Description
+	}
+	if opt.Confidential != nil {
+		issue.Confidential = *opt.Confidential
+	}
+	p.issues = append(p.issues, issue)
+	p.OpenIssuesCount++
+
+	writeJSON(w, http.StatusCreated, issue)
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, issue, ok := s.lookupIssue(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, issue)
+}
+
+func (s *Server) updateIssue(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, issue, ok := s.lookupIssue(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.UpdateIssueOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+
+	if opt.Title != nil {
+		issue.Title = *opt.Title
+	}
+	if opt.Description != nil {
+		issue.Description = *opt.Description
+	}
+	if opt.Confidential != nil {
+		issue.Confidential = *opt.Confidential
+	}
+	issue.Labels = updateLabels(issue.Labels, opt.Labels, opt.AddLabels, opt.RemoveLabels)
+
+	if opt.StateEvent != nil {
+		switch {
+		case *opt.StateEvent == "close" && issue.State == "opened":
+			issue.State = "closed"
+			issue.ClosedAt = now()
+			p.OpenIssuesCount--
+		case *opt.StateEvent == "reopen" && issue.State == "closed":
+			issue.State = "opened"
+			issue.ClosedAt = nil
+			p.OpenIssuesCount++
+		}
+	}
+	issue.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, issue)
+}
+
+func (s *Server) deleteIssue(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, issue, ok := s.lookupIssue(w, params)
+	if !ok {
+		return
+	}
+
+	p.issues = filter(p.issues, func(other *gitlab.Issue) bool { return other != issue })
+	if issue.State == "opened" {
+		p.OpenIssuesCount--
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,268 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// AddJob adds a new pending job to the given pipeline. It returns nil if the
+// pipeline doesn't exist.
+func (s *Server) AddJob(pid interface{}, pipeline int, stage, name string) *gitlab.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	pl := findPipeline(p, pipeline)
+	if pl == nil {
+		return nil
+	}
+
+	job := s.addJob(p, pl, stage, name)
+	updatePipelineStatus(p, pl)
+	result := *job
+
+	return &result
+}
+
+// Job returns a copy of the current state of the given job, or nil if it
+// doesn't exist.
+func (s *Server) Job(pid interface{}, job int) *gitlab.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	j := findJob(p, job)
+	if j == nil {
+		return nil
+	}
+	result := *j
+
+	return &result
+}
+
+// SetJobStatus sets the status of the given job and updates the status of
+// its pipeline to match the status of all its jobs. It returns false if the
+// job doesn't exist.
+func (s *Server) SetJobStatus(pid interface{}, job int, status gitlab.BuildStateValue) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return false
+	}
+	j := findJob(p, job)
+	if j == nil {
+		return false
+	}
+
+	setJobStatus(j, string(status))
+	if pl := findPipeline(p, j.Pipeline.ID); pl != nil {
+		updatePipelineStatus(p, pl)
+	}
+
+	return true
+}
+
+// addJob adds a new pending job. Must be called with the lock held.
+func (s *Server) addJob(p *project, pipeline *gitlab.Pipeline, stage, name string) *gitlab.Job {
+	id := s.nextID()
+	job := &gitlab.Job{
+		ID:        id,
+		Name:      name,
+		Stage:     stage,
+		Status:    string(gitlab.Pending),
+		Ref:       pipeline.Ref,
+		TagList:   []string{},
+		CreatedAt: now(),
+		Commit: &gitlab.Commit{
+			ID:        pipeline.SHA,
+			ShortID:   pipeline.SHA[:8],
+			ProjectID: p.ID,
+		},
+		User:   s.user,
+		WebURL: p.WebURL + "/-/jobs/" + strconv.Itoa(id),
+	}
+	job.Pipeline.ID = pipeline.ID
+	job.Pipeline.ProjectID = p.ID
+	job.Pipeline.Ref = pipeline.Ref
+	job.Pipeline.Sha = pipeline.SHA
+	job.Pipeline.Status = pipeline.Status
+	p.jobs = append(p.jobs, job)
+
+	return job
+}
+
+// findJob returns the job with the given ID.
+func findJob(p *project, id int) *gitlab.Job {
+	for _, job := range p.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// lookupJob returns the job from the request parameters, or writes a 404
+// response if it doesn't exist.
+func (s *Server) lookupJob(w http.ResponseWriter, params map[string]string) (*project, *gitlab.Job, bool) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	id, ok := intParam(w, params, "job", "404 Not found")
+	if !ok {
+		return nil, nil, false
+	}
+	job := findJob(p, id)
+	if job == nil {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return nil, nil, false
+	}
+	job.Pipeline.Status = findPipeline(p, job.Pipeline.ID).Status
+	return p, job, true
+}
+
+// setJobStatus sets the status of the job and updates its timestamps
+// accordingly.
+func setJobStatus(job *gitlab.Job, status string) {
+	job.Status = status
+
+	switch {
+	case isTerminal(status):
+		if job.StartedAt == nil {
+			job.StartedAt = now()
+		}
+		job.FinishedAt = now()
+		job.Duration = job.FinishedAt.Sub(*job.StartedAt).Seconds()
+	case status == string(gitlab.Running):
+		if job.StartedAt == nil {
+			job.StartedAt = now()
+		}
+		job.FinishedAt = nil
+	default:
+		job.StartedAt = nil
+		job.FinishedAt = nil
+	}
+}
+
+// retryJobLocked replaces the given job with a new pending job. Must be
+// called with the lock held.
+func (s *Server) retryJobLocked(p *project, job *gitlab.Job) *gitlab.Job {
+	pipeline := findPipeline(p, job.Pipeline.ID)
+	retry := s.addJob(p, pipeline, job.Stage, job.Name)
+	retry.AllowFailure = job.AllowFailure
+	p.retried[job.ID] = true
+
+	return retry
+}
+
+// filterJobs returns the jobs matching the scope[] query parameters, leaving
+// out retried jobs unless include_retried is set.
+func filterJobs(p *project, r *http.Request, keep func(*gitlab.Job) bool) []*gitlab.Job {
+	q := r.URL.Query()
+	scopes := q["scope[]"]
+	includeRetried := q.Get("include_retried") == "true"
+
+	jobs := make([]*gitlab.Job, 0, len(p.jobs))
+	for i := len(p.jobs) - 1; i >= 0; i-- {
+		job := p.jobs[i]
+		if !keep(job) || (p.retried[job.ID] && !includeRetried) {
+			continue
+		}
+
+		match := len(scopes) == 0
+		for _, scope := range scopes {
+			match = match || scope == job.Status
+		}
+		if match {
+			job.Pipeline.Status = findPipeline(p, job.Pipeline.ID).Status
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs
+}
+
+func (s *Server) listPipelineJobs(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, pipeline, ok := s.lookupPipeline(w, params)
+	if !ok {
+		return
+	}
+
+	paginate(w, r, filterJobs(p, r, func(job *gitlab.Job) bool {
+		return job.Pipeline.ID == pipeline.ID
+	}))
+}
+
+func (s *Server) listJobs(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	paginate(w, r, filterJobs(p, r, func(*gitlab.Job) bool { return true }))
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, job, ok := s.lookupJob(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) cancelJob(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, job, ok := s.lookupJob(w, params)
+	if !ok {
+		return
+	}
+
+	if !isTerminal(job.Status) {
+		setJobStatus(job, string(gitlab.Canceled))
+		updatePipelineStatus(p, findPipeline(p, job.Pipeline.ID))
+	}
+
+	writeJSON(w, http.StatusCreated, job)
+}
+
+func (s *Server) retryJob(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, job, ok := s.lookupJob(w, params)
+	if !ok {
+		return
+	}
+
+	if job.Status != string(gitlab.Failed) && job.Status != string(gitlab.Canceled) && job.Status != string(gitlab.Success) {
+		writeError(w, http.StatusForbidden, "403 Forbidden  - Job is not retryable")
+		return
+	}
+
+	retry := s.retryJobLocked(p, job)
+	updatePipelineStatus(p, findPipeline(p, job.Pipeline.ID))
+
+	writeJSON(w, http.StatusCreated, retry)
+}
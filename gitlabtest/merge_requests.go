@@ -0,0 +1,301 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// MergeRequest returns a copy of the current state of the given merge
+// request, or nil if it doesn't exist.
+func (s *Server) MergeRequest(pid interface{}, mergeRequest int) *gitlab.MergeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	mr := findMergeRequest(p, mergeRequest)
+	if mr == nil {
+		return nil
+	}
+	result := *mr
+
+	return &result
+}
+
+// findMergeRequest returns the merge request with the given IID.
+func findMergeRequest(p *project, iid int) *gitlab.MergeRequest {
+	for _, mr := range p.mergeRequests {
+		if mr.IID == iid {
+			return mr
+		}
+	}
+	return nil
+}
+
+// lookupMergeRequest returns the merge request from the request parameters,
+// or writes a 404 response if it doesn't exist.
+func (s *Server) lookupMergeRequest(w http.ResponseWriter, params map[string]string) (*project, *gitlab.MergeRequest, bool) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	iid, ok := intParam(w, params, "iid", "404 Not found")
+	if !ok {
+		return nil, nil, false
+	}
+	mr := findMergeRequest(p, iid)
+	if mr == nil {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return nil, nil, false
+	}
+
+	// Keep the SHA and head pipeline in sync with the source branch.
+	if mr.State == "opened" {
+		if b := findBranch(p, mr.SourceBranch); b != nil {
+			mr.SHA = b.Commit.ID
+		}
+	}
+	mr.HeadPipeline = nil
+	for _, pipeline := range p.pipelines {
+		if pipeline.SHA == mr.SHA {
+			mr.HeadPipeline = pipeline
+		}
+	}
+
+	return p, mr, true
+}
+
+func (s *Server) listMergeRequests(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	mergeRequests := make([]*gitlab.MergeRequest, 0, len(p.mergeRequests))
+	for i := len(p.mergeRequests) - 1; i >= 0; i-- {
+		mr := p.mergeRequests[i]
+		switch {
+		case q.Get("state") != "" && q.Get("state") != "all" && q.Get("state") != mr.State:
+		case q.Get("source_branch") != "" && q.Get("source_branch") != mr.SourceBranch:
+		case q.Get("target_branch") != "" && q.Get("target_branch") != mr.TargetBranch:
+		case !hasLabels(mr.Labels, q.Get("labels")):
+		default:
+			mergeRequests = append(mergeRequests, mr)
+		}
+	}
+
+	paginate(w, r, mergeRequests)
+}
+
+func (s *Server) createMergeRequest(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.CreateMergeRequestOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+	for _, param := range []struct {
+		name  string
+		value *string
+	}{
+		{"source_branch", opt.SourceBranch},
+		{"target_branch", opt.TargetBranch},
+		{"title", opt.Title},
+	} {
+		if param.value == nil || *param.value == "" {
+			writeMissingError(w, param.name)
+			return
+		}
+	}
+
+	source := findBranch(p, *opt.SourceBranch)
+	if source == nil {
+		writeValidationError(w, "source_branch", "is invalid")
+		return
+	}
+	if findBranch(p, *opt.TargetBranch) == nil {
+		writeValidationError(w, "target_branch", "is invalid")
+		return
+	}
+	if source.Name == *opt.TargetBranch {
+		writeValidationError(w, "source_branch", "must be different from target branch")
+		return
+	}
+	for _, other := range p.mergeRequests {
+		if other.State == "opened" && other.SourceBranch == source.Name && other.TargetBranch == *opt.TargetBranch {
+			writeJSON(w, http.StatusConflict, map[string][]string{
+				"message": {fmt.Sprintf("Another open merge request already exists for this source branch: !%d", other.IID)},
+			})
+			return
+		}
+	}
+
+	iid := len(p.mergeRequests) + 1
+	mr := &gitlab.MergeRequest{
+		ID:                  s.nextID(),
+		IID:                 iid,
+		ProjectID:           p.ID,
+		SourceProjectID:     p.ID,
+		TargetProjectID:     p.ID,
+		Title:               *opt.Title,
+		State:               "opened",
+		SourceBranch:        source.Name,
+		TargetBranch:        *opt.TargetBranch,
+		Author:              s.basicUser(),
+		Labels:              splitLabels(opt.Labels),
+		SHA:                 source.Commit.ID,
+		MergeStatus:         "can_be_merged",
+		DetailedMergeStatus: "mergeable",
+		WebURL:              p.WebURL + "/-/merge_requests/" + strconv.Itoa(iid),
+		CreatedAt:           now(),
+		UpdatedAt:           now(),
+	}
+	if opt.Description != nil {
+		mr.Description = *opt.Description
+	}
+	if opt.RemoveSourceBranch != nil {
+		mr.ForceRemoveSourceBranch = *opt.RemoveSourceBranch
+	}
+	p.mergeRequests = append(p.mergeRequests, mr)
+
+	writeJSON(w, http.StatusCreated, mr)
+}
+
+func (s *Server) getMergeRequest(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, mr, ok := s.lookupMergeRequest(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, mr)
+}
+
+func (s *Server) updateMergeRequest(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, mr, ok := s.lookupMergeRequest(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.UpdateMergeRequestOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+
+	if opt.Title != nil {
+		mr.Title = *opt.Title
+	}
+	if opt.Description != nil {
+		mr.Description = *opt.Description
+	}
+	if opt.TargetBranch != nil {
+		if findBranch(p, *opt.TargetBranch) == nil {
+			writeValidationError(w, "target_branch", "is invalid")
+			return
+		}
+		mr.TargetBranch = *opt.TargetBranch
+	}
+	if opt.RemoveSourceBranch != nil {
+		mr.ForceRemoveSourceBranch = *opt.RemoveSourceBranch
+	}
+	mr.Labels = updateLabels(mr.Labels, opt.Labels, opt.AddLabels, opt.RemoveLabels)
+
+	if opt.StateEvent != nil {
+		switch {
+		case *opt.StateEvent == "close" && mr.State == "opened":
+			mr.State = "closed"
+			mr.ClosedAt = now()
+		case *opt.StateEvent == "reopen" && mr.State == "closed":
+			mr.State = "opened"
+			mr.ClosedAt = nil
+		}
+	}
+	mr.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, mr)
+}
+
+func (s *Server) acceptMergeRequest(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, mr, ok := s.lookupMergeRequest(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.AcceptMergeRequestOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+
+	if mr.State != "opened" {
+		writeError(w, http.StatusMethodNotAllowed, "405 Method Not Allowed")
+		return
+	}
+	if opt.SHA != nil && *opt.SHA != mr.SHA {
+		writeError(w, http.StatusConflict, "SHA does not match HEAD of source branch: "+mr.SHA)
+		return
+	}
+	target := findBranch(p, mr.TargetBranch)
+	if target == nil || findBranch(p, mr.SourceBranch) == nil {
+		writeError(w, http.StatusUnprocessableEntity, "Branch cannot be merged")
+		return
+	}
+
+	message := fmt.Sprintf("Merge branch '%s' into '%s'", mr.SourceBranch, mr.TargetBranch)
+	if opt.MergeCommitMessage != nil {
+		message = *opt.MergeCommitMessage
+	}
+	commit := s.newCommit(p, message, target.Commit)
+	commit.ParentIDs = append(commit.ParentIDs, mr.SHA)
+	target.Commit = commit
+
+	mr.State = "merged"
+	mr.MergedAt = now()
+	mr.MergedBy = s.basicUser()
+	mr.MergeCommitSHA = commit.ID
+	mr.UpdatedAt = now()
+
+	if (opt.ShouldRemoveSourceBranch != nil && *opt.ShouldRemoveSourceBranch) || mr.ForceRemoveSourceBranch {
+		p.branches = filter(p.branches, func(b *gitlab.Branch) bool { return b.Name != mr.SourceBranch })
+	}
+
+	writeJSON(w, http.StatusOK, mr)
+}
+
+func (s *Server) listMergeRequestPipelines(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, mr, ok := s.lookupMergeRequest(w, params)
+	if !ok {
+		return
+	}
+
+	pipelines := []*gitlab.PipelineInfo{}
+	for i := len(p.pipelines) - 1; i >= 0; i-- {
+		if pipeline := p.pipelines[i]; pipeline.Ref == mr.SourceBranch {
+			pipelines = append(pipelines, pipelineInfo(pipeline))
+		}
+	}
+
+	paginate(w, r, pipelines)
+}
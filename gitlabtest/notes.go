@@ -0,0 +1,212 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// noteableTypes maps the API path of a noteable to its type.
+var noteableTypes = map[string]string{
+	"merge_requests": "MergeRequest",
+	"issues":         "Issue",
+}
+
+// MergeRequestNotes returns a copy of the notes of the given merge request.
+func (s *Server) MergeRequestNotes(pid interface{}, mergeRequest int) []*gitlab.Note {
+	return s.copyNotes(pid, "merge_requests", mergeRequest)
+}
+
+// IssueNotes returns a copy of the notes of the given issue.
+func (s *Server) IssueNotes(pid interface{}, issue int) []*gitlab.Note {
+	return s.copyNotes(pid, "issues", issue)
+}
+
+func (s *Server) copyNotes(pid interface{}, noteable string, iid int) []*gitlab.Note {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+
+	var notes []*gitlab.Note
+	for _, n := range p.notes[noteableKey(noteable, iid)] {
+		note := *n
+		notes = append(notes, &note)
+	}
+
+	return notes
+}
+
+func noteableKey(noteable string, iid int) string {
+	return noteable + "/" + strconv.Itoa(iid)
+}
+
+// lookupNoteable returns the project and the key of the notes of the
+// noteable from the request parameters, or writes a 404 response if the
+// noteable doesn't exist.
+func (s *Server) lookupNoteable(w http.ResponseWriter, noteable string, params map[string]string) (*project, string, int, bool) {
+	var (
+		p  *project
+		id int
+		ok bool
+	)
+	switch noteable {
+	case "merge_requests":
+		var mr *gitlab.MergeRequest
+		if p, mr, ok = s.lookupMergeRequest(w, params); ok {
+			id = mr.ID
+		}
+	case "issues":
+		var issue *gitlab.Issue
+		if p, issue, ok = s.lookupIssue(w, params); ok {
+			id = issue.ID
+		}
+	}
+	if !ok {
+		return nil, "", 0, false
+	}
+
+	iid, _ := strconv.Atoi(params["iid"])
+
+	return p, noteableKey(noteable, iid), id, true
+}
+
+// lookupNote returns the note from the request parameters, or writes a 404
+// response if it doesn't exist.
+func (s *Server) lookupNote(w http.ResponseWriter, noteable string, params map[string]string) (*project, string, *gitlab.Note, bool) {
+	p, key, _, ok := s.lookupNoteable(w, noteable, params)
+	if !ok {
+		return nil, "", nil, false
+	}
+	id, ok := intParam(w, params, "note", "404 Note Not Found")
+	if !ok {
+		return nil, "", nil, false
+	}
+	for _, n := range p.notes[key] {
+		if n.ID == id {
+			return p, key, n, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "404 Note Not Found")
+	return nil, "", nil, false
+}
+
+func (s *Server) listNotes(noteable string) func(http.ResponseWriter, *http.Request, map[string]string) {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		p, key, _, ok := s.lookupNoteable(w, noteable, params)
+		if !ok {
+			return
+		}
+
+		// Like GitLab, return the most recent notes first by default.
+		notes := make([]*gitlab.Note, 0, len(p.notes[key]))
+		for i := len(p.notes[key]) - 1; i >= 0; i-- {
+			notes = append(notes, p.notes[key][i])
+		}
+		if r.URL.Query().Get("sort") == "asc" {
+			notes = append(notes[:0:0], p.notes[key]...)
+		}
+
+		paginate(w, r, notes)
+	}
+}
+
+func (s *Server) createNote(noteable string) func(http.ResponseWriter, *http.Request, map[string]string) {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		p, key, noteableID, ok := s.lookupNoteable(w, noteable, params)
+		if !ok {
+			return
+		}
+
+		var opt gitlab.CreateMergeRequestNoteOptions
+		if !decodeBody(w, r, &opt) {
+			return
+		}
+		if opt.Body == nil || *opt.Body == "" {
+			writeMissingError(w, "body")
+			return
+		}
+
+		note := &gitlab.Note{
+			ID:           s.nextID(),
+			Body:         *opt.Body,
+			NoteableID:   noteableID,
+			NoteableType: noteableTypes[noteable],
+			ProjectID:    p.ID,
+			CreatedAt:    now(),
+			UpdatedAt:    now(),
+		}
+		note.NoteableIID, _ = strconv.Atoi(params["iid"])
+		note.Author.ID = s.user.ID
+		note.Author.Username = s.user.Username
+		note.Author.Name = s.user.Name
+		note.Author.State = s.user.State
+		p.notes[key] = append(p.notes[key], note)
+
+		writeJSON(w, http.StatusCreated, note)
+	}
+}
+
+func (s *Server) getNote(noteable string) func(http.ResponseWriter, *http.Request, map[string]string) {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		_, _, note, ok := s.lookupNote(w, noteable, params)
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, note)
+	}
+}
+
+func (s *Server) updateNote(noteable string) func(http.ResponseWriter, *http.Request, map[string]string) {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		_, _, note, ok := s.lookupNote(w, noteable, params)
+		if !ok {
+			return
+		}
+
+		var opt gitlab.UpdateMergeRequestNoteOptions
+		if !decodeBody(w, r, &opt) {
+			return
+		}
+		if opt.Body != nil {
+			note.Body = *opt.Body
+		}
+		note.UpdatedAt = now()
+
+		writeJSON(w, http.StatusOK, note)
+	}
+}
+
+func (s *Server) deleteNote(noteable string) func(http.ResponseWriter, *http.Request, map[string]string) {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		p, key, note, ok := s.lookupNote(w, noteable, params)
+		if !ok {
+			return
+		}
+
+		p.notes[key] = filter(p.notes[key], func(other *gitlab.Note) bool { return other != note })
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
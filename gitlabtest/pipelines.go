@@ -0,0 +1,321 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// AddPipeline adds a new pending pipeline for the given ref to the project.
+// It returns nil if the project or ref doesn't exist.
+func (s *Server) AddPipeline(pid interface{}, ref string) *gitlab.Pipeline {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	commit := resolveRef(p, ref)
+	if commit == nil {
+		return nil
+	}
+	pipeline := *s.addPipeline(p, ref, commit, "push")
+
+	return &pipeline
+}
+
+// Pipeline returns a copy of the current state of the given pipeline, or nil
+// if it doesn't exist.
+func (s *Server) Pipeline(pid interface{}, pipeline int) *gitlab.Pipeline {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	pl := findPipeline(p, pipeline)
+	if pl == nil {
+		return nil
+	}
+	result := *pl
+
+	return &result
+}
+
+// SetPipelineStatus sets the status of the given pipeline. It returns false
+// if the pipeline doesn't exist.
+func (s *Server) SetPipelineStatus(pid interface{}, pipeline int, status gitlab.BuildStateValue) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return false
+	}
+	pl := findPipeline(p, pipeline)
+	if pl == nil {
+		return false
+	}
+	setPipelineStatus(pl, string(status))
+
+	return true
+}
+
+// addPipeline adds a new pending pipeline. Must be called with the lock held.
+func (s *Server) addPipeline(p *project, ref string, commit *gitlab.Commit, source string) *gitlab.Pipeline {
+	id := s.nextID()
+	pipeline := &gitlab.Pipeline{
+		ID:        id,
+		IID:       len(p.pipelines) + 1,
+		ProjectID: p.ID,
+		Status:    string(gitlab.Pending),
+		Source:    source,
+		Ref:       ref,
+		SHA:       commit.ID,
+		BeforeSHA: "0000000000000000000000000000000000000000",
+		User:      s.basicUser(),
+		WebURL:    p.WebURL + "/-/pipelines/" + strconv.Itoa(id),
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	if len(commit.ParentIDs) > 0 {
+		pipeline.BeforeSHA = commit.ParentIDs[0]
+	}
+	p.pipelines = append(p.pipelines, pipeline)
+
+	return pipeline
+}
+
+// findPipeline returns the pipeline with the given ID.
+func findPipeline(p *project, id int) *gitlab.Pipeline {
+	for _, pipeline := range p.pipelines {
+		if pipeline.ID == id {
+			return pipeline
+		}
+	}
+	return nil
+}
+
+// lookupPipeline returns the pipeline from the request parameters, or writes
+// a 404 response if it doesn't exist.
+func (s *Server) lookupPipeline(w http.ResponseWriter, params map[string]string) (*project, *gitlab.Pipeline, bool) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	id, ok := intParam(w, params, "pipeline", "404 Not found")
+	if !ok {
+		return nil, nil, false
+	}
+	pipeline := findPipeline(p, id)
+	if pipeline == nil {
+		writeError(w, http.StatusNotFound, "404 Not found")
+		return nil, nil, false
+	}
+	return p, pipeline, true
+}
+
+// isTerminal reports whether the given pipeline or job status is final.
+func isTerminal(status string) bool {
+	switch gitlab.BuildStateValue(status) {
+	case gitlab.Success, gitlab.Failed, gitlab.Canceled, gitlab.Skipped:
+		return true
+	}
+	return false
+}
+
+// setPipelineStatus sets the status of the pipeline and updates its
+// timestamps accordingly.
+func setPipelineStatus(pipeline *gitlab.Pipeline, status string) {
+	pipeline.Status = status
+	pipeline.UpdatedAt = now()
+
+	switch {
+	case isTerminal(status):
+		if pipeline.StartedAt == nil {
+			pipeline.StartedAt = now()
+		}
+		pipeline.FinishedAt = now()
+		pipeline.Duration = int(pipeline.FinishedAt.Sub(*pipeline.StartedAt).Seconds())
+	case status == string(gitlab.Running):
+		if pipeline.StartedAt == nil {
+			pipeline.StartedAt = now()
+		}
+		pipeline.FinishedAt = nil
+	default:
+		pipeline.StartedAt = nil
+		pipeline.FinishedAt = nil
+	}
+}
+
+// updatePipelineStatus derives the status of the pipeline from its jobs.
+func updatePipelineStatus(p *project, pipeline *gitlab.Pipeline) {
+	var jobs []*gitlab.Job
+	for _, job := range p.jobs {
+		if job.Pipeline.ID == pipeline.ID && !p.retried[job.ID] {
+			jobs = append(jobs, job)
+		}
+	}
+	if len(jobs) == 0 {
+		return
+	}
+
+	var running, pending, failed, canceled, skipped bool
+	for _, job := range jobs {
+		switch gitlab.BuildStateValue(job.Status) {
+		case gitlab.Running:
+			running = true
+		case gitlab.Created, gitlab.Pending, gitlab.WaitingForResource, gitlab.Preparing:
+			pending = true
+		case gitlab.Failed:
+			failed = failed || !job.AllowFailure
+		case gitlab.Canceled:
+			canceled = true
+		case gitlab.Skipped:
+			skipped = true
+		}
+	}
+
+	status := gitlab.Success
+	switch {
+	case running || (pending && pipeline.StartedAt != nil):
+		status = gitlab.Running
+	case pending:
+		status = gitlab.Pending
+	case failed:
+		status = gitlab.Failed
+	case canceled:
+		status = gitlab.Canceled
+	case skipped && len(jobs) == 1:
+		status = gitlab.Skipped
+	}
+	setPipelineStatus(pipeline, string(status))
+}
+
+// pipelineInfo returns the given pipeline as a *gitlab.PipelineInfo.
+func pipelineInfo(pipeline *gitlab.Pipeline) *gitlab.PipelineInfo {
+	return &gitlab.PipelineInfo{
+		ID:        pipeline.ID,
+		IID:       pipeline.IID,
+		ProjectID: pipeline.ProjectID,
+		Status:    pipeline.Status,
+		Source:    pipeline.Source,
+		Ref:       pipeline.Ref,
+		SHA:       pipeline.SHA,
+		WebURL:    pipeline.WebURL,
+		UpdatedAt: pipeline.UpdatedAt,
+		CreatedAt: pipeline.CreatedAt,
+	}
+}
+
+func (s *Server) listPipelines(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	pipelines := make([]*gitlab.PipelineInfo, 0, len(p.pipelines))
+	for i := len(p.pipelines) - 1; i >= 0; i-- {
+		pipeline := p.pipelines[i]
+		switch {
+		case q.Get("ref") != "" && q.Get("ref") != pipeline.Ref:
+		case q.Get("sha") != "" && q.Get("sha") != pipeline.SHA:
+		case q.Get("status") != "" && q.Get("status") != pipeline.Status:
+		case q.Get("source") != "" && q.Get("source") != pipeline.Source:
+		default:
+			pipelines = append(pipelines, pipelineInfo(pipeline))
+		}
+	}
+
+	paginate(w, r, pipelines)
+}
+
+func (s *Server) createPipeline(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.CreatePipelineOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+	if opt.Ref == nil || *opt.Ref == "" {
+		writeMissingError(w, "ref")
+		return
+	}
+
+	b := findBranch(p, *opt.Ref)
+	if b == nil {
+		writeValidationError(w, "base", "Reference not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.addPipeline(p, b.Name, b.Commit, "api"))
+}
+
+func (s *Server) getPipeline(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, pipeline, ok := s.lookupPipeline(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, pipeline)
+}
+
+func (s *Server) cancelPipeline(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, pipeline, ok := s.lookupPipeline(w, params)
+	if !ok {
+		return
+	}
+
+	for _, job := range p.jobs {
+		if job.Pipeline.ID == pipeline.ID && !isTerminal(job.Status) {
+			setJobStatus(job, string(gitlab.Canceled))
+		}
+	}
+	if !isTerminal(pipeline.Status) {
+		setPipelineStatus(pipeline, string(gitlab.Canceled))
+	}
+
+	writeJSON(w, http.StatusOK, pipeline)
+}
+
+func (s *Server) retryPipeline(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, pipeline, ok := s.lookupPipeline(w, params)
+	if !ok {
+		return
+	}
+
+	retried := false
+	for _, job := range p.jobs {
+		if job.Pipeline.ID == pipeline.ID && !p.retried[job.ID] && (job.Status == string(gitlab.Failed) || job.Status == string(gitlab.Canceled)) {
+			s.retryJobLocked(p, job)
+			retried = true
+		}
+	}
+	if retried || pipeline.Status == string(gitlab.Failed) || pipeline.Status == string(gitlab.Canceled) {
+		setPipelineStatus(pipeline, string(gitlab.Pending))
+	}
+
+	writeJSON(w, http.StatusCreated, pipeline)
+}
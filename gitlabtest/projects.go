@@ -0,0 +1,268 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// AddProject adds a new project with the given path, for example
+// "my-group/my-project". If the path has no namespace, the project is created
+// in the namespace of the authenticated user. The project is created with a
+// "main" branch holding a single commit.
+func (s *Server) AddProject(pathWithNamespace string) *gitlab.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.addProject(pathWithNamespace, "main")
+	project := *p.Project
+
+	return &project
+}
+
+// Project returns a copy of the current state of the given project, which
+// can be specified by ID or by path, or nil if it doesn't exist.
+func (s *Server) Project(pid interface{}) *gitlab.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+	project := *p.Project
+
+	return &project
+}
+
+// addProject adds a new project. Must be called with the lock held.
+func (s *Server) addProject(pathWithNamespace, defaultBranch string) *project {
+	namespace, name := path.Split(pathWithNamespace)
+	namespace = strings.TrimSuffix(namespace, "/")
+	if namespace == "" {
+		namespace = s.user.Username
+		pathWithNamespace = path.Join(namespace, name)
+	}
+
+	webURL := s.URL + "/" + pathWithNamespace
+	p := &project{
+		Project: &gitlab.Project{
+			ID:                   s.nextID(),
+			Name:                 name,
+			Path:                 name,
+			NameWithNamespace:    strings.ReplaceAll(pathWithNamespace, "/", " / "),
+			PathWithNamespace:    pathWithNamespace,
+			DefaultBranch:        defaultBranch,
+			Visibility:           gitlab.PrivateVisibility,
+			HTTPURLToRepo:        webURL + ".git",
+			WebURL:               webURL,
+			IssuesEnabled:        true,
+			MergeRequestsEnabled: true,
+			JobsEnabled:          true,
+			CreatedAt:            now(),
+			LastActivityAt:       now(),
+			Namespace: &gitlab.ProjectNamespace{
+				ID:       s.nextID(),
+				Name:     path.Base(namespace),
+				Path:     path.Base(namespace),
+				Kind:     "group",
+				FullPath: namespace,
+				WebURL:   s.URL + "/" + namespace,
+			},
+		},
+		retried: make(map[int]bool),
+		notes:   make(map[string][]*gitlab.Note),
+	}
+	if namespace == s.user.Username {
+		p.Namespace.Kind = "user"
+	}
+
+	if defaultBranch != "" {
+		s.addBranch(p, defaultBranch, s.newCommit(p, "Initial commit", nil))
+	}
+
+	s.projects = append(s.projects, p)
+
+	return p
+}
+
+// findProject returns the project with the given ID or path. Must be called
+// with the lock held.
+func (s *Server) findProject(pid interface{}) *project {
+	var id string
+	switch v := pid.(type) {
+	case int:
+		id = strconv.Itoa(v)
+	case string:
+		id = v
+	case *gitlab.Project:
+		id = strconv.Itoa(v.ID)
+	default:
+		return nil
+	}
+
+	for _, p := range s.projects {
+		if strconv.Itoa(p.ID) == id || p.PathWithNamespace == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// lookupProject returns the project from the request parameters, or writes a
+// 404 response if it doesn't exist.
+func (s *Server) lookupProject(w http.ResponseWriter, params map[string]string) (*project, bool) {
+	p := s.findProject(params["id"])
+	if p == nil {
+		writeError(w, http.StatusNotFound, "404 Project Not Found")
+		return nil, false
+	}
+	return p, true
+}
+
+func (s *Server) getCurrentUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	writeJSON(w, http.StatusOK, s.user)
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	search := strings.ToLower(r.URL.Query().Get("search"))
+
+	projects := make([]*gitlab.Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		if search == "" || strings.Contains(strings.ToLower(p.PathWithNamespace), search) {
+			projects = append(projects, p.Project)
+		}
+	}
+
+	paginate(w, r, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var opt gitlab.CreateProjectOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+
+	var name string
+	switch {
+	case opt.Path != nil && *opt.Path != "":
+		name = *opt.Path
+	case opt.Name != nil && *opt.Name != "":
+		name = strings.ToLower(strings.ReplaceAll(*opt.Name, " ", "-"))
+	default:
+		writeMissingError(w, "name")
+		return
+	}
+
+	namespace := s.user.Username
+	if opt.NamespaceID != nil {
+		for _, p := range s.projects {
+			if p.Namespace.ID == *opt.NamespaceID {
+				namespace = p.Namespace.FullPath
+			}
+		}
+	}
+
+	pathWithNamespace := path.Join(namespace, name)
+	if s.findProject(pathWithNamespace) != nil {
+		writeValidationError(w, "path", "has already been taken")
+		return
+	}
+
+	// Like GitLab, only create a branch if the repository is initialized.
+	defaultBranch := "main"
+	if opt.DefaultBranch != nil {
+		defaultBranch = *opt.DefaultBranch
+	}
+	initialize := defaultBranch
+	if opt.InitializeWithReadme == nil || !*opt.InitializeWithReadme {
+		initialize = ""
+	}
+
+	p := s.addProject(pathWithNamespace, initialize)
+	p.DefaultBranch = defaultBranch
+	if opt.Name != nil {
+		p.Name = *opt.Name
+	}
+	if opt.Description != nil {
+		p.Description = *opt.Description
+	}
+	if opt.Visibility != nil {
+		p.Visibility = *opt.Visibility
+	}
+
+	writeJSON(w, http.StatusCreated, p.Project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, p.Project)
+}
+
+func (s *Server) editProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.EditProjectOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+
+	if opt.Name != nil {
+		p.Name = *opt.Name
+	}
+	if opt.Description != nil {
+		p.Description = *opt.Description
+	}
+	if opt.DefaultBranch != nil {
+		if findBranch(p, *opt.DefaultBranch) == nil {
+			writeValidationError(w, "base", "Could not change HEAD: branch '"+*opt.DefaultBranch+"' does not exist")
+			return
+		}
+		p.DefaultBranch = *opt.DefaultBranch
+		for _, b := range p.branches {
+			b.Default = b.Name == p.DefaultBranch
+		}
+	}
+	if opt.Visibility != nil {
+		p.Visibility = *opt.Visibility
+	}
+
+	writeJSON(w, http.StatusOK, p.Project)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	s.projects = filter(s.projects, func(other *project) bool { return other != p })
+
+	writeError(w, http.StatusAccepted, "202 Accepted")
+}
@@ -0,0 +1,74 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import "net/http"
+
+// registerRoutes registers all the API endpoints supported by the server.
+func (s *Server) registerRoutes() {
+	s.handle(http.MethodGet, "user", s.getCurrentUser)
+
+	s.handle(http.MethodGet, "projects", s.listProjects)
+	s.handle(http.MethodPost, "projects", s.createProject)
+	s.handle(http.MethodGet, "projects/:id", s.getProject)
+	s.handle(http.MethodPut, "projects/:id", s.editProject)
+	s.handle(http.MethodDelete, "projects/:id", s.deleteProject)
+
+	s.handle(http.MethodGet, "projects/:id/repository/branches", s.listBranches)
+	s.handle(http.MethodPost, "projects/:id/repository/branches", s.createBranch)
+	s.handle(http.MethodGet, "projects/:id/repository/branches/:branch", s.getBranch)
+	s.handle(http.MethodDelete, "projects/:id/repository/branches/:branch", s.deleteBranch)
+
+	s.handle(http.MethodGet, "projects/:id/merge_requests", s.listMergeRequests)
+	s.handle(http.MethodPost, "projects/:id/merge_requests", s.createMergeRequest)
+	s.handle(http.MethodGet, "projects/:id/merge_requests/:iid", s.getMergeRequest)
+	s.handle(http.MethodPut, "projects/:id/merge_requests/:iid", s.updateMergeRequest)
+	s.handle(http.MethodPut, "projects/:id/merge_requests/:iid/merge", s.acceptMergeRequest)
+	s.handle(http.MethodGet, "projects/:id/merge_requests/:iid/pipelines", s.listMergeRequestPipelines)
+
+	s.handle(http.MethodGet, "projects/:id/issues", s.listIssues)
+	s.handle(http.MethodPost, "projects/:id/issues", s.createIssue)
+	s.handle(http.MethodGet, "projects/:id/issues/:iid", s.getIssue)
+	s.handle(http.MethodPut, "projects/:id/issues/:iid", s.updateIssue)
+	s.handle(http.MethodDelete, "projects/:id/issues/:iid", s.deleteIssue)
+
+	for _, noteable := range []string{"merge_requests", "issues"} {
+		s.handle(http.MethodGet, "projects/:id/"+noteable+"/:iid/notes", s.listNotes(noteable))
+		s.handle(http.MethodPost, "projects/:id/"+noteable+"/:iid/notes", s.createNote(noteable))
+		s.handle(http.MethodGet, "projects/:id/"+noteable+"/:iid/notes/:note", s.getNote(noteable))
+		s.handle(http.MethodPut, "projects/:id/"+noteable+"/:iid/notes/:note", s.updateNote(noteable))
+		s.handle(http.MethodDelete, "projects/:id/"+noteable+"/:iid/notes/:note", s.deleteNote(noteable))
+	}
+
+	s.handle(http.MethodGet, "projects/:id/pipelines", s.listPipelines)
+	s.handle(http.MethodPost, "projects/:id/pipeline", s.createPipeline)
+	s.handle(http.MethodGet, "projects/:id/pipelines/:pipeline", s.getPipeline)
+	s.handle(http.MethodPost, "projects/:id/pipelines/:pipeline/cancel", s.cancelPipeline)
+	s.handle(http.MethodPost, "projects/:id/pipelines/:pipeline/retry", s.retryPipeline)
+	s.handle(http.MethodGet, "projects/:id/pipelines/:pipeline/jobs", s.listPipelineJobs)
+
+	s.handle(http.MethodGet, "projects/:id/jobs", s.listJobs)
+	s.handle(http.MethodGet, "projects/:id/jobs/:job", s.getJob)
+	s.handle(http.MethodPost, "projects/:id/jobs/:job/cancel", s.cancelJob)
+	s.handle(http.MethodPost, "projects/:id/jobs/:job/retry", s.retryJob)
+
+	s.handle(http.MethodGet, "projects/:id/variables", s.listVariables)
+	s.handle(http.MethodPost, "projects/:id/variables", s.createVariable)
+	s.handle(http.MethodGet, "projects/:id/variables/:key", s.getVariable)
+	s.handle(http.MethodPut, "projects/:id/variables/:key", s.updateVariable)
+	s.handle(http.MethodDelete, "projects/:id/variables/:key", s.deleteVariable)
+}
@@ -0,0 +1,446 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package gitlabtest provides an in-memory fake of the GitLab API for use in
+// tests of code built on top of the gitlab package.
+//
+// The fake keeps state for a subset of the API (projects, branches, merge
+// requests, issues, pipelines, jobs, notes and project variables), honours
+// the pagination parameters and headers, requires a valid token and returns
+// errors in the same format as GitLab does.
+//
+// It also provides builders for the web- and system hooks GitLab sends, to
+// test webhook receivers.
+//
+// Example:
+//
+//	func TestMyAutomation(t *testing.T) {
+//		fake := gitlabtest.NewServer(t)
+//		project := fake.AddProject("my-project")
+//
+//		client := fake.Client(t)
+//		// Use client, then inspect the state using the fake.
+//	}
+package gitlabtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// DefaultToken is the token accepted by a Server created without any
+// WithToken options.
+const DefaultToken = "gitlabtest-token"
+
+// ServerOptionFunc can be used to customize a new Server.
+type ServerOptionFunc func(*Server)
+
+// WithToken adds a token accepted by the server. The token can be used as a
+// private token, a job token or an OAuth token.
+func WithToken(token string) ServerOptionFunc {
+	return func(s *Server) {
+		if len(s.tokens) == 0 {
+			s.token = token
+		}
+		s.tokens[token] = true
+	}
+}
+
+// WithUser sets the user that is authenticated by all accepted tokens.
+func WithUser(user *gitlab.User) ServerOptionFunc {
+	return func(s *Server) {
+		s.user = user
+	}
+}
+
+// Server is an in-memory fake of the GitLab API. It is safe for concurrent
+// use.
+type Server struct {
+	// URL is the base URL of the server, to be used with gitlab.WithBaseURL.
+	URL string
+
+	server *httptest.Server
+	routes []*route
+	token  string
+	tokens map[string]bool
+	user   *gitlab.User
+
+	mu       sync.Mutex
+	lastID   int
+	projects []*project
+}
+
+// project holds the state of a single project.
+type project struct {
+	*gitlab.Project
+
+	branches      []*gitlab.Branch
+	mergeRequests []*gitlab.MergeRequest
+	issues        []*gitlab.Issue
+	pipelines     []*gitlab.Pipeline
+	jobs          []*gitlab.Job
+	retried       map[int]bool
+	variables     []*gitlab.ProjectVariable
+	notes         map[string][]*gitlab.Note
+}
+
+// NewServer starts a new fake GitLab server, which is closed when the test
+// finishes.
+func NewServer(t testing.TB, options ...ServerOptionFunc) *Server {
+	s := &Server{
+		tokens: make(map[string]bool),
+		user: &gitlab.User{
+			ID:       1,
+			Username: "root",
+			Name:     "Administrator",
+			State:    "active",
+		},
+	}
+
+	for _, fn := range options {
+		if fn != nil {
+			fn(s)
+		}
+	}
+	if len(s.tokens) == 0 {
+		WithToken(DefaultToken)(s)
+	}
+
+	s.registerRoutes()
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.server.Close)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Client returns a new client configured to talk to the server, using the
+// first token added using WithToken, or DefaultToken. Retries are disabled to
+// keep tests fast.
+func (s *Server) Client(t testing.TB, options ...gitlab.ClientOptionFunc) *gitlab.Client {
+	options = append([]gitlab.ClientOptionFunc{gitlab.WithBaseURL(s.URL), gitlab.WithoutRetries()}, options...)
+	client, err := gitlab.NewClient(s.token, options...)
+	if err != nil {
+		t.Fatalf("gitlabtest: failed to create client: %v", err)
+	}
+
+	return client
+}
+
+// Close shuts down the server. It is called automatically when the test
+// that created the server finishes.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// nextID returns a new unique ID. Must be called with the lock held.
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+// now returns the current time as a pointer, as used by most gitlab types.
+func now() *time.Time {
+	t := time.Now().UTC().Truncate(time.Millisecond)
+	return &t
+}
+
+// route is a single API endpoint. Segments of the pattern starting with a
+// colon match any value, which is made available as a parameter.
+type route struct {
+	method   string
+	segments []string
+	handler  func(w http.ResponseWriter, r *http.Request, params map[string]string)
+}
+
+func (s *Server) handle(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, params map[string]string)) {
+	s.routes = append(s.routes, &route{
+		method:   method,
+		segments: strings.Split(pattern, "/"),
+		handler:  handler,
+	})
+}
+
+func (r *route) match(method string, segments []string) (map[string]string, bool) {
+	if r.method != method || len(r.segments) != len(segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range r.segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			params[segment[1:]] = segments[i]
+		case segment != segments[i]:
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticated(r) {
+		writeError(w, http.StatusUnauthorized, "401 Unauthorized")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.EscapedPath(), "/api/v4/")
+	if path == r.URL.EscapedPath() {
+		writeError(w, http.StatusNotFound, "404 Not Found")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+
+	for _, route := range s.routes {
+		if params, ok := route.match(r.Method, segments); ok {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			route.handler(w, r, params)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "404 Not Found")
+}
+
+// authenticated reports whether the request carries an accepted token.
+func (s *Server) authenticated(r *http.Request) bool {
+	for _, token := range []string{
+		r.Header.Get("PRIVATE-TOKEN"),
+		r.Header.Get("JOB-TOKEN"),
+		strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+	} {
+		if token != "" && s.tokens[token] {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format used by GitLab.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+// writeValidationError writes a validation error for a single attribute.
+func writeValidationError(w http.ResponseWriter, attribute, message string) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"message": map[string][]string{attribute: {message}},
+	})
+}
+
+// writeMissingError writes the error GitLab returns for missing parameters.
+func writeMissingError(w http.ResponseWriter, parameter string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": parameter + " is missing"})
+}
+
+// decodeBody decodes the JSON request body into v.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+// paginate writes a single page of the given items, using the page and
+// per_page query parameters, and sets the pagination headers just like
+// GitLab does for offset-based pagination.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	q := r.URL.Query()
+
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage <= 0 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	total := len(items)
+	totalPages := (total + perPage - 1) / perPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	h := w.Header()
+	h.Set("X-Total", strconv.Itoa(total))
+	h.Set("X-Total-Pages", strconv.Itoa(totalPages))
+	h.Set("X-Per-Page", strconv.Itoa(perPage))
+	h.Set("X-Page", strconv.Itoa(page))
+	h.Set("X-Next-Page", "")
+	h.Set("X-Prev-Page", "")
+
+	var links []string
+	link := func(rel string, page int) {
+		u := *r.URL
+		u.Scheme = "http"
+		u.Host = r.Host
+		q := u.Query()
+		q.Set("page", strconv.Itoa(page))
+		q.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = q.Encode()
+		links = append(links, fmt.Sprintf("<%s>; rel=%q", u.String(), rel))
+	}
+	if page < totalPages {
+		h.Set("X-Next-Page", strconv.Itoa(page+1))
+		link("next", page+1)
+	}
+	if page > 1 {
+		h.Set("X-Prev-Page", strconv.Itoa(page-1))
+		link("prev", page-1)
+	}
+	link("first", 1)
+	link("last", totalPages)
+	h.Set("Link", strings.Join(links, ", "))
+
+	writeJSON(w, http.StatusOK, items[start:end])
+}
+
+// filter returns the items for which keep returns true.
+func filter[T any](items []T, keep func(T) bool) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// sha returns a fake, but stable and valid looking, commit SHA.
+func sha(parts ...interface{}) string {
+	h := uint64(14695981039346656037)
+	for _, c := range fmt.Sprint(parts...) {
+		h ^= uint64(c)
+		h *= 1099511628211
+	}
+	return fmt.Sprintf("%016x%016x%08x", h, h^0x5bd1e995, uint32(h>>16))
+}
+
+// intParam parses the named request parameter, writing a 404 response with
+// the given message if it isn't a number.
+func intParam(w http.ResponseWriter, params map[string]string, name, notFound string) (int, bool) {
+	v, err := strconv.Atoi(params[name])
+	if err != nil {
+		writeError(w, http.StatusNotFound, notFound)
+		return 0, false
+	}
+	return v, true
+}
+
+// splitLabels returns the labels of the given options. Labels are sent as a
+// single comma separated string, so they need to be split again.
+func splitLabels(opt *gitlab.LabelOptions) gitlab.Labels {
+	labels := gitlab.Labels{}
+	if opt == nil {
+		return labels
+	}
+	for _, v := range *opt {
+		for _, label := range strings.Split(v, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
+
+// hasLabels reports whether labels contains all labels in the comma separated
+// list of wanted labels.
+func hasLabels(labels gitlab.Labels, wanted string) bool {
+	for _, want := range strings.Split(wanted, ",") {
+		if want = strings.TrimSpace(want); want == "" {
+			continue
+		}
+		found := false
+		for _, label := range labels {
+			found = found || label == want
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// updateLabels applies the labels, add_labels and remove_labels options.
+func updateLabels(labels gitlab.Labels, set, add, remove *gitlab.LabelOptions) gitlab.Labels {
+	if set != nil {
+		labels = splitLabels(set)
+	}
+	for _, label := range splitLabels(add) {
+		if !hasLabels(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	for _, label := range splitLabels(remove) {
+		labels = filter(labels, func(l string) bool { return l != label })
+	}
+	return labels
+}
+
+// basicUser returns the authenticated user as a *gitlab.BasicUser.
+func (s *Server) basicUser() *gitlab.BasicUser {
+	return &gitlab.BasicUser{
+		ID:       s.user.ID,
+		Username: s.user.Username,
+		Name:     s.user.Name,
+		State:    s.user.State,
+		WebURL:   s.URL + "/" + s.user.Username,
+	}
+}
@@ -0,0 +1,291 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestAuthentication(t *testing.T) {
+	fake := NewServer(t, WithToken("secret"))
+
+	client, err := gitlab.NewClient("wrong", gitlab.WithBaseURL(fake.URL), gitlab.WithoutRetries())
+	require.NoError(t, err)
+
+	_, resp, err := client.Users.CurrentUser()
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	user, _, err := fake.Client(t).Users.CurrentUser()
+	require.NoError(t, err)
+	assert.Equal(t, "root", user.Username)
+
+	oauth, err := gitlab.NewOAuthClient("secret", gitlab.WithBaseURL(fake.URL))
+	require.NoError(t, err)
+	_, _, err = oauth.Users.CurrentUser()
+	require.NoError(t, err)
+}
+
+func TestProjects(t *testing.T) {
+	fake := NewServer(t)
+	client := fake.Client(t)
+
+	project, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+		Name:                 gitlab.Ptr("My Project"),
+		Path:                 gitlab.Ptr("my-project"),
+		InitializeWithReadme: gitlab.Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "root/my-project", project.PathWithNamespace)
+	assert.Equal(t, "main", project.DefaultBranch)
+
+	got, _, err := client.Projects.GetProject("root/my-project", nil)
+	require.NoError(t, err)
+	assert.Equal(t, project.ID, got.ID)
+
+	_, _, err = client.Projects.CreateProject(&gitlab.CreateProjectOptions{Path: gitlab.Ptr("my-project")})
+	var validationErr *gitlab.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"has already been taken"}, validationErr.Fields["path"])
+
+	_, err = client.Projects.DeleteProject(project.ID, nil)
+	require.NoError(t, err)
+
+	_, _, err = client.Projects.GetProject(project.ID, nil)
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+}
+
+func TestPagination(t *testing.T) {
+	fake := NewServer(t)
+	project := fake.AddProject("group/project")
+	for i := 0; i < 5; i++ {
+		fake.AddBranch(project.ID, fmt.Sprintf("feature-%d", i), "main", "Add feature")
+	}
+
+	client := fake.Client(t)
+
+	branches, resp, err := client.Branches.ListBranches(project.ID, &gitlab.ListBranchesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 2, Page: 2},
+	})
+	require.NoError(t, err)
+	assert.Len(t, branches, 2)
+	assert.Equal(t, 6, resp.TotalItems)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.Equal(t, 3, resp.NextPage)
+	assert.Equal(t, 1, resp.PreviousPage)
+
+	all, err := gitlab.All(context.Background(), func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Branch, *gitlab.Response, error) {
+		return client.Branches.ListBranches(project.ID, &gitlab.ListBranchesOptions{
+			ListOptions: gitlab.ListOptions{PerPage: 2},
+		}, options...)
+	})
+	require.NoError(t, err)
+	assert.Len(t, all, 6)
+}
+
+func TestMergeRequests(t *testing.T) {
+	fake := NewServer(t)
+	project := fake.AddProject("group/project")
+	branch := fake.AddBranch(project.ID, "feature", "main", "Add feature")
+
+	client := fake.Client(t)
+
+	mr, _, err := client.MergeRequests.CreateMergeRequest(project.ID, &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.Ptr("Add feature"),
+		SourceBranch: gitlab.Ptr("feature"),
+		TargetBranch: gitlab.Ptr("main"),
+		Labels:       &gitlab.LabelOptions{"backend", "feature"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, mr.IID)
+	assert.Equal(t, branch.Commit.ID, mr.SHA)
+	assert.Equal(t, gitlab.Labels{"backend", "feature"}, mr.Labels)
+
+	_, _, err = client.MergeRequests.CreateMergeRequest(project.ID, &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.Ptr("Add feature again"),
+		SourceBranch: gitlab.Ptr("feature"),
+		TargetBranch: gitlab.Ptr("main"),
+	})
+	var conflictErr *gitlab.ConflictError
+	require.ErrorAs(t, err, &conflictErr)
+
+	mrs, _, err := client.MergeRequests.ListProjectMergeRequests(project.ID, &gitlab.ListProjectMergeRequestsOptions{
+		State:  gitlab.Ptr("opened"),
+		Labels: &gitlab.LabelOptions{"backend"},
+	})
+	require.NoError(t, err)
+	require.Len(t, mrs, 1)
+
+	_, _, err = client.MergeRequests.AcceptMergeRequest(project.ID, mr.IID, &gitlab.AcceptMergeRequestOptions{
+		SHA: gitlab.Ptr("0123456789abcdef"),
+	})
+	require.ErrorAs(t, err, &conflictErr)
+
+	merged, _, err := client.MergeRequests.AcceptMergeRequest(project.ID, mr.IID, &gitlab.AcceptMergeRequestOptions{
+		SHA:                      gitlab.Ptr(mr.SHA),
+		ShouldRemoveSourceBranch: gitlab.Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "merged", merged.State)
+	assert.Equal(t, merged.MergeCommitSHA, fake.Branch(project.ID, "main").Commit.ID)
+	assert.Nil(t, fake.Branch(project.ID, "feature"))
+
+	note, _, err := client.Notes.CreateMergeRequestNote(project.ID, mr.IID, &gitlab.CreateMergeRequestNoteOptions{
+		Body: gitlab.Ptr("LGTM"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "MergeRequest", note.NoteableType)
+
+	notes := fake.MergeRequestNotes(project.ID, mr.IID)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "LGTM", notes[0].Body)
+}
+
+func TestIssues(t *testing.T) {
+	fake := NewServer(t)
+	project := fake.AddProject("group/project")
+
+	client := fake.Client(t)
+
+	_, _, err := client.Issues.CreateIssue(project.ID, &gitlab.CreateIssueOptions{})
+	require.Error(t, err)
+
+	issue, _, err := client.Issues.CreateIssue(project.ID, &gitlab.CreateIssueOptions{
+		Title: gitlab.Ptr("Bug"),
+	})
+	require.NoError(t, err)
+
+	issue, _, err = client.Issues.UpdateIssue(project.ID, issue.IID, &gitlab.UpdateIssueOptions{
+		StateEvent: gitlab.Ptr("close"),
+		AddLabels:  &gitlab.LabelOptions{"wontfix"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "closed", issue.State)
+	assert.Equal(t, gitlab.Labels{"wontfix"}, issue.Labels)
+
+	issues, _, err := client.Issues.ListProjectIssues(project.ID, &gitlab.ListProjectIssuesOptions{
+		State: gitlab.Ptr("opened"),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	_, err = client.Issues.DeleteIssue(project.ID, issue.IID)
+	require.NoError(t, err)
+	assert.Nil(t, fake.Issue(project.ID, issue.IID))
+}
+
+func TestPipelines(t *testing.T) {
+	fake := NewServer(t)
+	project := fake.AddProject("group/project")
+
+	client := fake.Client(t)
+
+	_, _, err := client.Pipelines.CreatePipeline(project.ID, &gitlab.CreatePipelineOptions{Ref: gitlab.Ptr("unknown")})
+	var validationErr *gitlab.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	pipeline, _, err := client.Pipelines.CreatePipeline(project.ID, &gitlab.CreatePipelineOptions{Ref: gitlab.Ptr("main")})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", pipeline.Status)
+
+	build := fake.AddJob(project.ID, pipeline.ID, "build", "compile")
+	test := fake.AddJob(project.ID, pipeline.ID, "test", "unit")
+
+	fake.SetJobStatus(project.ID, build.ID, gitlab.Success)
+	fake.SetJobStatus(project.ID, test.ID, gitlab.Failed)
+
+	pipeline, _, err = client.Pipelines.GetPipeline(project.ID, pipeline.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", pipeline.Status)
+
+	_, _, err = client.Pipelines.RetryPipelineBuild(project.ID, pipeline.ID)
+	require.NoError(t, err)
+
+	jobs, _, err := client.Jobs.ListPipelineJobs(project.ID, pipeline.ID, &gitlab.ListJobsOptions{
+		Scope: &[]gitlab.BuildStateValue{gitlab.Pending},
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "unit", jobs[0].Name)
+	assert.NotEqual(t, test.ID, jobs[0].ID)
+
+	fake.SetJobStatus(project.ID, jobs[0].ID, gitlab.Success)
+	assert.Equal(t, "success", fake.Pipeline(project.ID, pipeline.ID).Status)
+
+	pipelines, _, err := client.Pipelines.ListProjectPipelines(project.ID, &gitlab.ListProjectPipelinesOptions{
+		Ref: gitlab.Ptr("main"),
+	})
+	require.NoError(t, err)
+	require.Len(t, pipelines, 1)
+	assert.Equal(t, "success", pipelines[0].Status)
+}
+
+func TestVariables(t *testing.T) {
+	fake := NewServer(t)
+	project := fake.AddProject("group/project")
+
+	client := fake.Client(t)
+
+	for _, scope := range []string{"*", "production"} {
+		_, _, err := client.ProjectVariables.CreateVariable(project.ID, &gitlab.CreateProjectVariableOptions{
+			Key:              gitlab.Ptr("TOKEN"),
+			Value:            gitlab.Ptr("value-" + scope),
+			EnvironmentScope: gitlab.Ptr(scope),
+		})
+		require.NoError(t, err)
+	}
+
+	_, _, err := client.ProjectVariables.CreateVariable(project.ID, &gitlab.CreateProjectVariableOptions{
+		Key:   gitlab.Ptr("TOKEN"),
+		Value: gitlab.Ptr("duplicate"),
+	})
+	var validationErr *gitlab.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	v, _, err := client.ProjectVariables.GetVariable(project.ID, "TOKEN", &gitlab.GetProjectVariableOptions{
+		Filter: &gitlab.VariableFilter{EnvironmentScope: "production"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "value-production", v.Value)
+
+	_, _, err = client.ProjectVariables.UpdateVariable(project.ID, "TOKEN", &gitlab.UpdateProjectVariableOptions{
+		Value:  gitlab.Ptr("updated"),
+		Filter: &gitlab.VariableFilter{EnvironmentScope: "production"},
+	})
+	require.NoError(t, err)
+
+	_, err = client.ProjectVariables.RemoveVariable(project.ID, "TOKEN", &gitlab.RemoveProjectVariableOptions{
+		Filter: &gitlab.VariableFilter{EnvironmentScope: "*"},
+	})
+	require.NoError(t, err)
+
+	variables := fake.Variables(project.ID)
+	require.Len(t, variables, 1)
+	assert.Equal(t, "updated", variables[0].Value)
+	assert.Equal(t, "production", variables[0].EnvironmentScope)
+
+	_, _, err = client.ProjectVariables.GetVariable(project.ID, "MISSING", nil)
+	assert.True(t, errors.Is(err, gitlab.ErrNotFound))
+}
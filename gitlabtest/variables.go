@@ -0,0 +1,208 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// Variables returns a copy of the current variables of the given project.
+func (s *Server) Variables(pid interface{}) []*gitlab.ProjectVariable {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.findProject(pid)
+	if p == nil {
+		return nil
+	}
+
+	var variables []*gitlab.ProjectVariable
+	for _, v := range p.variables {
+		variable := *v
+		variables = append(variables, &variable)
+	}
+
+	return variables
+}
+
+// findVariable returns the variable with the given key. If scope is empty,
+// the first variable with the given key is returned.
+func findVariable(p *project, key, scope string) *gitlab.ProjectVariable {
+	for _, v := range p.variables {
+		if v.Key == key && (scope == "" || v.EnvironmentScope == scope) {
+			return v
+		}
+	}
+	return nil
+}
+
+// lookupVariable returns the variable from the request parameters, or writes
+// a 404 response if it doesn't exist.
+func (s *Server) lookupVariable(w http.ResponseWriter, params map[string]string, scope string) (*project, *gitlab.ProjectVariable, bool) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return nil, nil, false
+	}
+	v := findVariable(p, params["key"], scope)
+	if v == nil {
+		writeError(w, http.StatusNotFound, "404 Variable Not Found")
+		return nil, nil, false
+	}
+	return p, v, true
+}
+
+func (s *Server) listVariables(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	variables := make([]*gitlab.ProjectVariable, 0, len(p.variables))
+	for _, v := range p.variables {
+		variables = append(variables, hideValue(v))
+	}
+
+	paginate(w, r, variables)
+}
+
+func (s *Server) createVariable(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, ok := s.lookupProject(w, params)
+	if !ok {
+		return
+	}
+
+	var opt gitlab.CreateProjectVariableOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+	if opt.Key == nil || *opt.Key == "" {
+		writeMissingError(w, "key")
+		return
+	}
+	if opt.Value == nil {
+		writeMissingError(w, "value")
+		return
+	}
+
+	v := &gitlab.ProjectVariable{
+		Key:              *opt.Key,
+		Value:            *opt.Value,
+		VariableType:     gitlab.EnvVariableType,
+		EnvironmentScope: "*",
+	}
+	if opt.EnvironmentScope != nil {
+		v.EnvironmentScope = *opt.EnvironmentScope
+	}
+	if findVariable(p, v.Key, v.EnvironmentScope) != nil {
+		writeValidationError(w, "key", "("+v.Key+") has already been taken")
+		return
+	}
+	if opt.Description != nil {
+		v.Description = *opt.Description
+	}
+	if opt.VariableType != nil {
+		v.VariableType = *opt.VariableType
+	}
+	if opt.Protected != nil {
+		v.Protected = *opt.Protected
+	}
+	if opt.Masked != nil {
+		v.Masked = *opt.Masked
+	}
+	if opt.MaskedAndHidden != nil && *opt.MaskedAndHidden {
+		v.Masked = true
+		v.Hidden = true
+	}
+	if opt.Raw != nil {
+		v.Raw = *opt.Raw
+	}
+	p.variables = append(p.variables, v)
+
+	writeJSON(w, http.StatusCreated, hideValue(v))
+}
+
+func (s *Server) getVariable(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, v, ok := s.lookupVariable(w, params, r.URL.Query().Get("filter[environment_scope]"))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, hideValue(v))
+}
+
+func (s *Server) updateVariable(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var opt gitlab.UpdateProjectVariableOptions
+	if !decodeBody(w, r, &opt) {
+		return
+	}
+
+	scope := r.URL.Query().Get("filter[environment_scope]")
+	if opt.Filter != nil {
+		scope = opt.Filter.EnvironmentScope
+	}
+	_, v, ok := s.lookupVariable(w, params, scope)
+	if !ok {
+		return
+	}
+
+	if opt.Value != nil {
+		v.Value = *opt.Value
+	}
+	if opt.Description != nil {
+		v.Description = *opt.Description
+	}
+	if opt.EnvironmentScope != nil {
+		v.EnvironmentScope = *opt.EnvironmentScope
+	}
+	if opt.VariableType != nil {
+		v.VariableType = *opt.VariableType
+	}
+	if opt.Protected != nil {
+		v.Protected = *opt.Protected
+	}
+	if opt.Masked != nil {
+		v.Masked = *opt.Masked
+	}
+	if opt.Raw != nil {
+		v.Raw = *opt.Raw
+	}
+
+	writeJSON(w, http.StatusOK, hideValue(v))
+}
+
+func (s *Server) deleteVariable(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	p, v, ok := s.lookupVariable(w, params, r.URL.Query().Get("filter[environment_scope]"))
+	if !ok {
+		return
+	}
+
+	p.variables = filter(p.variables, func(other *gitlab.ProjectVariable) bool { return other != v })
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// hideValue returns the variable as returned by the API, which doesn't
+// include the value of hidden variables.
+func hideValue(v *gitlab.ProjectVariable) *gitlab.ProjectVariable {
+	if !v.Hidden {
+		return v
+	}
+	hidden := *v
+	hidden.Value = ""
+	return &hidden
+}
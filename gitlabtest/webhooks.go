@@ -0,0 +1,335 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// payloads contains payloads as sent by GitLab for every kind of event. They
+// are copied from the testdata of the gitlab package, because embedded files
+// can't come from a parent directory.
+//
+//go:embed payloads
+var payloads embed.FS
+
+// webhookPayloads maps every event type to the payload used by NewWebhook.
+var webhookPayloads = map[gitlab.EventType]string{
+	gitlab.EventConfidentialIssue:       "webhooks/issue.json",
+	gitlab.EventConfidentialNote:        "webhooks/note_issue.json",
+	gitlab.EventTypeBuild:               "webhooks/build.json",
+	gitlab.EventTypeDeployment:          "webhooks/deployment.json",
+	gitlab.EventTypeFeatureFlag:         "webhooks/feature_flag.json",
+	gitlab.EventTypeIssue:               "webhooks/issue.json",
+	gitlab.EventTypeJob:                 "webhooks/job.json",
+	gitlab.EventTypeMember:              "webhooks/member.json",
+	gitlab.EventTypeMergeRequest:        "webhooks/merge_request.json",
+	gitlab.EventTypeNote:                "webhooks/note_merge_request.json",
+	gitlab.EventTypePipeline:            "webhooks/pipeline.json",
+	gitlab.EventTypePush:                "webhooks/push.json",
+	gitlab.EventTypeRelease:             "webhooks/release.json",
+	gitlab.EventTypeResourceAccessToken: "webhooks/resource_access_token_project.json",
+	gitlab.EventTypeServiceHook:         "webhooks/service_merge_request.json",
+	gitlab.EventTypeSubGroup:            "webhooks/subgroup.json",
+	gitlab.EventTypeSystemHook:          "systemhooks/push.json",
+	gitlab.EventTypeTagPush:             "webhooks/tag_push.json",
+	gitlab.EventTypeWikiPage:            "webhooks/wiki_page.json",
+}
+
+// notePayloads maps every noteable type to the payload used by
+// NewNoteWebhook.
+var notePayloads = map[string]string{
+	"Commit":       "webhooks/note_commit.json",
+	"Issue":        "webhooks/note_issue.json",
+	"MergeRequest": "webhooks/note_merge_request.json",
+	"Snippet":      "webhooks/note_snippet.json",
+}
+
+// Webhook is a web- or system hook delivery, as sent by GitLab. Sending the
+// same Webhook more than once simulates GitLab retrying the delivery.
+type Webhook struct {
+	t testing.TB
+
+	EventType      gitlab.EventType
+	EventUUID      string
+	WebhookUUID    string
+	InstanceURL    string
+	IdempotencyKey string
+
+	payload []byte
+}
+
+// NewWebhook returns a webhook for the given event type, using a realistic
+// payload that can be customized using Set.
+//
+// The confidential event types use an issue event and a comment on an issue
+// respectively. Use NewNoteWebhook for comments on other noteables, and
+// NewSystemHook for other system hook events, and NewWebhookFromEvent for
+// event types without a payload, such as emoji and vulnerability events.
+func NewWebhook(t testing.TB, eventType gitlab.EventType) *Webhook {
+	t.Helper()
+
+	name, ok := webhookPayloads[eventType]
+	if !ok {
+		t.Fatalf("gitlabtest: no payload for event type %q", eventType)
+	}
+
+	w := newWebhook(t, eventType, name)
+	switch eventType {
+	case gitlab.EventConfidentialIssue:
+		w.Set("event_type", "confidential_issue")
+		w.Set("object_attributes.confidential", true)
+	case gitlab.EventConfidentialNote:
+		w.Set("event_type", "confidential_note")
+		w.Set("issue.confidential", true)
+	}
+
+	return w
+}
+
+// NewNoteWebhook returns a webhook for a comment on the given type of
+// noteable: Commit, Issue, MergeRequest or Snippet.
+func NewNoteWebhook(t testing.TB, noteableType string) *Webhook {
+	t.Helper()
+
+	name, ok := notePayloads[noteableType]
+	if !ok {
+		t.Fatalf("gitlabtest: no payload for noteable type %q", noteableType)
+	}
+
+	return newWebhook(t, gitlab.EventTypeNote, name)
+}
+
+// NewSystemHook returns a system hook for the given event name, for example
+// "project_create" or "user_add_to_group". Use "merge_request" for merge
+// request events.
+func NewSystemHook(t testing.TB, eventName string) *Webhook {
+	t.Helper()
+
+	name := "systemhooks/" + eventName + ".json"
+	if _, err := payloads.Open("payloads/" + name); err != nil || strings.ContainsAny(eventName, "./") {
+		t.Fatalf("gitlabtest: no payload for system hook event %q", eventName)
+	}
+
+	return newWebhook(t, gitlab.EventTypeSystemHook, name)
+}
+
+// NewWebhookFromEvent returns a webhook of the given event type with the
+// given event as payload.
+func NewWebhookFromEvent(t testing.TB, eventType gitlab.EventType, event interface{}) *Webhook {
+	t.Helper()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("gitlabtest: error encoding event: %v", err)
+	}
+
+	return &Webhook{
+		t:              t,
+		EventType:      eventType,
+		EventUUID:      newUUID(t),
+		WebhookUUID:    newUUID(t),
+		InstanceURL:    "https://gitlab.example.com",
+		IdempotencyKey: newUUID(t),
+		payload:        payload,
+	}
+}
+
+func newWebhook(t testing.TB, eventType gitlab.EventType, name string) *Webhook {
+	t.Helper()
+
+	payload, err := payloads.ReadFile("payloads/" + name)
+	if err != nil {
+		t.Fatalf("gitlabtest: error reading payload: %v", err)
+	}
+
+	w := NewWebhookFromEvent(t, eventType, json.RawMessage(payload))
+	w.payload = payload
+
+	return w
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID(t testing.TB) string {
+	t.Helper()
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("gitlabtest: error generating UUID: %v", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Set sets the attribute at the given dot separated path of the payload,
+// for example "object_attributes.action". Array elements are addressed
+// using their index, for example "commits.0.message". Intermediate objects
+// are created when needed.
+func (w *Webhook) Set(path string, value interface{}) *Webhook {
+	w.t.Helper()
+
+	dec := json.NewDecoder(bytes.NewReader(w.payload))
+	dec.UseNumber()
+
+	var payload interface{}
+	if err := dec.Decode(&payload); err != nil {
+		w.t.Fatalf("gitlabtest: error decoding payload: %v", err)
+	}
+
+	keys := strings.Split(path, ".")
+	parent := payload
+	for i, key := range keys {
+		last := i == len(keys)-1
+
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if last {
+				node[key] = value
+				break
+			}
+			if _, ok := node[key].(map[string]interface{}); !ok {
+				if _, ok := node[key].([]interface{}); !ok {
+					node[key] = map[string]interface{}{}
+				}
+			}
+			parent = node[key]
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				w.t.Fatalf("gitlabtest: invalid index %q in path %q", key, path)
+			}
+			if last {
+				node[idx] = value
+				break
+			}
+			parent = node[idx]
+		default:
+			w.t.Fatalf("gitlabtest: %q in path %q is not an object or array", strings.Join(keys[:i], "."), path)
+		}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		w.t.Fatalf("gitlabtest: error encoding payload: %v", err)
+	}
+	w.payload = data
+
+	return w
+}
+
+// Payload returns the JSON payload of the webhook.
+func (w *Webhook) Payload() []byte {
+	return append([]byte(nil), w.payload...)
+}
+
+// Event returns the webhook parsed using gitlab.ParseHook.
+func (w *Webhook) Event() interface{} {
+	w.t.Helper()
+
+	event, err := gitlab.ParseHook(w.EventType, w.payload)
+	if err != nil {
+		w.t.Fatalf("gitlabtest: error parsing %s payload: %v", w.EventType, err)
+	}
+
+	return event
+}
+
+// Request returns a POST request delivering the webhook to the given URL,
+// with the headers GitLab sets. The token is omitted if it is empty.
+func (w *Webhook) Request(url, token string) *http.Request {
+	w.t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(w.payload))
+	if err != nil {
+		w.t.Fatalf("gitlabtest: error creating request: %v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GitLab/17.0.0")
+	req.Header.Set("X-Gitlab-Event", string(w.EventType))
+	req.Header.Set("X-Gitlab-Event-UUID", w.EventUUID)
+	req.Header.Set("X-Gitlab-Webhook-UUID", w.WebhookUUID)
+	req.Header.Set("X-Gitlab-Instance", w.InstanceURL)
+	req.Header.Set("Idempotency-Key", w.IdempotencyKey)
+	if token != "" {
+		req.Header.Set("X-Gitlab-Token", token)
+	}
+
+	return req
+}
+
+// WebhookSender delivers webhooks to a URL the way GitLab does: it doesn't
+// follow redirects and gives up on receivers that don't respond in time.
+type WebhookSender struct {
+	t      testing.TB
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewWebhookSender returns a new sender delivering webhooks to the given URL
+// using the given secret token.
+func NewWebhookSender(t testing.TB, url, token string) *WebhookSender {
+	return &WebhookSender{
+		t:     t,
+		url:   url,
+		token: token,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send delivers the webhook and returns the response of the receiver. The
+// response body is read completely, so it doesn't have to be closed.
+func (s *WebhookSender) Send(ctx context.Context, w *Webhook) *http.Response {
+	s.t.Helper()
+
+	req := w.Request(s.url, s.token).WithContext(ctx)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.t.Fatalf("gitlabtest: error delivering %s: %v", w.EventType, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.t.Fatalf("gitlabtest: error reading response: %v", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp
+}
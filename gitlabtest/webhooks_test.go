@@ -0,0 +1,147 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlabtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestWebhooks(t *testing.T) {
+	tests := map[gitlab.EventType]interface{}{
+		gitlab.EventConfidentialIssue:       &gitlab.IssueEvent{},
+		gitlab.EventConfidentialNote:        &gitlab.IssueCommentEvent{},
+		gitlab.EventTypeBuild:               &gitlab.BuildEvent{},
+		gitlab.EventTypeDeployment:          &gitlab.DeploymentEvent{},
+		gitlab.EventTypeFeatureFlag:         &gitlab.FeatureFlagEvent{},
+		gitlab.EventTypeIssue:               &gitlab.IssueEvent{},
+		gitlab.EventTypeJob:                 &gitlab.JobEvent{},
+		gitlab.EventTypeMember:              &gitlab.MemberEvent{},
+		gitlab.EventTypeMergeRequest:        &gitlab.MergeEvent{},
+		gitlab.EventTypeNote:                &gitlab.MergeCommentEvent{},
+		gitlab.EventTypePipeline:            &gitlab.PipelineEvent{},
+		gitlab.EventTypePush:                &gitlab.PushEvent{},
+		gitlab.EventTypeRelease:             &gitlab.ReleaseEvent{},
+		gitlab.EventTypeResourceAccessToken: &gitlab.ProjectResourceAccessTokenEvent{},
+		gitlab.EventTypeServiceHook:         &gitlab.MergeEvent{},
+		gitlab.EventTypeSubGroup:            &gitlab.SubGroupEvent{},
+		gitlab.EventTypeSystemHook:          &gitlab.PushSystemEvent{},
+		gitlab.EventTypeTagPush:             &gitlab.TagEvent{},
+		gitlab.EventTypeWikiPage:            &gitlab.WikiPageEvent{},
+	}
+
+	for eventType, want := range tests {
+		t.Run(string(eventType), func(t *testing.T) {
+			w := NewWebhook(t, eventType)
+			assert.IsType(t, want, w.Event())
+
+			req := w.Request("http://localhost/webhook", "secret")
+			assert.Equal(t, eventType, gitlab.HookEventType(req))
+			assert.Equal(t, "secret", gitlab.HookEventToken(req))
+
+			event, delivery, err := gitlab.ParseHookRequest(req)
+			require.NoError(t, err)
+			assert.IsType(t, want, event)
+			assert.Equal(t, w.IdempotencyKey, delivery.Key())
+			assert.Equal(t, w.EventUUID, delivery.EventUUID)
+		})
+	}
+
+	assert.True(t, NewWebhook(t, gitlab.EventConfidentialIssue).Event().(*gitlab.IssueEvent).ObjectAttributes.Confidential)
+}
+
+func TestNoteWebhooks(t *testing.T) {
+	assert.IsType(t, &gitlab.CommitCommentEvent{}, NewNoteWebhook(t, "Commit").Event())
+	assert.IsType(t, &gitlab.IssueCommentEvent{}, NewNoteWebhook(t, "Issue").Event())
+	assert.IsType(t, &gitlab.MergeCommentEvent{}, NewNoteWebhook(t, "MergeRequest").Event())
+	assert.IsType(t, &gitlab.SnippetCommentEvent{}, NewNoteWebhook(t, "Snippet").Event())
+}
+
+func TestSystemHooks(t *testing.T) {
+	entries, err := payloads.ReadDir("payloads/systemhooks")
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		t.Run(name, func(t *testing.T) {
+			assert.NotNil(t, NewSystemHook(t, name).Event())
+		})
+	}
+}
+
+func TestWebhookSet(t *testing.T) {
+	w := NewWebhook(t, gitlab.EventTypeMergeRequest).
+		Set("object_attributes.action", "merge").
+		Set("object_attributes.iid", 42).
+		Set("labels.0.title", "bug")
+
+	event := w.Event().(*gitlab.MergeEvent)
+	assert.Equal(t, "merge", event.ObjectAttributes.Action)
+	assert.Equal(t, 42, event.ObjectAttributes.IID)
+	assert.Equal(t, "bug", event.Labels[0].Title)
+
+	// Attributes that are not touched keep their original values.
+	assert.Equal(t, "merge_request", event.ObjectKind)
+	assert.NotEmpty(t, event.ObjectAttributes.Title)
+}
+
+func TestWebhookFromEvent(t *testing.T) {
+	w := NewWebhookFromEvent(t, gitlab.EventTypePush, &gitlab.PushEvent{
+		ObjectKind: "push",
+		Ref:        "refs/heads/main",
+	})
+
+	event := w.Event().(*gitlab.PushEvent)
+	assert.Equal(t, "refs/heads/main", event.Ref)
+}
+
+func TestWebhookSender(t *testing.T) {
+	h := gitlab.NewWebhookHandler("secret",
+		gitlab.WithWebhookDeliveryStore(gitlab.NewMemoryWebhookDeliveryStore(time.Hour)),
+	)
+
+	var pushes int
+	h.OnPush(func(ctx context.Context, event *gitlab.PushEvent) error {
+		pushes++
+		return nil
+	})
+
+	receiver := httptest.NewServer(h)
+	defer receiver.Close()
+
+	w := NewWebhook(t, gitlab.EventTypePush)
+
+	resp := NewWebhookSender(t, receiver.URL, "secret").Send(context.Background(), w)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Sending the same webhook again is a retry of the same delivery.
+	resp = NewWebhookSender(t, receiver.URL, "secret").Send(context.Background(), w)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, 1, pushes)
+
+	resp = NewWebhookSender(t, receiver.URL, "invalid").Send(context.Background(), w)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
@@ -79,6 +79,26 @@ func (s *GroupImportExportService) ExportDownload(gid interface{}, options ...Re
 	return bytes.NewReader(exportDownload.Bytes()), resp, err
 }
 
+// StreamExportDownload streams the finished export to the provided io.Writer.
+// If the download is interrupted, it is resumed using a HTTP Range request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/group_import_export.html#export-download
+func (s *GroupImportExportService) StreamExportDownload(gid interface{}, w io.Writer, options ...RequestOptionFunc) (*Response, error) {
+	group, err := parseID(gid)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("groups/%s/export/download", PathEscape(group))
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.stream(req, w)
+}
+
 // GroupImportFileOptions represents the available ImportFile() options.
 //
 // GitLab API docs:
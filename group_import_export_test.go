@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
@@ -50,6 +51,28 @@ func TestGroupExportDownload(t *testing.T) {
 	}
 }
 
+func TestGroupStreamExportDownload(t *testing.T) {
+	mux, client := setup(t)
+	content := []byte("fake content")
+
+	mux.HandleFunc("/api/v4/groups/1/export/download",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			w.Write(content)
+		})
+
+	var b bytes.Buffer
+	_, err := client.GroupImportExport.StreamExportDownload(1, &b)
+	if err != nil {
+		t.Errorf("GroupImportExport.StreamExportDownload returned error: %v", err)
+	}
+
+	want := []byte("fake content")
+	if !reflect.DeepEqual(want, b.Bytes()) {
+		t.Errorf("GroupImportExport.StreamExportDownload returned %+v, want %+v", b.Bytes(), want)
+	}
+}
+
 func TestGroupImport(t *testing.T) {
 	mux, client := setup(t)
 
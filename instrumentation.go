@@ -0,0 +1,267 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+const headerRateRemaining = "RateLimit-Remaining"
+
+// Instrumentation describes the interface that all (custom) instrumentation
+// must implement. It can be used to create tracing spans and to record
+// metrics for every API call made by the client, without this package
+// depending on a specific tracing or metrics library.
+//
+// An OpenTelemetry implementation starts a span in StartRequest, named after
+// RequestInfo.Operation, and ends it in EndRequest.
+type Instrumentation interface {
+	// StartRequest is called before a request is sent. The returned context
+	// is used for the request and passed to EndRequest, so it can be used to
+	// carry a tracing span.
+	StartRequest(ctx context.Context, info *RequestInfo) context.Context
+
+	// EndRequest is called after the request is done, including all retries.
+	EndRequest(ctx context.Context, info *RequestInfo, result *RequestResult)
+}
+
+// RequestInfo describes an API call.
+type RequestInfo struct {
+	// Operation is the name of the service method making the call, for
+	// example "MergeRequestsService.AcceptMergeRequest".
+	Operation string
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// PathTemplate is the path of the request relative to the API base URL,
+	// with all path parameters replaced by ":id", for example
+	// "projects/:id/merge_requests/:id/merge".
+	PathTemplate string
+
+	// URL is the full URL of the request.
+	URL string
+}
+
+// RequestResult describes the outcome of an API call.
+type RequestResult struct {
+	// StatusCode is the HTTP status code of the final response, or zero if no
+	// response was received.
+	StatusCode int
+
+	// Retries is the number of times the request was retried.
+	Retries int
+
+	// Duration is the total time it took to make the call, including retries.
+	Duration time.Duration
+
+	// RateLimitRemaining is the value of the RateLimit-Remaining header, or -1
+	// if the header was not set.
+	RateLimitRemaining int
+
+	// Err is the error returned by the call, if any.
+	Err error
+}
+
+// retriesKey is the context key used to count the retries of a request.
+type retriesKey struct{}
+
+// packagePath is the import path of this package, used to find the name of
+// the service method that made a call.
+var packagePath = reflect.TypeOf(Client{}).PkgPath()
+
+// instrumentRetries wraps the retry policy of the client, so the retries of
+// instrumented requests can be counted.
+func (c *Client) instrumentRetries() {
+	checkRetry := c.client.CheckRetry
+	c.client.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		retry, checkErr := checkRetry(ctx, resp, err)
+		if retry {
+			if retries, ok := ctx.Value(retriesKey{}).(*int); ok {
+				*retries++
+			}
+		}
+		return retry, checkErr
+	}
+}
+
+// instrumentedDo wraps Client.do with the configured instrumentation.
+func (c *Client) instrumentedDo(req *retryablehttp.Request, v interface{}) (*Response, error) {
+	info := &RequestInfo{
+		Operation:    callerOperation(),
+		Method:       req.Method,
+		PathTemplate: c.pathTemplate(req),
+		URL:          req.URL.String(),
+	}
+
+	ctx := c.instrumentation.StartRequest(req.Context(), info)
+
+	// Use a copy of the request, so the caller's request keeps its original
+	// context and can be sent again.
+	retries := new(int)
+	req = req.WithContext(context.WithValue(ctx, retriesKey{}, retries))
+
+	start := time.Now()
+	resp, err := c.do(req, v)
+
+	result := &RequestResult{
+		Retries:            *retries,
+		Duration:           time.Since(start),
+		RateLimitRemaining: -1,
+		Err:                err,
+	}
+	if result.Retries > c.client.RetryMax {
+		// The retry policy is also consulted after the last attempt.
+		result.Retries = c.client.RetryMax
+	}
+	if resp != nil && resp.Response != nil {
+		result.StatusCode = resp.StatusCode
+		if v := resp.Header.Get(headerRateRemaining); v != "" {
+			if remaining, err := strconv.Atoi(v); err == nil {
+				result.RateLimitRemaining = remaining
+			}
+		}
+	}
+
+	c.instrumentation.EndRequest(ctx, info, result)
+
+	return resp, err
+}
+
+// pathTemplate returns the path of the request relative to the API base URL
+// with all path parameters (IDs, paths of projects and files, branch names,
+// SHAs, usernames etc.) replaced by ":id", to keep the cardinality low.
+//
+// The path is matched against the known API routes. Paths of unknown routes
+// keep only the segments that are static in some known route.
+func (c *Client) pathTemplate(req *retryablehttp.Request) string {
+	path := strings.TrimPrefix(req.URL.EscapedPath(), c.baseURL.EscapedPath())
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	tree := apiRouteTree()
+	template := make([]string, len(segments))
+	if !tree.match(segments, template) {
+		for i, segment := range segments {
+			if tree.segments[segment] {
+				template[i] = segment
+			} else {
+				template[i] = ":id"
+			}
+		}
+	}
+
+	return strings.Join(template, "/")
+}
+
+// routeNode is a node in the tree of API routes.
+type routeNode struct {
+	static map[string]*routeNode
+	param  *routeNode
+	route  bool
+
+	// segments contains all static segments of the routes, and is only set
+	// on the root of the tree.
+	segments map[string]bool
+}
+
+var (
+	routeTreeOnce sync.Once
+	routeTree     *routeNode
+)
+
+// apiRouteTree returns the tree of all known API routes.
+func apiRouteTree() *routeNode {
+	routeTreeOnce.Do(func() {
+		routeTree = &routeNode{segments: make(map[string]bool)}
+		for _, route := range apiRoutes {
+			n := routeTree
+			for _, segment := range strings.Split(route, "/") {
+				if segment == ":id" {
+					if n.param == nil {
+						n.param = &routeNode{}
+					}
+					n = n.param
+					continue
+				}
+				routeTree.segments[segment] = true
+				if n.static == nil {
+					n.static = make(map[string]*routeNode)
+				}
+				if n.static[segment] == nil {
+					n.static[segment] = &routeNode{}
+				}
+				n = n.static[segment]
+			}
+			n.route = true
+		}
+	})
+	return routeTree
+}
+
+// match reports whether the segments match a route of the tree, preferring
+// static segments over parameters, and fills in the template of the route.
+func (n *routeNode) match(segments, template []string) bool {
+	if len(segments) == 0 {
+		return n.route
+	}
+	if next := n.static[segments[0]]; next != nil && next.match(segments[1:], template[1:]) {
+		template[0] = segments[0]
+		return true
+	}
+	if n.param != nil && n.param.match(segments[1:], template[1:]) {
+		template[0] = ":id"
+		return true
+	}
+	return false
+}
+
+// callerOperation returns the name of the first exported service method found
+// on the call stack, for example "MergeRequestsService.AcceptMergeRequest".
+func callerOperation() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	prefix := packagePath + ".(*"
+	for {
+		frame, more := frames.Next()
+
+		if name := strings.TrimPrefix(frame.Function, prefix); name != frame.Function {
+			// name has the form "JobsService).TailTrace" or "JobsService).TailTrace.func1".
+			if parts := strings.SplitN(name, ").", 2); len(parts) == 2 && strings.HasSuffix(parts[0], "Service") {
+				method := strings.SplitN(parts[1], ".", 2)[0]
+				if method != "" && unicode.IsUpper(rune(method[0])) {
+					return parts[0] + "." + method
+				}
+			}
+		}
+
+		if !more {
+			return "Client.Do"
+		}
+	}
+}
@@ -0,0 +1,165 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type contextKey string
+
+type recordingInstrumentation struct {
+	infos   []*RequestInfo
+	results []*RequestResult
+}
+
+func (i *recordingInstrumentation) StartRequest(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, contextKey("span"), info.Operation)
+}
+
+func (i *recordingInstrumentation) EndRequest(ctx context.Context, info *RequestInfo, result *RequestResult) {
+	if ctx.Value(contextKey("span")) != info.Operation {
+		panic("context returned by StartRequest is not passed to EndRequest")
+	}
+	i.infos = append(i.infos, info)
+	i.results = append(i.results, result)
+}
+
+func TestInstrumentation(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	attempts := 0
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/5/merge", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("RateLimit-Remaining", "42")
+		fmt.Fprint(w, `{"iid":5}`)
+	})
+
+	instrumentation := &recordingInstrumentation{}
+	client, err := NewClient("",
+		WithBaseURL(server.URL),
+		WithCustomBackoff(func(_, _ time.Duration, _ int, _ *http.Response) time.Duration {
+			return 0
+		}),
+		WithInstrumentation(instrumentation),
+	)
+	require.NoError(t, err)
+
+	_, _, err = client.MergeRequests.AcceptMergeRequest(1, 5, nil)
+	require.NoError(t, err)
+
+	require.Len(t, instrumentation.infos, 1)
+	assert.Equal(t, "MergeRequestsService.AcceptMergeRequest", instrumentation.infos[0].Operation)
+	assert.Equal(t, http.MethodPut, instrumentation.infos[0].Method)
+	assert.Equal(t, "projects/:id/merge_requests/:id/merge", instrumentation.infos[0].PathTemplate)
+
+	result := instrumentation.results[0]
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, 1, result.Retries)
+	assert.Equal(t, 42, result.RateLimitRemaining)
+	assert.NoError(t, result.Err)
+}
+
+func TestInstrumentationOperationFromPaginator(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/api/v4/projects/1/pipelines", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	instrumentation := &recordingInstrumentation{}
+	client, err := NewClient("", WithBaseURL(server.URL), WithInstrumentation(instrumentation))
+	require.NoError(t, err)
+
+	_, err = All(context.Background(), func(options ...RequestOptionFunc) ([]*PipelineInfo, *Response, error) {
+		return client.Pipelines.ListProjectPipelines(1, nil, options...)
+	})
+	require.Error(t, err)
+
+	require.Len(t, instrumentation.infos, 1)
+	assert.Equal(t, "PipelinesService.ListProjectPipelines", instrumentation.infos[0].Operation)
+	assert.Equal(t, http.StatusNotFound, instrumentation.results[0].StatusCode)
+	assert.Equal(t, -1, instrumentation.results[0].RateLimitRemaining)
+	assert.ErrorIs(t, instrumentation.results[0].Err, ErrNotFound)
+}
+
+func TestPathTemplate(t *testing.T) {
+	client, err := NewClient("", WithBaseURL("https://gitlab.example.com/gitlab/"))
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"projects/group%2Fproject/repository/files/docs%2FREADME%2Emd": "projects/:id/repository/files/:id",
+		"projects/12/pipelines/345/jobs":                               "projects/:id/pipelines/:id/jobs",
+		"projects/12/repository/commits/0a1b2c3d4e5f":                  "projects/:id/repository/commits/:id",
+		"projects/12/repository/commits/0a1b2c3d4e5f/refs":             "projects/:id/repository/commits/:id/refs",
+		"projects/12/repository/branches/main":                         "projects/:id/repository/branches/:id",
+		"projects/12/repository/tags/v1.0.0":                           "projects/:id/repository/tags/:id",
+		"users/jdoe/projects":                                          "users/:id/projects",
+		"user":                                                         "user",
+		"projects/12/unknown/feature-x":                                "projects/:id/:id/:id",
+	}
+
+	for path, want := range tests {
+		req, err := client.NewRequest(http.MethodGet, path, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, client.pathTemplate(req))
+	}
+}
+
+func TestInstrumentationKeepsRequestContext(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	instrumentation := &recordingInstrumentation{}
+	client, err := NewClient("", WithBaseURL(server.URL), WithInstrumentation(instrumentation))
+	require.NoError(t, err)
+
+	req, err := client.NewRequest(http.MethodGet, "projects/1", nil, nil)
+	require.NoError(t, err)
+	ctx := req.Context()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.Do(req, nil)
+		require.NoError(t, err)
+		assert.Equal(t, ctx, req.Context())
+	}
+
+	require.Len(t, instrumentation.results, 2)
+	assert.Equal(t, 0, instrumentation.results[1].Retries)
+}
@@ -0,0 +1,300 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTracePollInterval is the default time TailTrace waits between polls.
+const defaultTracePollInterval = 3 * time.Second
+
+var (
+	ansiEscapeRe   = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+	sectionStartRe = regexp.MustCompile(`section_start:(\d+):([A-Za-z0-9_.-]+)(?:\[([^\]]*)\])?\r?`)
+	sectionEndRe   = regexp.MustCompile(`section_end:(\d+):([A-Za-z0-9_.-]+)\r?`)
+)
+
+// TraceSection represents a collapsible section of a job log.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/ci/jobs/index.html#custom-collapsible-sections
+type TraceSection struct {
+	Name       string
+	Header     string
+	Collapsed  bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Lines      []string
+}
+
+// Duration returns the time it took to complete the section.
+func (s *TraceSection) Duration() time.Duration {
+	return s.FinishedAt.Sub(s.StartedAt)
+}
+
+// TailTraceOptions represents the available TailTrace() options.
+type TailTraceOptions struct {
+	// PollInterval is the time to wait between polls. Defaults to 3 seconds.
+	PollInterval time.Duration
+
+	// Offset is the number of bytes of the log to skip, which can be used to
+	// continue tailing a log at the offset returned by an earlier call to
+	// TailTrace.
+	Offset int64
+
+	// StripANSI removes ANSI escape codes and section markers from the log
+	// before it is written.
+	StripANSI bool
+
+	// OnSection is called for every collapsible section once it is finished.
+	OnSection func(*TraceSection)
+}
+
+// TailTrace follows the log of a specific job of a project and writes new
+// parts of the log to the provided io.Writer as they become available. The
+// log is polled using HTTP Range requests starting at the last known offset
+// and TailTrace returns once the job reached a terminal state (success,
+// failed, canceled, skipped or manual) and the complete log is written.
+//
+// Use the WithContext request option to stop tailing early. TailTrace always
+// returns the offset in the log up to which it was written, which can be
+// passed as TailTraceOptions.Offset to continue tailing later. A partial last
+// line is only written once the job is finished, so it isn't included in the
+// offset before that.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/jobs.html#get-a-log-file
+func (s *JobsService) TailTrace(pid interface{}, jobID int, w io.Writer, opt *TailTraceOptions, options ...RequestOptionFunc) (*Job, int64, error) {
+	if opt == nil {
+		opt = &TailTraceOptions{}
+	}
+
+	interval := opt.PollInterval
+	if interval <= 0 {
+		interval = defaultTracePollInterval
+	}
+
+	tw := &traceWriter{w: w, stripANSI: opt.StripANSI, onSection: opt.OnSection}
+	offset := opt.Offset
+
+	// written returns the offset up to which the log is written, which
+	// excludes the partial line the writer is holding on to.
+	written := func() int64 {
+		return offset - int64(len(tw.buf))
+	}
+
+	for {
+		job, _, err := s.GetJob(pid, jobID, options...)
+		if err != nil {
+			return nil, written(), err
+		}
+		// Jobs waiting for manual action don't progress on their own.
+		terminal := isTerminalBuildState(job.Status) || job.Status == string(Manual)
+
+		// Fetch the log after getting the job state, so we are sure the final
+		// part of the log is fetched when the job is in a terminal state.
+		req, chunk, err := s.getTraceChunk(pid, jobID, offset, options)
+		if err != nil {
+			return job, written(), err
+		}
+		if len(chunk) > 0 {
+			_, err := tw.Write(chunk)
+			offset += int64(len(chunk))
+			if err != nil {
+				return job, written(), err
+			}
+		}
+
+		if terminal {
+			err := tw.Flush()
+			return job, written(), err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return job, written(), req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// getTraceChunk requests the part of the log starting at the given offset.
+func (s *JobsService) getTraceChunk(pid interface{}, jobID int, offset int64, options []RequestOptionFunc) (*http.Request, []byte, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, nil, err
+	}
+	u := fmt.Sprintf("projects/%s/jobs/%d/trace", PathEscape(project), jobID)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	var b bytes.Buffer
+	resp, err := s.client.Do(req, &b)
+	if err != nil {
+		var errResp *ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			// There is nothing new since the last request.
+			return req.Request, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	chunk := b.Bytes()
+	if resp.StatusCode != http.StatusPartialContent {
+		// The Range header was ignored, so skip the part we already have.
+		if int64(len(chunk)) <= offset {
+			return req.Request, nil, nil
+		}
+		chunk = chunk[offset:]
+	}
+
+	return req.Request, chunk, nil
+}
+
+// isTerminalBuildState reports whether a job or pipeline with the given
+// status will not change state anymore.
+func isTerminalBuildState(status string) bool {
+	switch BuildStateValue(status) {
+	case Success, Failed, Canceled, Skipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// traceWriter processes a job log line by line, optionally stripping ANSI
+// escape codes and collecting collapsible sections.
+type traceWriter struct {
+	w         io.Writer
+	stripANSI bool
+	onSection func(*TraceSection)
+
+	buf      []byte
+	sections []*TraceSection
+}
+
+func (tw *traceWriter) Write(p []byte) (int, error) {
+	tw.buf = append(tw.buf, p...)
+
+	for {
+		i := bytes.IndexByte(tw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := tw.buf[:i+1]
+		if err := tw.writeLine(line); err != nil {
+			return 0, err
+		}
+		tw.buf = tw.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any remaining partial line.
+func (tw *traceWriter) Flush() error {
+	if len(tw.buf) == 0 {
+		return nil
+	}
+	if err := tw.writeLine(tw.buf); err != nil {
+		return err
+	}
+	tw.buf = nil
+	return nil
+}
+
+func (tw *traceWriter) writeLine(line []byte) error {
+	text := string(line)
+
+	for _, m := range sectionStartRe.FindAllStringSubmatch(text, -1) {
+		section := &TraceSection{
+			Name:      m[2],
+			StartedAt: parseSectionTime(m[1]),
+			Collapsed: strings.Contains(m[3], "collapsed=true"),
+		}
+		section.Header = strings.TrimSpace(stripTraceMarkers(text[strings.Index(text, m[0])+len(m[0]):]))
+		tw.sections = append(tw.sections, section)
+	}
+
+	for _, m := range sectionEndRe.FindAllStringSubmatch(text, -1) {
+		tw.finishSection(m[2], parseSectionTime(m[1]))
+	}
+
+	stripped := stripTraceMarkers(text)
+	if content := strings.TrimRight(stripped, "\r\n"); content != "" && len(tw.sections) > 0 {
+		current := tw.sections[len(tw.sections)-1]
+		if content != current.Header {
+			current.Lines = append(current.Lines, content)
+		}
+	}
+
+	if tw.stripANSI {
+		if strings.TrimSpace(stripped) == "" && strings.TrimSpace(text) != "" {
+			// The line only contained markers, so don't write it at all.
+			return nil
+		}
+		_, err := io.WriteString(tw.w, stripped)
+		return err
+	}
+
+	_, err := tw.w.Write(line)
+	return err
+}
+
+func (tw *traceWriter) finishSection(name string, finishedAt time.Time) {
+	for i := len(tw.sections) - 1; i >= 0; i-- {
+		if tw.sections[i].Name != name {
+			continue
+		}
+		section := tw.sections[i]
+		section.FinishedAt = finishedAt
+		tw.sections = append(tw.sections[:i], tw.sections[i+1:]...)
+		if tw.onSection != nil {
+			tw.onSection(section)
+		}
+		return
+	}
+}
+
+// stripTraceMarkers removes all section markers and ANSI escape codes.
+func stripTraceMarkers(s string) string {
+	s = sectionStartRe.ReplaceAllString(s, "")
+	s = sectionEndRe.ReplaceAllString(s, "")
+	return ansiEscapeRe.ReplaceAllString(s, "")
+}
+
+func parseSectionTime(s string) time.Time {
+	sec, _ := strconv.ParseInt(s, 10, 64)
+	return time.Unix(sec, 0)
+}
@@ -0,0 +1,157 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailTrace(t *testing.T) {
+	mux, client := setup(t)
+
+	chunks := []string{
+		"\x1b[0Ksection_start:1560896352:install[collapsed=true]\r\x1b[0K\x1b[32;1mInstalling\x1b[0;m\n",
+		"npm ci\nadded 12 packages\n",
+		"\x1b[0Ksection_end:1560896360:install\r\x1b[0K\nJob succ",
+		"eeded\n",
+	}
+
+	polls := 0
+	mux.HandleFunc("/api/v4/projects/1/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		polls++
+		status := "running"
+		if polls == len(chunks) {
+			status = "success"
+		}
+		fmt.Fprintf(w, `{"id":2,"status":%q}`, status)
+	})
+
+	var ranges []string
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		ranges = append(ranges, r.Header.Get("Range"))
+
+		trace := strings.Join(chunks[:polls], "")
+		offset := 0
+		if rng := r.Header.Get("Range"); rng != "" {
+			offset, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		fmt.Fprint(w, trace[offset:])
+	})
+
+	var sections []*TraceSection
+	var b bytes.Buffer
+	job, offset, err := client.Jobs.TailTrace(1, 2, &b, &TailTraceOptions{
+		PollInterval: time.Millisecond,
+		StripANSI:    true,
+		OnSection: func(s *TraceSection) {
+			sections = append(sections, s)
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "success", job.Status)
+	assert.Equal(t, "Installing\nnpm ci\nadded 12 packages\nJob succeeded\n", b.String())
+	assert.Equal(t, []string{"", "bytes=80-", "bytes=105-", "bytes=153-"}, ranges)
+	assert.Equal(t, int64(159), offset)
+
+	require.Len(t, sections, 1)
+	assert.Equal(t, "install", sections[0].Name)
+	assert.Equal(t, "Installing", sections[0].Header)
+	assert.True(t, sections[0].Collapsed)
+	assert.Equal(t, 8*time.Second, sections[0].Duration())
+	assert.Equal(t, []string{"npm ci", "added 12 packages"}, sections[0].Lines)
+}
+
+func TestTailTraceRaw(t *testing.T) {
+	mux, client := setup(t)
+
+	trace := "\x1b[32;1mJob succeeded\x1b[0;m\n"
+
+	mux.HandleFunc("/api/v4/projects/1/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"status":"failed"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, trace)
+	})
+
+	var b bytes.Buffer
+	job, _, err := client.Jobs.TailTrace(1, 2, &b, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", job.Status)
+	assert.Equal(t, trace, b.String())
+}
+
+func TestTailTraceContextCanceled(t *testing.T) {
+	mux, client := setup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mux.HandleFunc("/api/v4/projects/1/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"status":"running"}`)
+	})
+	polls := 0
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls > 1 {
+			cancel()
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, "\x1b[32;1mline one\x1b[0;m\nline t")
+	})
+
+	var b bytes.Buffer
+	_, offset, err := client.Jobs.TailTrace(1, 2, &b, &TailTraceOptions{
+		PollInterval: time.Millisecond,
+		Offset:       10,
+		StripANSI:    true,
+	}, WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "line one\n", b.String())
+	assert.Equal(t, int64(10+len("\x1b[32;1mline one\x1b[0;m\n")), offset)
+}
+
+func TestTailTraceManual(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"status":"manual"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "")
+	})
+
+	var b bytes.Buffer
+	job, offset, err := client.Jobs.TailTrace(1, 2, &b, &TailTraceOptions{PollInterval: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, "manual", job.Status)
+	assert.Equal(t, int64(0), offset)
+}
@@ -19,6 +19,7 @@ package gitlab
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 )
@@ -273,6 +274,27 @@ func (s *JobsService) GetJobArtifacts(pid interface{}, jobID int, options ...Req
 	return bytes.NewReader(artifactsBuf.Bytes()), resp, err
 }
 
+// StreamJobArtifacts streams the artifacts archive of a job to the provided
+// io.Writer. If the download is interrupted, it is resumed using a HTTP
+// Range request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/job_artifacts.html#get-job-artifacts
+func (s *JobsService) StreamJobArtifacts(pid interface{}, jobID int, w io.Writer, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("projects/%s/jobs/%d/artifacts", PathEscape(project), jobID)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.stream(req, w)
+}
+
 // DownloadArtifactsFileOptions represents the available DownloadArtifactsFile()
 // options.
 //
@@ -308,6 +330,27 @@ func (s *JobsService) DownloadArtifactsFile(pid interface{}, refName string, opt
 	return bytes.NewReader(artifactsBuf.Bytes()), resp, err
 }
 
+// StreamArtifactsFile streams the artifacts archive from the given reference
+// name and job to the provided io.Writer. If the download is interrupted, it
+// is resumed using a HTTP Range request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/job_artifacts.html#download-the-artifacts-archive
+func (s *JobsService) StreamArtifactsFile(pid interface{}, refName string, w io.Writer, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("projects/%s/jobs/artifacts/%s/download", PathEscape(project), refName)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, opt, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.stream(req, w)
+}
+
 // DownloadSingleArtifactsFile download a file from the artifacts from the
 // given reference name and job provided the job finished successfully.
 // Only a single file is going to be extracted from the archive and streamed
@@ -342,6 +385,33 @@ func (s *JobsService) DownloadSingleArtifactsFile(pid interface{}, jobID int, ar
 	return bytes.NewReader(artifactBuf.Bytes()), resp, err
 }
 
+// StreamSingleArtifactsFile streams a single file from the artifacts of the
+// given job to the provided io.Writer. If the download is interrupted, it is
+// resumed using a HTTP Range request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/job_artifacts.html#download-a-single-artifact-file-by-job-id
+func (s *JobsService) StreamSingleArtifactsFile(pid interface{}, jobID int, artifactPath string, w io.Writer, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf(
+		"projects/%s/jobs/%d/artifacts/%s",
+		PathEscape(project),
+		jobID,
+		artifactPath,
+	)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.stream(req, w)
+}
+
 // DownloadSingleArtifactsFile download a single artifact file for a specific
 // job of the latest successful pipeline for the given reference name from
 // inside the job’s artifacts archive. The file is extracted from the archive
@@ -376,6 +446,34 @@ func (s *JobsService) DownloadSingleArtifactsFileByTagOrBranch(pid interface{},
 	return bytes.NewReader(artifactBuf.Bytes()), resp, err
 }
 
+// StreamSingleArtifactsFileByTagOrBranch streams a single artifact file for a
+// specific job of the latest successful pipeline for the given reference name
+// to the provided io.Writer. If the download is interrupted, it is resumed
+// using a HTTP Range request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/job_artifacts.html#download-a-single-artifact-file-from-specific-tag-or-branch
+func (s *JobsService) StreamSingleArtifactsFileByTagOrBranch(pid interface{}, refName string, artifactPath string, w io.Writer, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf(
+		"projects/%s/jobs/artifacts/%s/raw/%s",
+		PathEscape(project),
+		PathEscape(refName),
+		artifactPath,
+	)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, opt, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.stream(req, w)
+}
+
 // GetTraceFile gets a trace of a specific job of a project
 //
 // GitLab API docs:
@@ -401,6 +499,27 @@ func (s *JobsService) GetTraceFile(pid interface{}, jobID int, options ...Reques
 	return bytes.NewReader(traceBuf.Bytes()), resp, err
 }
 
+// StreamTraceFile streams the trace of a specific job of a project to the
+// provided io.Writer. If the download is interrupted, it is resumed using a
+// HTTP Range request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/jobs.html#get-a-log-file
+func (s *JobsService) StreamTraceFile(pid interface{}, jobID int, w io.Writer, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("projects/%s/jobs/%d/trace", PathEscape(project), jobID)
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.stream(req, w)
+}
+
 // CancelJob cancels a single job of a project.
 //
 // GitLab API docs:
@@ -17,13 +17,16 @@
 package gitlab
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestListPipelineJobs(t *testing.T) {
@@ -189,6 +192,98 @@ func TestJobsService_ListProjectJobs(t *testing.T) {
 	assert.Equal(t, want, jobs)
 }
 
+func TestStreamJobArtifacts(t *testing.T) {
+	mux, client := setup(t)
+
+	wantContent := []byte("This is the artifacts archive")
+	mux.HandleFunc("/api/v4/projects/9/jobs/1/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		w.Write(wantContent)
+	})
+
+	var b bytes.Buffer
+	resp, err := client.Jobs.StreamJobArtifacts(9, 1, &b)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, wantContent, b.Bytes())
+}
+
+func TestStreamTraceFileResumesInterruptedDownload(t *testing.T) {
+	mux, client := setup(t)
+
+	content := "Running with gitlab-runner\nJob succeeded\n"
+	half := len(content) / 2
+
+	var ranges []string
+	mux.HandleFunc("/api/v4/projects/9/jobs/1/trace", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		ranges = append(ranges, r.Header.Get("Range"))
+
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("ETag", `"abc"`)
+
+		if r.Header.Get("Range") == "" {
+			// Send only part of the content and then drop the connection.
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(content[:half]))
+			w.(http.Flusher).Flush()
+
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+
+		assert.Equal(t, fmt.Sprintf("bytes=%d-", half), r.Header.Get("Range"))
+		assert.Equal(t, `"abc"`, r.Header.Get("If-Range"))
+
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", half, len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(content[half:]))
+	})
+
+	var b bytes.Buffer
+	resp, err := client.Jobs.StreamTraceFile(9, 1, &b)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, content, b.String())
+	require.Equal(t, []string{"", fmt.Sprintf("bytes=%d-", half)}, ranges)
+}
+
+func TestStreamTraceFileRangeIgnored(t *testing.T) {
+	mux, client := setup(t)
+
+	content := "Running with gitlab-runner\nJob succeeded\n"
+	half := len(content) / 2
+
+	requests := 0
+	mux.HandleFunc("/api/v4/projects/9/jobs/1/trace", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Accept-Ranges", "bytes")
+
+		if requests == 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(content[:half]))
+			w.(http.Flusher).Flush()
+
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+
+		// Ignore the Range header and send the full content.
+		w.Write([]byte(content))
+	})
+
+	var b bytes.Buffer
+	_, err := client.Jobs.StreamTraceFile(9, 1, &b)
+	require.NoError(t, err)
+	require.Equal(t, content, b.String())
+}
+
 func TestDownloadSingleArtifactsFileByTagOrBranch(t *testing.T) {
 	mux, client := setup(t)
 
@@ -0,0 +1,272 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultMergePollInterval is the default time MergeWhenReady waits between
+// polls.
+const defaultMergePollInterval = 5 * time.Second
+
+// defaultMergeAttempts is the default number of times MergeWhenReady tries to
+// merge before giving up.
+const defaultMergeAttempts = 3
+
+// MergeBlockedReason represents the reason a merge request cannot be merged.
+// Apart from the reasons below, it can be any detailed merge status reported
+// by GitLab that blocks merging.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/merge_requests.html#merge-status
+type MergeBlockedReason string
+
+// The most common reasons a merge request cannot be merged.
+const (
+	MergeBlockedConflict       MergeBlockedReason = "conflict"
+	MergeBlockedDiscussions    MergeBlockedReason = "discussions_not_resolved"
+	MergeBlockedDraft          MergeBlockedReason = "draft_status"
+	MergeBlockedNeedRebase     MergeBlockedReason = "need_rebase"
+	MergeBlockedNotApproved    MergeBlockedReason = "not_approved"
+	MergeBlockedNotOpen        MergeBlockedReason = "not_open"
+	MergeBlockedPipelineFailed MergeBlockedReason = "pipeline_failed"
+	MergeBlockedPipelineManual MergeBlockedReason = "pipeline_manual"
+	MergeBlockedRebaseFailed   MergeBlockedReason = "rebase_failed"
+)
+
+// MergeBlockedError is returned by MergeWhenReady when the merge request
+// cannot be merged without human intervention.
+type MergeBlockedError struct {
+	Reason       MergeBlockedReason
+	MergeRequest *MergeRequest
+
+	// Pipeline is the failed pipeline for MergeBlockedPipelineFailed, or the
+	// pipeline waiting for a manual job for MergeBlockedPipelineManual.
+	Pipeline *PipelineInfo
+
+	// ApprovalState contains the approval rules for MergeBlockedNotApproved.
+	ApprovalState *MergeRequestApprovalState
+}
+
+func (e *MergeBlockedError) Error() string {
+	msg := fmt.Sprintf("merge request !%d cannot be merged: %s", e.MergeRequest.IID, e.Reason)
+	if e.Reason == MergeBlockedRebaseFailed && e.MergeRequest.MergeError != "" {
+		msg += ": " + e.MergeRequest.MergeError
+	}
+	return msg
+}
+
+// MergeWhenReadyOptions represents the available MergeWhenReady() options.
+type MergeWhenReadyOptions struct {
+	// PollInterval is the time to wait between polls. Defaults to 5 seconds.
+	PollInterval time.Duration
+
+	// MergeAttempts is the number of times merging is attempted when GitLab
+	// rejects the merge. Defaults to 3.
+	MergeAttempts int
+
+	// DisableRebase returns a MergeBlockedError when the source branch is
+	// behind the target branch, instead of rebasing it.
+	DisableRebase bool
+
+	// SkipCI skips creating a pipeline when rebasing the source branch.
+	SkipCI bool
+
+	// WaitForApproval keeps waiting while the merge request is not approved,
+	// instead of returning a MergeBlockedError.
+	WaitForApproval bool
+
+	// Accept contains the options used to merge the merge request. The SHA is
+	// always set to the head SHA the merge request was found mergeable with.
+	Accept *AcceptMergeRequestOptions
+}
+
+// MergeWhenReady waits until a merge request is mergeable and merges it. It
+// polls the detailed merge status of the merge request, rebases the source
+// branch when it is behind the target branch and waits for running checks
+// and pipelines to finish.
+//
+// The merge is pinned to the head SHA that was found mergeable, so new
+// commits pushed in the meantime are never merged unchecked. If merging fails
+// because the merge request changed, MergeWhenReady starts waiting again,
+// until the merge has been rejected MergeAttempts times. The last
+// *ErrorResponse is returned in that case.
+//
+// A *MergeBlockedError is returned when the merge request cannot be merged
+// without human intervention. If the merge request is already merged, it is
+// returned without error. Use the WithContext request option to stop waiting.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/merge_requests.html#merge-a-merge-request
+func (s *MergeRequestsService) MergeWhenReady(pid interface{}, mergeRequest int, opt *MergeWhenReadyOptions, options ...RequestOptionFunc) (*MergeRequest, error) {
+	if opt == nil {
+		opt = &MergeWhenReadyOptions{}
+	}
+
+	interval := opt.PollInterval
+	if interval <= 0 {
+		interval = defaultMergePollInterval
+	}
+
+	attempts := opt.MergeAttempts
+	if attempts <= 0 {
+		attempts = defaultMergeAttempts
+	}
+
+	// GitLab keeps the merge error of an earlier failed rebase, so only a merge
+	// error other than the one seen when requesting the rebase means it failed.
+	rebasing := false
+	staleMergeError := ""
+	for {
+		mr, resp, err := s.GetMergeRequest(pid, mergeRequest, &GetMergeRequestsOptions{
+			IncludeRebaseInProgress: Ptr(true),
+		}, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		blocked := func(reason MergeBlockedReason) *MergeBlockedError {
+			return &MergeBlockedError{Reason: reason, MergeRequest: mr}
+		}
+
+		switch {
+		case mr.State == "merged":
+			return mr, nil
+		case mr.State != "opened":
+			return nil, blocked(MergeBlockedNotOpen)
+		case mr.RebaseInProgress:
+			// Wait for the rebase to finish.
+		case rebasing && mr.MergeError != "" && mr.MergeError != staleMergeError:
+			return nil, blocked(MergeBlockedRebaseFailed)
+		default:
+			rebasing = false
+
+			switch mr.DetailedMergeStatus {
+			case "mergeable":
+				merged, rejected, err := s.acceptMergeRequest(pid, mr, opt.Accept, options)
+				if rejected && attempts > 1 {
+					// Check the merge status again.
+					attempts--
+					break
+				}
+				return merged, err
+
+			case "need_rebase":
+				if opt.DisableRebase {
+					return nil, blocked(MergeBlockedNeedRebase)
+				}
+				staleMergeError = mr.MergeError
+				_, err := s.RebaseMergeRequest(pid, mergeRequest, &RebaseMergeRequestOptions{SkipCI: Ptr(opt.SkipCI)}, options...)
+				// A conflict means a rebase is already in progress.
+				var conflictErr *ConflictError
+				if err != nil && !errors.As(err, &conflictErr) {
+					return nil, err
+				}
+				rebasing = true
+
+			case "ci_must_pass", "ci_still_running":
+				pipeline, err := s.headPipeline(pid, mr, options)
+				if err != nil {
+					return nil, err
+				}
+				switch {
+				case pipeline == nil:
+					// Wait for the pipeline to be created.
+				case pipeline.Status == string(Manual):
+					blockedErr := blocked(MergeBlockedPipelineManual)
+					blockedErr.Pipeline = pipeline
+					return nil, blockedErr
+				case isTerminalBuildState(pipeline.Status) && pipeline.Status != string(Success):
+					blockedErr := blocked(MergeBlockedPipelineFailed)
+					blockedErr.Pipeline = pipeline
+					return nil, blockedErr
+				}
+
+			case "not_approved":
+				if !opt.WaitForApproval {
+					state, _, err := s.client.MergeRequestApprovals.GetApprovalState(pid, mergeRequest, options...)
+					if err != nil {
+						return nil, err
+					}
+					blockedErr := blocked(MergeBlockedNotApproved)
+					blockedErr.ApprovalState = state
+					return nil, blockedErr
+				}
+
+			case "checking", "unchecked", "preparing", "approvals_syncing":
+				// Wait for GitLab to determine the merge status.
+
+			default:
+				return nil, blocked(MergeBlockedReason(mr.DetailedMergeStatus))
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-resp.Request.Context().Done():
+			timer.Stop()
+			return nil, resp.Request.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// acceptMergeRequest merges the merge request pinned to its current head SHA.
+// It reports whether GitLab rejected the merge, because the merge request is
+// not mergeable (anymore) or its head SHA changed in the meantime.
+func (s *MergeRequestsService) acceptMergeRequest(pid interface{}, mr *MergeRequest, opt *AcceptMergeRequestOptions, options []RequestOptionFunc) (*MergeRequest, bool, error) {
+	aopt := &AcceptMergeRequestOptions{}
+	if opt != nil {
+		*aopt = *opt
+	}
+	aopt.SHA = Ptr(mr.SHA)
+
+	merged, _, err := s.AcceptMergeRequest(pid, mr.IID, aopt, options...)
+	if err != nil {
+		var errResp *ErrorResponse
+		if errors.As(err, &errResp) {
+			switch errResp.Response.StatusCode {
+			case http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusConflict, http.StatusUnprocessableEntity:
+				return nil, true, err
+			}
+		}
+		return nil, false, err
+	}
+
+	return merged, false, nil
+}
+
+// headPipeline returns the most recent pipeline for the head SHA of the merge
+// request, or nil if there is none yet.
+func (s *MergeRequestsService) headPipeline(pid interface{}, mr *MergeRequest, options []RequestOptionFunc) (*PipelineInfo, error) {
+	pipelines, _, err := s.ListMergeRequestPipelines(pid, mr.IID, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pipeline := range pipelines {
+		if pipeline.SHA == mr.SHA {
+			return pipeline, nil
+		}
+	}
+
+	return nil, nil
+}
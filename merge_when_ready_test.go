@@ -0,0 +1,240 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeWhenReady(t *testing.T) {
+	mux, client := setup(t)
+
+	states := []string{
+		`{"iid":1,"state":"opened","detailed_merge_status":"need_rebase","sha":"aaa"}`,
+		`{"iid":1,"state":"opened","detailed_merge_status":"need_rebase","sha":"aaa","rebase_in_progress":true}`,
+		`{"iid":1,"state":"opened","detailed_merge_status":"ci_still_running","sha":"bbb"}`,
+		`{"iid":1,"state":"opened","detailed_merge_status":"mergeable","sha":"bbb"}`,
+		`{"iid":1,"state":"opened","detailed_merge_status":"mergeable","sha":"ccc"}`,
+	}
+	var polls int
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, "true", r.URL.Query().Get("include_rebase_in_progress"))
+
+		fmt.Fprint(w, states[polls])
+		polls++
+	})
+
+	var rebases int
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1/rebase", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		rebases++
+		fmt.Fprint(w, `{"rebase_in_progress":true}`)
+	})
+
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1/pipelines", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"id":2,"sha":"bbb","status":"running"},{"id":1,"sha":"aaa","status":"failed"}]`)
+	})
+
+	var shas []string
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		var opt AcceptMergeRequestOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opt))
+		assert.True(t, *opt.Squash)
+		shas = append(shas, *opt.SHA)
+
+		// The first attempt fails, because a commit was pushed meanwhile.
+		if *opt.SHA == "bbb" {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"message":"SHA does not match HEAD of source branch"}`)
+			return
+		}
+		fmt.Fprint(w, `{"iid":1,"state":"merged"}`)
+	})
+
+	mr, err := client.MergeRequests.MergeWhenReady(1, 1, &MergeWhenReadyOptions{
+		PollInterval: time.Millisecond,
+		Accept:       &AcceptMergeRequestOptions{Squash: Ptr(true)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "merged", mr.State)
+
+	assert.Equal(t, 5, polls)
+	assert.Equal(t, 1, rebases)
+	assert.Equal(t, []string{"bbb", "ccc"}, shas)
+}
+
+func TestMergeWhenReadyBlocked(t *testing.T) {
+	tests := map[string]struct {
+		mr     string
+		opt    *MergeWhenReadyOptions
+		reason MergeBlockedReason
+	}{
+		"draft": {
+			mr:     `{"iid":1,"state":"opened","detailed_merge_status":"draft_status"}`,
+			reason: MergeBlockedDraft,
+		},
+		"closed": {
+			mr:     `{"iid":1,"state":"closed","detailed_merge_status":"not_open"}`,
+			reason: MergeBlockedNotOpen,
+		},
+		"pipeline failed": {
+			mr:     `{"iid":1,"state":"opened","detailed_merge_status":"ci_must_pass","sha":"aaa"}`,
+			reason: MergeBlockedPipelineFailed,
+		},
+		"pipeline manual": {
+			mr:     `{"iid":1,"state":"opened","detailed_merge_status":"ci_still_running","sha":"bbb"}`,
+			reason: MergeBlockedPipelineManual,
+		},
+		"not approved": {
+			mr:     `{"iid":1,"state":"opened","detailed_merge_status":"not_approved"}`,
+			reason: MergeBlockedNotApproved,
+		},
+		"rebase disabled": {
+			mr:     `{"iid":1,"state":"opened","detailed_merge_status":"need_rebase"}`,
+			opt:    &MergeWhenReadyOptions{DisableRebase: true},
+			reason: MergeBlockedNeedRebase,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mux, client := setup(t)
+
+			mux.HandleFunc("/api/v4/projects/1/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, test.mr)
+			})
+			mux.HandleFunc("/api/v4/projects/1/merge_requests/1/pipelines", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `[{"id":2,"sha":"bbb","status":"manual"},{"id":1,"sha":"aaa","status":"failed"}]`)
+			})
+			mux.HandleFunc("/api/v4/projects/1/merge_requests/1/approval_state", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"rules":[{"name":"Maintainers","approved":false}]}`)
+			})
+
+			_, err := client.MergeRequests.MergeWhenReady(1, 1, test.opt)
+
+			var blockedErr *MergeBlockedError
+			require.ErrorAs(t, err, &blockedErr)
+			assert.Equal(t, test.reason, blockedErr.Reason)
+
+			switch test.reason {
+			case MergeBlockedPipelineFailed:
+				assert.Equal(t, 1, blockedErr.Pipeline.ID)
+			case MergeBlockedPipelineManual:
+				assert.Equal(t, 2, blockedErr.Pipeline.ID)
+			case MergeBlockedNotApproved:
+				assert.Equal(t, "Maintainers", blockedErr.ApprovalState.Rules[0].Name)
+			}
+		})
+	}
+}
+
+func TestMergeWhenReadyRebaseFailed(t *testing.T) {
+	mux, client := setup(t)
+
+	states := []string{
+		`{"iid":1,"state":"opened","detailed_merge_status":"need_rebase"}`,
+		`{"iid":1,"state":"opened","detailed_merge_status":"need_rebase","merge_error":"Rebase failed: conflicts"}`,
+	}
+	var polls int
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, states[polls])
+		polls++
+	})
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1/rebase", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"rebase_in_progress":true}`)
+	})
+
+	_, err := client.MergeRequests.MergeWhenReady(1, 1, &MergeWhenReadyOptions{PollInterval: time.Millisecond})
+
+	var blockedErr *MergeBlockedError
+	require.ErrorAs(t, err, &blockedErr)
+	assert.Equal(t, MergeBlockedRebaseFailed, blockedErr.Reason)
+	assert.Contains(t, err.Error(), "Rebase failed: conflicts")
+}
+
+func TestMergeWhenReadyStaleMergeError(t *testing.T) {
+	mux, client := setup(t)
+
+	states := []string{
+		`{"iid":1,"state":"opened","detailed_merge_status":"need_rebase","sha":"aaa","merge_error":"Rebase failed: conflicts"}`,
+		`{"iid":1,"state":"opened","detailed_merge_status":"need_rebase","sha":"aaa","merge_error":"Rebase failed: conflicts","rebase_in_progress":true}`,
+		`{"iid":1,"state":"opened","detailed_merge_status":"mergeable","sha":"bbb","merge_error":"Rebase failed: conflicts"}`,
+	}
+	var polls int
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, states[polls])
+		polls++
+	})
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1/rebase", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"rebase_in_progress":true}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"iid":1,"state":"merged"}`)
+	})
+
+	mr, err := client.MergeRequests.MergeWhenReady(1, 1, &MergeWhenReadyOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, "merged", mr.State)
+	assert.Equal(t, 3, polls)
+}
+
+func TestMergeWhenReadyMergeRejected(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"iid":1,"state":"opened","detailed_merge_status":"mergeable","sha":"aaa"}`)
+	})
+	var merges int
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		merges++
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprint(w, `{"message":"405 Method Not Allowed"}`)
+	})
+
+	_, err := client.MergeRequests.MergeWhenReady(1, 1, &MergeWhenReadyOptions{PollInterval: time.Millisecond})
+
+	var errResp *ErrorResponse
+	require.ErrorAs(t, err, &errResp)
+	assert.Equal(t, http.StatusMethodNotAllowed, errResp.Response.StatusCode)
+	assert.Equal(t, defaultMergeAttempts, merges)
+}
+
+func TestMergeWhenReadyCanceled(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/merge_requests/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"iid":1,"state":"opened","detailed_merge_status":"checking"}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.MergeRequests.MergeWhenReady(1, 1, &MergeWhenReadyOptions{PollInterval: time.Hour}, WithContext(ctx))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,177 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// OAuthEndpoint returns the OAuth 2.0 endpoint of the GitLab instance with
+// the given base URL, for example "https://gitlab.example.com".
+//
+// GitLab API docs: https://docs.gitlab.com/ee/api/oauth2.html
+func OAuthEndpoint(baseURL string) oauth2.Endpoint {
+	baseURL = strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/"+strings.TrimSuffix(apiVersionPath, "/"))
+	return oauth2.Endpoint{
+		AuthURL:   baseURL + "/oauth/authorize",
+		TokenURL:  baseURL + "/oauth/token",
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+}
+
+// OAuthRefreshTokenSourceOptions represents the available
+// NewOAuthRefreshTokenSource() options.
+type OAuthRefreshTokenSourceOptions struct {
+	// OnRotate is called with every new token, and can be used to persist
+	// the rotated refresh token.
+	OnRotate func(token *oauth2.Token) error
+
+	// OnRotateError is called when OnRotate returns an error. The new token
+	// is returned regardless, because GitLab already revoked the previous
+	// refresh token.
+	OnRotateError func(token *oauth2.Token, err error)
+}
+
+// NewOAuthRefreshTokenSource returns a token source that uses the refresh
+// token of the given token to request a new access token every time Token
+// is called. The first call returns the given token instead, as long as its
+// access token is valid. GitLab rotates the refresh token on every refresh,
+// so the OnRotate option can be used to persist the new token. The returned
+// token source is safe for concurrent use.
+//
+// The returned token source does not cache tokens, it is meant to be used
+// with NewOAuthTokenSourceClient which caches the token until it expires or
+// is rejected by GitLab.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/oauth2.html#authorization-code-flow
+func NewOAuthRefreshTokenSource(ctx context.Context, config *oauth2.Config, token *oauth2.Token, opt *OAuthRefreshTokenSourceOptions) oauth2.TokenSource {
+	if opt == nil {
+		opt = &OAuthRefreshTokenSourceOptions{}
+	}
+	return &refreshTokenSource{
+		ctx:          ctx,
+		config:       config,
+		opt:          opt,
+		initial:      token,
+		refreshToken: token.RefreshToken,
+	}
+}
+
+type refreshTokenSource struct {
+	ctx    context.Context
+	config *oauth2.Config
+	opt    *OAuthRefreshTokenSourceOptions
+
+	mu           sync.Mutex
+	initial      *oauth2.Token
+	refreshToken string
+}
+
+func (s *refreshTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only the first call can return the initial token, later calls are made
+	// because it expired or was rejected.
+	if initial := s.initial; initial != nil {
+		s.initial = nil
+		if initial.Valid() {
+			return initial, nil
+		}
+	}
+
+	if s.refreshToken == "" {
+		return nil, errors.New("oauth2: token expired and refresh token is not set")
+	}
+
+	// Passing a token without an access token forces a refresh.
+	token, err := s.config.TokenSource(s.ctx, &oauth2.Token{RefreshToken: s.refreshToken}).Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token.RefreshToken != "" && token.RefreshToken != s.refreshToken {
+		s.refreshToken = token.RefreshToken
+		if s.opt.OnRotate != nil {
+			if err := s.opt.OnRotate(token); err != nil && s.opt.OnRotateError != nil {
+				s.opt.OnRotateError(token, err)
+			}
+		}
+	}
+
+	return token, nil
+}
+
+// NewOAuthClientCredentialsTokenSource returns a token source that requests a
+// new access token using the client credentials flow every time Token is
+// called. Like NewOAuthRefreshTokenSource it does not cache tokens.
+func NewOAuthClientCredentialsTokenSource(ctx context.Context, config *clientcredentials.Config) oauth2.TokenSource {
+	return tokenSourceFunc(func() (*oauth2.Token, error) {
+		return config.Token(ctx)
+	})
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+// cachingTokenSource caches the token returned by the underlying token
+// source until it expires, or until it is invalidated because GitLab
+// rejected it.
+type cachingTokenSource struct {
+	src oauth2.TokenSource
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func (s *cachingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+
+	return token, nil
+}
+
+// invalidate removes the given token from the cache, unless the cached token
+// was already replaced by another request.
+func (s *cachingTokenSource) invalidate(token *oauth2.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = nil
+	}
+}
@@ -0,0 +1,205 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+func TestOAuthEndpoint(t *testing.T) {
+	want := oauth2.Endpoint{
+		AuthURL:   "https://gitlab.example.com/oauth/authorize",
+		TokenURL:  "https://gitlab.example.com/oauth/token",
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+	assert.Equal(t, want, OAuthEndpoint("https://gitlab.example.com"))
+	assert.Equal(t, want, OAuthEndpoint("https://gitlab.example.com/"))
+	assert.Equal(t, want, OAuthEndpoint("https://gitlab.example.com/api/v4/"))
+}
+
+func TestOAuthTokenSourceClientRefreshesToken(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var mu sync.Mutex
+	refreshes := 0
+	validToken := ""
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Equal(t, fmt.Sprintf("refresh-%d", refreshes), r.Form.Get("refresh_token"))
+		refreshes++
+		validToken = fmt.Sprintf("access-%d", refreshes)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":%q,"refresh_token":"refresh-%d","token_type":"Bearer","expires_in":7200}`, validToken, refreshes)
+	})
+
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"401 Unauthorized"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	var rotated []string
+	config := &oauth2.Config{ClientID: "id", ClientSecret: "secret", Endpoint: OAuthEndpoint(server.URL)}
+	ts := NewOAuthRefreshTokenSource(context.Background(), config, &oauth2.Token{RefreshToken: "refresh-0"}, &OAuthRefreshTokenSourceOptions{
+		OnRotate: func(token *oauth2.Token) error {
+			rotated = append(rotated, token.RefreshToken)
+			return nil
+		},
+	})
+
+	client, err := NewOAuthTokenSourceClient(ts, WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	user, _, err := client.Users.CurrentUser()
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.ID)
+
+	// The cached token is used for subsequent requests.
+	_, _, err = client.Users.CurrentUser()
+	require.NoError(t, err)
+	assert.Equal(t, 1, refreshes)
+
+	// Revoke the token, so the client needs to refresh it.
+	mu.Lock()
+	validToken = "revoked"
+	mu.Unlock()
+
+	_, _, err = client.Users.CurrentUser()
+	require.NoError(t, err)
+	assert.Equal(t, 2, refreshes)
+	assert.Equal(t, []string{"refresh-1", "refresh-2"}, rotated)
+}
+
+func TestOAuthRefreshTokenSource(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	refreshes := 0
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		refreshes++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"access-%d","refresh_token":"refresh-%d","token_type":"Bearer","expires_in":7200}`, refreshes, refreshes)
+	})
+
+	var rotateErrs []error
+	config := &oauth2.Config{ClientID: "id", ClientSecret: "secret", Endpoint: OAuthEndpoint(server.URL)}
+	ts := NewOAuthRefreshTokenSource(context.Background(), config, &oauth2.Token{
+		AccessToken:  "access-0",
+		RefreshToken: "refresh-0",
+		Expiry:       time.Now().Add(time.Hour),
+	}, &OAuthRefreshTokenSourceOptions{
+		OnRotate: func(token *oauth2.Token) error {
+			return errors.New("disk full")
+		},
+		OnRotateError: func(token *oauth2.Token, err error) {
+			assert.Equal(t, "refresh-1", token.RefreshToken)
+			rotateErrs = append(rotateErrs, err)
+		},
+	})
+
+	// The initial token is used while it is valid.
+	token, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-0", token.AccessToken)
+	assert.Equal(t, 0, refreshes)
+
+	// The new token is returned, even though persisting it failed.
+	token, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, 1, refreshes)
+	assert.Equal(t, []error{errors.New("disk full")}, rotateErrs)
+}
+
+func TestOAuthTokenSourceClientGivesUpOnSameToken(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	requests := 0
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "static"})
+	client, err := NewOAuthTokenSourceClient(ts, WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, resp, err := client.Users.CurrentUser()
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+}
+
+func TestOAuthClientCredentialsTokenSource(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"access","token_type":"Bearer","expires_in":7200}`)
+	})
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	ts := NewOAuthClientCredentialsTokenSource(context.Background(), &clientcredentials.Config{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		TokenURL:     OAuthEndpoint(server.URL).TokenURL,
+	})
+
+	client, err := NewOAuthTokenSourceClient(ts, WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	_, _, err = client.Users.CurrentUser()
+	require.NoError(t, err)
+}
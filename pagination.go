@@ -0,0 +1,292 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"sync"
+)
+
+// ListFunc represents a single call to one of the paginated List methods. The
+// given request options must be passed on to the List method, as they are
+// used to request the correct page.
+//
+// Example:
+//
+//	f := func(options ...gitlab.RequestOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+//		return git.Projects.ListProjects(opt, options...)
+//	}
+type ListFunc[T any] func(options ...RequestOptionFunc) ([]T, *Response, error)
+
+// PaginationOptionFunc can be used to customize the behavior of Scan, All and
+// AllConcurrent.
+type PaginationOptionFunc func(*paginationOptions)
+
+type paginationOptions struct {
+	maxItems    int
+	concurrency int
+}
+
+// defaultConcurrency is the default number of pages AllConcurrent requests
+// concurrently.
+const defaultConcurrency = 4
+
+// WithMaxItems limits the number of items returned by Scan, All and
+// AllConcurrent. A value of zero or less means no limit.
+func WithMaxItems(maxItems int) PaginationOptionFunc {
+	return func(o *paginationOptions) {
+		o.maxItems = maxItems
+	}
+}
+
+// WithConcurrency sets the maximum number of pages AllConcurrent requests
+// concurrently. Defaults to 4.
+func WithConcurrency(concurrency int) PaginationOptionFunc {
+	return func(o *paginationOptions) {
+		o.concurrency = concurrency
+	}
+}
+
+func newPaginationOptions(options []PaginationOptionFunc) *paginationOptions {
+	o := &paginationOptions{concurrency: defaultConcurrency}
+	for _, fn := range options {
+		if fn != nil {
+			fn(o)
+		}
+	}
+	return o
+}
+
+// nextPageOptions returns the request options needed to request the page
+// following the given response, or nil if there are no more pages. Keyset
+// pagination (using the Link header) takes precedence over offset pagination
+// (using the X-Next-Page header).
+func nextPageOptions(resp *Response) []RequestOptionFunc {
+	switch {
+	case resp == nil:
+		return nil
+	case resp.NextLink != "":
+		return []RequestOptionFunc{WithKeysetPaginationParameters(resp.NextLink)}
+	case resp.NextPage != 0:
+		return []RequestOptionFunc{WithOffsetPaginationParameters(resp.NextPage)}
+	default:
+		return nil
+	}
+}
+
+// Scan returns an iterator over all items returned by the given ListFunc,
+// transparently requesting the next page (using either offset-based or
+// keyset-based pagination) when needed. The iterator stops when all pages
+// are consumed, when the context is canceled or when an error occurs, in
+// which case the error is yielded as the last value.
+//
+// The returned function has the same signature as iter.Seq2[T, error], so
+// with Go 1.23 or later it can be used directly in a range statement:
+//
+//	for p, err := range gitlab.Scan(ctx, f) {
+//		if err != nil {
+//			return err
+//		}
+//		log.Printf("Found project: %s", p.Name)
+//	}
+func Scan[T any](ctx context.Context, f ListFunc[T], options ...PaginationOptionFunc) func(yield func(T, error) bool) {
+	o := newPaginationOptions(options)
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		var count int
+
+		pageOptions := []RequestOptionFunc{WithContext(ctx)}
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, resp, err := f(pageOptions...)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				count++
+				if o.maxItems > 0 && count >= o.maxItems {
+					return
+				}
+			}
+
+			next := nextPageOptions(resp)
+			if next == nil {
+				return
+			}
+			pageOptions = append([]RequestOptionFunc{WithContext(ctx)}, next...)
+		}
+	}
+}
+
+// All collects all items returned by the given ListFunc by requesting all
+// available pages. See Scan for more details.
+func All[T any](ctx context.Context, f ListFunc[T], options ...PaginationOptionFunc) ([]T, error) {
+	var all []T
+	var err error
+
+	Scan(ctx, f, options...)(func(item T, e error) bool {
+		if e != nil {
+			err = e
+			return false
+		}
+		all = append(all, item)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// AllConcurrent collects all items returned by the given ListFunc just like
+// All does, but after requesting the first page it requests the remaining
+// pages concurrently. The items are returned in page order.
+//
+// Pages can only be requested concurrently when using offset-based pagination
+// and when GitLab returns the X-Total-Pages header, which it omits for large
+// collections. In all other cases the remaining pages are requested serially.
+//
+// All requests are still subject to the rate limiter of the client, so the
+// concurrency (see WithConcurrency) only limits the number of requests that
+// are in flight at any given time.
+func AllConcurrent[T any](ctx context.Context, f ListFunc[T], options ...PaginationOptionFunc) ([]T, error) {
+	o := newPaginationOptions(options)
+
+	items, resp, err := f(WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil || resp.NextLink != "" || resp.NextPage == 0 || resp.TotalPages == 0 ||
+		o.concurrency < 2 || (o.maxItems > 0 && len(items) >= o.maxItems) {
+		return collectRemaining(ctx, f, o, items, resp)
+	}
+
+	first, last := resp.NextPage, resp.TotalPages
+	if o.maxItems > 0 && resp.ItemsPerPage > 0 {
+		// Don't request more pages than needed to get the requested number of items.
+		needed := first - 1 + (o.maxItems-len(items)+resp.ItemsPerPage-1)/resp.ItemsPerPage
+		if needed < last {
+			last = needed
+		}
+	}
+	if first > last {
+		return truncate(items, o.maxItems), nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan int)
+	results := make([][]T, last-first+1)
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+
+	workers := o.concurrency
+	if workers > len(results) {
+		workers = len(results)
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				pageItems, _, err := f(WithContext(ctx), WithOffsetPaginationParameters(page))
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				results[page-first] = pageItems
+			}
+		}()
+	}
+
+sendPages:
+	for page := first; page <= last; page++ {
+		select {
+		case pages <- page:
+		case <-ctx.Done():
+			break sendPages
+		}
+	}
+	close(pages)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, pageItems := range results {
+		items = append(items, pageItems...)
+	}
+
+	return truncate(items, o.maxItems), nil
+}
+
+// collectRemaining serially requests all pages following the given response
+// and appends their items to the given items.
+func collectRemaining[T any](ctx context.Context, f ListFunc[T], o *paginationOptions, items []T, resp *Response) ([]T, error) {
+	for {
+		if o.maxItems > 0 && len(items) >= o.maxItems {
+			return truncate(items, o.maxItems), nil
+		}
+
+		next := nextPageOptions(resp)
+		if next == nil {
+			return items, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var pageItems []T
+		var err error
+
+		pageItems, resp, err = f(append([]RequestOptionFunc{WithContext(ctx)}, next...)...)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+	}
+}
+
+func truncate[T any](items []T, maxItems int) []T {
+	if maxItems > 0 && len(items) > maxItems {
+		return items[:maxItems]
+	}
+	return items
+}
@@ -0,0 +1,273 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllOffsetPagination(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+		case "2":
+			w.Header().Set("X-Next-Page", "3")
+			fmt.Fprint(w, `[{"id":3},{"id":4}]`)
+		case "3":
+			fmt.Fprint(w, `[{"id":5}]`)
+		default:
+			t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+
+	opt := &ListProjectsOptions{ListOptions: ListOptions{PerPage: 2}}
+	projects, err := All(context.Background(), func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(opt, options...)
+	})
+	require.NoError(t, err)
+
+	var ids []int
+	for _, p := range projects {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+}
+
+func TestAllKeysetPagination(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, "keyset", r.URL.Query().Get("pagination"))
+		switch r.URL.Query().Get("id_after") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(
+				`<%s://%s/api/v4/projects?id_after=2&order_by=id&pagination=keyset&per_page=2&sort=asc>; rel="next"`,
+				"http", r.Host,
+			))
+			fmt.Fprint(w, `[{"id":1},{"id":2}]`)
+		case "2":
+			fmt.Fprint(w, `[{"id":3}]`)
+		default:
+			t.Fatalf("unexpected id_after %q", r.URL.Query().Get("id_after"))
+		}
+	})
+
+	opt := &ListProjectsOptions{
+		ListOptions: ListOptions{
+			OrderBy:    "id",
+			Pagination: "keyset",
+			PerPage:    2,
+			Sort:       "asc",
+		},
+	}
+	projects, err := All(context.Background(), func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(opt, options...)
+	})
+	require.NoError(t, err)
+	assert.Len(t, projects, 3)
+	assert.Equal(t, 3, projects[2].ID)
+}
+
+func TestScanMaxItems(t *testing.T) {
+	mux, client := setup(t)
+
+	requests := 0
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-Next-Page", fmt.Sprint(requests+1))
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, requests*2-1, requests*2)
+	})
+
+	var ids []int
+	Scan(context.Background(), func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(nil, options...)
+	}, WithMaxItems(3))(func(p *Project, err error) bool {
+		require.NoError(t, err)
+		ids = append(ids, p.ID)
+		return true
+	})
+
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.Equal(t, 2, requests)
+}
+
+func TestScanContextCanceled(t *testing.T) {
+	mux, client := setup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Next-Page", "2")
+		fmt.Fprint(w, `[{"id":1}]`)
+	})
+
+	var ids []int
+	var errs []error
+	Scan(ctx, func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(nil, options...)
+	})(func(p *Project, err error) bool {
+		if err != nil {
+			errs = append(errs, err)
+			return false
+		}
+		ids = append(ids, p.ID)
+		cancel()
+		return true
+	})
+
+	assert.Equal(t, []int{1}, ids)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+}
+
+func TestAllConcurrent(t *testing.T) {
+	mux, client := setup(t)
+
+	var mu sync.Mutex
+	requested := map[string]int{}
+
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		page := r.URL.Query().Get("page")
+
+		mu.Lock()
+		requested[page]++
+		mu.Unlock()
+
+		p, _ := strconv.Atoi(page)
+		if p == 0 {
+			p = 1
+		}
+		w.Header().Set("X-Page", strconv.Itoa(p))
+		w.Header().Set("X-Per-Page", "2")
+		w.Header().Set("X-Total-Pages", "5")
+		if p < 5 {
+			w.Header().Set("X-Next-Page", strconv.Itoa(p+1))
+		}
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, p*2-1, p*2)
+	})
+
+	projects, err := AllConcurrent(context.Background(), func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(nil, options...)
+	}, WithConcurrency(3))
+	require.NoError(t, err)
+
+	var ids []int
+	for _, p := range projects {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ids)
+	assert.Equal(t, map[string]int{"": 1, "2": 1, "3": 1, "4": 1, "5": 1}, requested)
+}
+
+func TestAllConcurrentMaxItems(t *testing.T) {
+	mux, client := setup(t)
+
+	var mu sync.Mutex
+	requested := map[string]int{}
+
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+
+		mu.Lock()
+		requested[page]++
+		mu.Unlock()
+
+		p, _ := strconv.Atoi(page)
+		if p == 0 {
+			p = 1
+		}
+		w.Header().Set("X-Page", strconv.Itoa(p))
+		w.Header().Set("X-Per-Page", "2")
+		w.Header().Set("X-Total-Pages", "10")
+		w.Header().Set("X-Next-Page", strconv.Itoa(p+1))
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, p*2-1, p*2)
+	})
+
+	projects, err := AllConcurrent(context.Background(), func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(nil, options...)
+	}, WithMaxItems(5))
+	require.NoError(t, err)
+	assert.Len(t, projects, 5)
+	assert.Equal(t, 5, projects[4].ID)
+	assert.Equal(t, map[string]int{"": 1, "2": 1, "3": 1}, requested)
+}
+
+func TestAllConcurrentWithoutTotalPages(t *testing.T) {
+	mux, client := setup(t)
+
+	var pages []string
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		switch page {
+		case "":
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":1}]`)
+		case "2":
+			w.Header().Set("X-Next-Page", "3")
+			fmt.Fprint(w, `[{"id":2}]`)
+		case "3":
+			fmt.Fprint(w, `[{"id":3}]`)
+		}
+	})
+
+	projects, err := AllConcurrent(context.Background(), func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(nil, options...)
+	})
+	require.NoError(t, err)
+	assert.Len(t, projects, 3)
+	assert.Equal(t, []string{"", "2", "3"}, pages)
+}
+
+func TestAllConcurrentError(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "3" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"bad page"}`)
+			return
+		}
+		w.Header().Set("X-Total-Pages", "4")
+		w.Header().Set("X-Next-Page", "2")
+		fmt.Fprint(w, `[{"id":1}]`)
+	})
+
+	_, err := AllConcurrent(context.Background(), func(options ...RequestOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(nil, options...)
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad page")
+}
@@ -0,0 +1,584 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GetPipelineGraphOptions represents the available GetPipelineGraph()
+// options.
+type GetPipelineGraphOptions struct {
+	// Needs returns the names of the jobs the given job of the pipeline
+	// needs, and whether the job uses needs at all. If set, it is used
+	// instead of fetching the needs of the jobs from GitLab, and the needs of
+	// jobs for which it returns false are approximated.
+	Needs func(pipeline *Pipeline, job string) ([]string, bool)
+}
+
+// PipelineGraph represents the jobs of a pipeline and its downstream
+// pipelines, and the dependencies between them.
+type PipelineGraph struct {
+	// Pipelines contains the pipeline and its downstream pipelines, in the
+	// order they were reached.
+	Pipelines []*PipelineGraphPipeline
+
+	// Nodes contains the jobs and bridges of all pipelines.
+	Nodes []*PipelineGraphNode
+
+	// NeedsApproximated reports whether the needs of any node were
+	// approximated from the stages of its pipeline.
+	NeedsApproximated bool
+}
+
+// PipelineGraphPipeline represents a single pipeline in a PipelineGraph.
+type PipelineGraphPipeline struct {
+	Pipeline *Pipeline
+	Stages   []string
+	Nodes    []*PipelineGraphNode
+
+	// Trigger is the bridge that triggered the pipeline, or nil for the
+	// pipeline the graph was requested for.
+	Trigger *PipelineGraphNode
+}
+
+// PipelineGraphNode represents a job or bridge in a PipelineGraph. Exactly
+// one of Job and Bridge is set.
+type PipelineGraphNode struct {
+	// ID uniquely identifies the node in the graph.
+	ID string
+
+	ProjectID  int
+	PipelineID int
+	Name       string
+	Stage      string
+	Status     string
+	Job        *Job
+	Bridge     *Bridge
+
+	// Needs contains the nodes this node depends on. The first jobs of a
+	// downstream pipeline depend on the bridge that triggered it.
+	Needs []*PipelineGraphNode
+
+	// NeedsApproximated reports whether the needs of the node are unknown,
+	// so it depends on all jobs of the previous stage instead.
+	NeedsApproximated bool
+
+	// QueuedDuration is the time the job waited for a runner, and Duration
+	// the time it ran.
+	QueuedDuration time.Duration
+	Duration       time.Duration
+}
+
+// PipelineGraphPath represents a path through a PipelineGraph.
+type PipelineGraphPath struct {
+	Nodes          []*PipelineGraphNode
+	QueuedDuration time.Duration
+	Duration       time.Duration
+}
+
+// Total returns the total time spent queued and running along the path.
+func (p *PipelineGraphPath) Total() time.Duration {
+	return p.QueuedDuration + p.Duration
+}
+
+// GetPipelineGraph assembles the graph of the jobs and bridges of a pipeline,
+// including the downstream pipelines triggered by its bridges.
+//
+// The jobs API doesn't expose the needs of jobs, so they are fetched using
+// the GraphQL API. Jobs without needs depend on the jobs of the previous
+// stage. If the GraphQL API doesn't return the needs of a job, its needs are
+// approximated by the jobs of the previous stage, and the node is marked with
+// NeedsApproximated.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/jobs.html#list-pipeline-jobs
+// https://docs.gitlab.com/ee/api/graphql/reference/#cijob
+func (s *PipelinesService) GetPipelineGraph(pid interface{}, pipeline int, opt *GetPipelineGraphOptions, options ...RequestOptionFunc) (*PipelineGraph, *Response, error) {
+	if opt == nil {
+		opt = &GetPipelineGraphOptions{}
+	}
+
+	g := &PipelineGraph{}
+	resp, err := s.addPipelineGraph(g, pid, pipeline, nil, opt, options, make(map[[2]int]bool))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return g, resp, nil
+}
+
+// addPipelineGraph adds the pipeline and its downstream pipelines to the
+// graph.
+func (s *PipelinesService) addPipelineGraph(g *PipelineGraph, pid interface{}, pipeline int, trigger *PipelineGraphNode, opt *GetPipelineGraphOptions, options []RequestOptionFunc, seen map[[2]int]bool) (*Response, error) {
+	p, resp, err := s.GetPipeline(pid, pipeline, options...)
+	if err != nil {
+		return resp, err
+	}
+	ctx := resp.Request.Context()
+
+	lopt := &ListJobsOptions{ListOptions: ListOptions{PerPage: 100}}
+	jobs, err := All(ctx, func(pageOptions ...RequestOptionFunc) ([]*Job, *Response, error) {
+		return s.client.Jobs.ListPipelineJobs(pid, pipeline, lopt, append(pageOptions, options...)...)
+	})
+	if err != nil {
+		return resp, err
+	}
+	bridges, err := All(ctx, func(pageOptions ...RequestOptionFunc) ([]*Bridge, *Response, error) {
+		return s.client.Jobs.ListPipelineBridges(pid, pipeline, lopt, append(pageOptions, options...)...)
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	var needs map[string]*pipelineJobNeeds
+	if opt.Needs == nil {
+		needs, resp, err = s.pipelineNeeds(p, options)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	gp := &PipelineGraphPipeline{Pipeline: p, Trigger: trigger}
+	g.Pipelines = append(g.Pipelines, gp)
+	seen[[2]int{p.ProjectID, p.ID}] = true
+
+	ids := make(map[*PipelineGraphNode]int)
+	for _, job := range jobs {
+		node := &PipelineGraphNode{
+			Name:           job.Name,
+			Stage:          job.Stage,
+			Status:         job.Status,
+			Job:            job,
+			QueuedDuration: secondsToDuration(job.QueuedDuration),
+			Duration:       secondsToDuration(job.Duration),
+		}
+		gp.Nodes = append(gp.Nodes, node)
+		ids[node] = job.ID
+	}
+	for _, bridge := range bridges {
+		node := &PipelineGraphNode{
+			Name:           bridge.Name,
+			Stage:          bridge.Stage,
+			Status:         bridge.Status,
+			Bridge:         bridge,
+			QueuedDuration: secondsToDuration(bridge.QueuedDuration),
+			Duration:       secondsToDuration(bridge.Duration),
+		}
+		gp.Nodes = append(gp.Nodes, node)
+		ids[node] = bridge.ID
+	}
+
+	// Jobs are created in stage order, so the order of the stages follows
+	// from the job IDs.
+	sort.Slice(gp.Nodes, func(i, j int) bool { return ids[gp.Nodes[i]] < ids[gp.Nodes[j]] })
+
+	byName := make(map[string]*PipelineGraphNode)
+	byStage := make(map[string][]*PipelineGraphNode)
+	for _, node := range gp.Nodes {
+		node.ID = fmt.Sprintf("p%d_j%d", p.ID, ids[node])
+		node.ProjectID = p.ProjectID
+		node.PipelineID = p.ID
+
+		if _, ok := byStage[node.Stage]; !ok {
+			gp.Stages = append(gp.Stages, node.Stage)
+		}
+		byStage[node.Stage] = append(byStage[node.Stage], node)
+		byName[node.Name] = node
+	}
+
+	for i, stage := range gp.Stages {
+		for _, node := range byStage[stage] {
+			jn := needs[node.Name]
+			if opt.Needs != nil {
+				if names, ok := opt.Needs(p, node.Name); ok {
+					jn = &pipelineJobNeeds{dag: true, names: names}
+				}
+			}
+
+			switch {
+			case jn == nil:
+				node.NeedsApproximated = true
+				g.NeedsApproximated = true
+			case jn.dag:
+				for _, name := range jn.names {
+					if need, ok := byName[name]; ok {
+						node.Needs = append(node.Needs, need)
+					}
+				}
+				continue
+			}
+			if i > 0 {
+				node.Needs = append(node.Needs, byStage[gp.Stages[i-1]]...)
+			}
+		}
+	}
+
+	if trigger != nil {
+		for _, node := range gp.Nodes {
+			if len(node.Needs) == 0 {
+				node.Needs = []*PipelineGraphNode{trigger}
+			}
+		}
+	}
+
+	g.Nodes = append(g.Nodes, gp.Nodes...)
+
+	for _, node := range gp.Nodes {
+		if node.Bridge == nil || node.Bridge.DownstreamPipeline == nil {
+			continue
+		}
+		downstream := node.Bridge.DownstreamPipeline
+		if seen[[2]int{downstream.ProjectID, downstream.ID}] {
+			continue
+		}
+
+		resp, err := s.addPipelineGraph(g, downstream.ProjectID, downstream.ID, node, opt, options, seen)
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// pipelineNeedsQuery queries the needs of the jobs of a pipeline.
+const pipelineNeedsQuery = `query($ids: [ID!], $iid: ID!, $after: String) {
+  projects(ids: $ids) {
+    nodes {
+      pipeline(iid: $iid) {
+        jobs(first: 100, after: $after, retried: false) {
+          pageInfo { hasNextPage endCursor }
+          nodes {
+            name
+            schedulingType
+            needs { nodes { name } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// pipelineJobNeeds represents the needs of a job. Jobs that don't use needs
+// depend on the jobs of the previous stage.
+type pipelineJobNeeds struct {
+	dag   bool
+	names []string
+}
+
+// pipelineNeedsResult is the result of pipelineNeedsQuery.
+type pipelineNeedsResult struct {
+	Data struct {
+		Projects struct {
+			Nodes []struct {
+				Pipeline *struct {
+					Jobs struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []struct {
+							Name           string `json:"name"`
+							SchedulingType string `json:"schedulingType"`
+							Needs          struct {
+								Nodes []struct {
+									Name string `json:"name"`
+								} `json:"nodes"`
+							} `json:"needs"`
+						} `json:"nodes"`
+					} `json:"jobs"`
+				} `json:"pipeline"`
+			} `json:"nodes"`
+		} `json:"projects"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// pipelineNeeds returns the needs of the jobs of the pipeline keyed by job
+// name, using the GraphQL API. It returns nil without error if GitLab doesn't
+// return the needs, for example when the GraphQL API is disabled.
+func (s *PipelinesService) pipelineNeeds(p *Pipeline, options []RequestOptionFunc) (map[string]*pipelineJobNeeds, *Response, error) {
+	needs := make(map[string]*pipelineJobNeeds)
+	variables := map[string]interface{}{
+		"ids": []string{fmt.Sprintf("gid://gitlab/Project/%d", p.ProjectID)},
+		"iid": fmt.Sprint(p.IID),
+	}
+
+	var resp *Response
+	for {
+		body := map[string]interface{}{
+			"query":     pipelineNeedsQuery,
+			"variables": variables,
+		}
+		req, err := s.client.NewRequest(http.MethodPost, "", body, options)
+		if err != nil {
+			return nil, nil, err
+		}
+		// The GraphQL API lives next to the REST API, at /api/graphql.
+		req.URL = req.URL.ResolveReference(&url.URL{Path: "../graphql"})
+
+		var result pipelineNeedsResult
+		resp, err = s.client.Do(req, &result)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return nil, resp, nil
+			}
+			return nil, resp, err
+		}
+
+		projects := result.Data.Projects.Nodes
+		if len(result.Errors) > 0 || len(projects) == 0 || projects[0].Pipeline == nil {
+			return nil, resp, nil
+		}
+
+		jobs := projects[0].Pipeline.Jobs
+		for _, job := range jobs.Nodes {
+			jn := &pipelineJobNeeds{dag: job.SchedulingType == "dag"}
+			for _, need := range job.Needs.Nodes {
+				jn.names = append(jn.names, need.Name)
+			}
+			needs[job.Name] = jn
+		}
+
+		if !jobs.PageInfo.HasNextPage {
+			return needs, resp, nil
+		}
+		variables["after"] = jobs.PageInfo.EndCursor
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// CriticalPath returns the chain of dependent jobs that takes the longest
+// time to queue and run, which determines the minimum duration of the
+// pipeline.
+func (g *PipelineGraph) CriticalPath() *PipelineGraphPath {
+	costs := make(map[*PipelineGraphNode]time.Duration)
+	next := make(map[*PipelineGraphNode]*PipelineGraphNode)
+
+	var cost func(n *PipelineGraphNode) time.Duration
+	cost = func(n *PipelineGraphNode) time.Duration {
+		if c, ok := costs[n]; ok {
+			return c
+		}
+		// Guard against cycles in user provided needs.
+		costs[n] = 0
+
+		var longest time.Duration
+		for _, need := range n.Needs {
+			if c := cost(need); next[n] == nil || c > longest {
+				longest = c
+				next[n] = need
+			}
+		}
+
+		costs[n] = longest + n.QueuedDuration + n.Duration
+		return costs[n]
+	}
+
+	var last *PipelineGraphNode
+	for _, n := range g.Nodes {
+		if last == nil || cost(n) > cost(last) {
+			last = n
+		}
+	}
+
+	path := &PipelineGraphPath{}
+	for n := last; n != nil; n = next[n] {
+		path.Nodes = append([]*PipelineGraphNode{n}, path.Nodes...)
+		path.QueuedDuration += n.QueuedDuration
+		path.Duration += n.Duration
+	}
+
+	return path
+}
+
+// QueuedDuration returns the total time the jobs of the graph waited for a
+// runner.
+func (g *PipelineGraph) QueuedDuration() time.Duration {
+	var d time.Duration
+	for _, n := range g.Nodes {
+		d += n.QueuedDuration
+	}
+	return d
+}
+
+// Duration returns the total time the jobs of the graph ran.
+func (g *PipelineGraph) Duration() time.Duration {
+	var d time.Duration
+	for _, n := range g.Nodes {
+		d += n.Duration
+	}
+	return d
+}
+
+// DOT renders the graph in the Graphviz DOT language. Every pipeline and
+// stage is rendered as a cluster, and the critical path is colored red.
+// Dependencies approximated from the stages are dashed, and the label of
+// their pipeline says so.
+func (g *PipelineGraph) DOT() string {
+	critical := g.criticalNodes()
+
+	var b strings.Builder
+	b.WriteString("digraph pipeline {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, gp := range g.Pipelines {
+		fmt.Fprintf(&b, "  subgraph cluster_p%d {\n", gp.Pipeline.ID)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(gp.label()))
+		for _, stage := range gp.Stages {
+			fmt.Fprintf(&b, "    subgraph %s {\n", dotQuote(fmt.Sprintf("cluster_p%d_%s", gp.Pipeline.ID, stage)))
+			fmt.Fprintf(&b, "      label=%s;\n", dotQuote(stage))
+			for _, n := range gp.Nodes {
+				if n.Stage != stage {
+					continue
+				}
+				attrs := ""
+				if critical[n] {
+					attrs = ", color=red"
+				}
+				fmt.Fprintf(&b, "      %s [label=%s%s];\n", n.ID, dotQuote(n.label()), attrs)
+			}
+			b.WriteString("    }\n")
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, n := range g.Nodes {
+		for _, need := range n.Needs {
+			var attrs []string
+			if critical[n] && critical[need] {
+				attrs = append(attrs, "color=red")
+			}
+			if n.approximates(need) {
+				attrs = append(attrs, "style=dashed")
+			}
+			if len(attrs) > 0 {
+				fmt.Fprintf(&b, "  %s -> %s [%s];\n", need.ID, n.ID, strings.Join(attrs, ", "))
+			} else {
+				fmt.Fprintf(&b, "  %s -> %s;\n", need.ID, n.ID)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Every pipeline and stage
+// is rendered as a subgraph, and the critical path is highlighted.
+// Dependencies approximated from the stages are dotted, and the label of
+// their pipeline says so.
+func (g *PipelineGraph) Mermaid() string {
+	critical := g.criticalNodes()
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, gp := range g.Pipelines {
+		fmt.Fprintf(&b, "  subgraph p%d[%s]\n", gp.Pipeline.ID, mermaidQuote(gp.label()))
+		for i, stage := range gp.Stages {
+			fmt.Fprintf(&b, "    subgraph p%d_s%d[%s]\n", gp.Pipeline.ID, i, mermaidQuote(stage))
+			for _, n := range gp.Nodes {
+				if n.Stage == stage {
+					fmt.Fprintf(&b, "      %s[%s]\n", n.ID, mermaidQuote(n.label()))
+				}
+			}
+			b.WriteString("    end\n")
+		}
+		b.WriteString("  end\n")
+	}
+
+	for _, n := range g.Nodes {
+		for _, need := range n.Needs {
+			arrow := "-->"
+			if n.approximates(need) {
+				arrow = "-.->"
+			}
+			fmt.Fprintf(&b, "  %s %s %s\n", need.ID, arrow, n.ID)
+		}
+	}
+
+	var ids []string
+	for _, n := range g.Nodes {
+		if critical[n] {
+			ids = append(ids, n.ID)
+		}
+	}
+	if len(ids) > 0 {
+		b.WriteString("  classDef critical stroke:#f00,stroke-width:2px\n")
+		fmt.Fprintf(&b, "  class %s critical\n", strings.Join(ids, ","))
+	}
+
+	return b.String()
+}
+
+func (g *PipelineGraph) criticalNodes() map[*PipelineGraphNode]bool {
+	critical := make(map[*PipelineGraphNode]bool)
+	for _, n := range g.CriticalPath().Nodes {
+		critical[n] = true
+	}
+	return critical
+}
+
+// label returns the text used to render the pipeline.
+func (gp *PipelineGraphPipeline) label() string {
+	label := fmt.Sprintf("Pipeline #%d", gp.Pipeline.ID)
+	for _, n := range gp.Nodes {
+		if n.NeedsApproximated {
+			return label + "\n(needs approximated from stages)"
+		}
+	}
+	return label
+}
+
+// approximates reports whether the dependency on need was approximated from
+// the stages. The dependency on the bridge that triggered a downstream
+// pipeline is always known.
+func (n *PipelineGraphNode) approximates(need *PipelineGraphNode) bool {
+	return n.NeedsApproximated && need.PipelineID == n.PipelineID
+}
+
+// label returns the text used to render the node.
+func (n *PipelineGraphNode) label() string {
+	return fmt.Sprintf("%s\n%s %s", n.Name, n.Status, n.Duration.Round(time.Second))
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+	return `"` + s + `"`
+}
@@ -0,0 +1,245 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func handlePipelineGraph(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"id":1,"iid":1,"project_id":1,"status":"success"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"id":12,"name":"test-b","stage":"test","status":"success","queued_duration":1,"duration":100},
+			{"id":11,"name":"test-a","stage":"test","status":"success","queued_duration":2,"duration":30},
+			{"id":10,"name":"build","stage":"build","status":"success","queued_duration":5,"duration":60}
+		]`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1/bridges", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":13,"name":"deploy","stage":"deploy","status":"success","downstream_pipeline":{"id":5,"project_id":2}}]`)
+	})
+
+	mux.HandleFunc("/api/v4/projects/2/pipelines/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"iid":3,"project_id":2,"status":"success"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/2/pipelines/5/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":20,"name":"rollout","stage":"deploy","status":"success","queued_duration":3,"duration":20}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/2/pipelines/5/bridges", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+}
+
+// handlePipelineNeeds serves the needs of the jobs of the pipelines using
+// GraphQL, returning the given pages of jobs per project ID. Projects without
+// pages are not found.
+func handlePipelineNeeds(t *testing.T, mux *http.ServeMux, pages map[string][]string) {
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var body struct {
+			Query     string `json:"query"`
+			Variables struct {
+				IDs   []string `json:"ids"`
+				IID   string   `json:"iid"`
+				After string   `json:"after"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Contains(t, body.Query, "schedulingType")
+
+		jobs, ok := pages[body.Variables.IDs[0]]
+		if !ok {
+			fmt.Fprint(w, `{"data":{"projects":{"nodes":[]}}}`)
+			return
+		}
+
+		page := 0
+		if body.Variables.After != "" {
+			page, _ = strconv.Atoi(body.Variables.After)
+		}
+		fmt.Fprintf(w, `{"data":{"projects":{"nodes":[{"pipeline":{"jobs":{
+			"pageInfo":{"hasNextPage":%t,"endCursor":"%d"},
+			"nodes":%s
+		}}}]}}}`, page+1 < len(jobs), page+1, jobs[page])
+	})
+}
+
+func TestGetPipelineGraph(t *testing.T) {
+	mux, client := setup(t)
+	handlePipelineGraph(t, mux)
+	handlePipelineNeeds(t, mux, map[string][]string{
+		"gid://gitlab/Project/1": {`[
+			{"name":"build","schedulingType":"stage","needs":{"nodes":[]}},
+			{"name":"test-a","schedulingType":"stage","needs":{"nodes":[]}},
+			{"name":"test-b","schedulingType":"stage","needs":{"nodes":[]}},
+			{"name":"deploy","schedulingType":"stage","needs":{"nodes":[]}}
+		]`},
+	})
+
+	g, _, err := client.Pipelines.GetPipelineGraph(1, 1, nil)
+	require.NoError(t, err)
+
+	require.Len(t, g.Pipelines, 2)
+	assert.Equal(t, []string{"build", "test", "deploy"}, g.Pipelines[0].Stages)
+	assert.Equal(t, "deploy", g.Pipelines[1].Trigger.Name)
+	require.Len(t, g.Nodes, 5)
+	assert.True(t, g.NeedsApproximated)
+
+	var path []string
+	critical := g.CriticalPath()
+	for _, n := range critical.Nodes {
+		path = append(path, n.Name)
+	}
+	assert.Equal(t, []string{"build", "test-b", "deploy", "rollout"}, path)
+	assert.Equal(t, 9*time.Second, critical.QueuedDuration)
+	assert.Equal(t, 180*time.Second, critical.Duration)
+	assert.Equal(t, 189*time.Second, critical.Total())
+
+	assert.Equal(t, 11*time.Second, g.QueuedDuration())
+	assert.Equal(t, 210*time.Second, g.Duration())
+
+	assert.Equal(t, `flowchart LR
+  subgraph p1["Pipeline #1"]
+    subgraph p1_s0["build"]
+      p1_j10["build<br>success 1m0s"]
+    end
+    subgraph p1_s1["test"]
+      p1_j11["test-a<br>success 30s"]
+      p1_j12["test-b<br>success 1m40s"]
+    end
+    subgraph p1_s2["deploy"]
+      p1_j13["deploy<br>success 0s"]
+    end
+  end
+  subgraph p5["Pipeline #5<br>(needs approximated from stages)"]
+    subgraph p5_s0["deploy"]
+      p5_j20["rollout<br>success 20s"]
+    end
+  end
+  p1_j10 --> p1_j11
+  p1_j10 --> p1_j12
+  p1_j11 --> p1_j13
+  p1_j12 --> p1_j13
+  p1_j13 --> p5_j20
+  classDef critical stroke:#f00,stroke-width:2px
+  class p1_j10,p1_j12,p1_j13,p5_j20 critical
+`, g.Mermaid())
+
+	dot := g.DOT()
+	assert.Contains(t, dot, "subgraph cluster_p1 {\n")
+	assert.Contains(t, dot, `p1_j12 [label="test-b\nsuccess 1m40s", color=red];`)
+	assert.Contains(t, dot, `p1_j11 [label="test-a\nsuccess 30s"];`)
+	assert.Contains(t, dot, `label="Pipeline #1";`)
+	assert.Contains(t, dot, `label="Pipeline #5\n(needs approximated from stages)";`)
+	assert.Contains(t, dot, "p1_j12 -> p1_j13 [color=red];\n")
+	assert.Contains(t, dot, "p1_j11 -> p1_j13;\n")
+	assert.Contains(t, dot, "p1_j13 -> p5_j20 [color=red];\n")
+}
+
+func TestGetPipelineGraphDAG(t *testing.T) {
+	mux, client := setup(t)
+	handlePipelineGraph(t, mux)
+	handlePipelineNeeds(t, mux, map[string][]string{
+		"gid://gitlab/Project/1": {
+			`[
+				{"name":"build","schedulingType":"stage","needs":{"nodes":[]}},
+				{"name":"test-a","schedulingType":"dag","needs":{"nodes":[{"name":"build"}]}}
+			]`,
+			`[
+				{"name":"test-b","schedulingType":"dag","needs":{"nodes":[]}},
+				{"name":"deploy","schedulingType":"dag","needs":{"nodes":[{"name":"test-a"}]}}
+			]`,
+		},
+		"gid://gitlab/Project/2": {`[
+			{"name":"rollout","schedulingType":"stage","needs":{"nodes":[]}}
+		]`},
+	})
+
+	g, _, err := client.Pipelines.GetPipelineGraph(1, 1, nil)
+	require.NoError(t, err)
+	assert.False(t, g.NeedsApproximated)
+
+	needs := make(map[string][]string)
+	for _, n := range g.Nodes {
+		for _, need := range n.Needs {
+			needs[n.Name] = append(needs[n.Name], need.Name)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"test-a":  {"build"},
+		"deploy":  {"test-a"},
+		"rollout": {"deploy"},
+	}, needs)
+
+	var path []string
+	for _, n := range g.CriticalPath().Nodes {
+		path = append(path, n.Name)
+	}
+	assert.Equal(t, []string{"build", "test-a", "deploy", "rollout"}, path)
+}
+
+func TestGetPipelineGraphNeeds(t *testing.T) {
+	mux, client := setup(t)
+	handlePipelineGraph(t, mux)
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unexpected GraphQL request")
+	})
+
+	g, _, err := client.Pipelines.GetPipelineGraph(1, 1, &GetPipelineGraphOptions{
+		Needs: func(pipeline *Pipeline, job string) ([]string, bool) {
+			switch job {
+			case "test-b":
+				return nil, true
+			case "deploy":
+				return []string{"test-a"}, true
+			}
+			return nil, false
+		},
+	})
+	require.NoError(t, err)
+
+	var path []string
+	for _, n := range g.CriticalPath().Nodes {
+		path = append(path, n.Name)
+	}
+	assert.Equal(t, []string{"build", "test-a", "deploy", "rollout"}, path)
+
+	approximated := make(map[string]bool)
+	for _, n := range g.Nodes {
+		approximated[n.Name] = n.NeedsApproximated
+	}
+	assert.Equal(t, map[string]bool{
+		"build":   true,
+		"test-a":  true,
+		"test-b":  false,
+		"deploy":  false,
+		"rollout": true,
+	}, approximated)
+	assert.Contains(t, g.Mermaid(), "  p1_j11 --> p1_j13\n")
+}
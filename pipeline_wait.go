@@ -0,0 +1,276 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// defaultPipelinePollInterval is the default time WaitForPipeline waits
+	// between polls after a change.
+	defaultPipelinePollInterval = 2 * time.Second
+
+	// defaultPipelineMaxPollInterval is the default maximum time
+	// WaitForPipeline waits between polls.
+	defaultPipelineMaxPollInterval = 30 * time.Second
+)
+
+// WaitForPipelineOptions represents the available WaitForPipeline() options.
+type WaitForPipelineOptions struct {
+	// PollInterval is the time to wait between polls after a change was
+	// observed. While nothing changes the interval is doubled up to
+	// MaxPollInterval. Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// MaxPollInterval is the maximum time to wait between polls. Defaults to
+	// 30 seconds.
+	MaxPollInterval time.Duration
+
+	// FollowDownstream also waits for the downstream (child and multi-project)
+	// pipelines triggered by the bridge jobs of the pipeline.
+	FollowDownstream bool
+
+	// OnJob is called for every observed state transition of a job or bridge,
+	// including the first time it is observed.
+	OnJob func(*PipelineJobEvent)
+}
+
+// PipelineJobEvent represents a state transition of a job or bridge of a
+// pipeline. Exactly one of Job and Bridge is set.
+type PipelineJobEvent struct {
+	ProjectID      int
+	PipelineID     int
+	Job            *Job
+	Bridge         *Bridge
+	PreviousStatus string
+	Status         string
+}
+
+// PipelineSummary represents the result of a finished pipeline.
+type PipelineSummary struct {
+	Pipeline *Pipeline
+	Jobs     []*Job
+	Bridges  []*Bridge
+
+	// Downstream contains the summaries of the downstream pipelines, if they
+	// were followed.
+	Downstream []*PipelineSummary
+}
+
+// Status returns the final status of the pipeline.
+func (s *PipelineSummary) Status() string {
+	return s.Pipeline.Status
+}
+
+// Duration returns the time it took to run the pipeline.
+func (s *PipelineSummary) Duration() time.Duration {
+	return time.Duration(s.Pipeline.Duration) * time.Second
+}
+
+// FailedJobs returns the failed jobs of the pipeline and its downstream
+// pipelines. Their FailureReason contains the reason they failed.
+func (s *PipelineSummary) FailedJobs() []*Job {
+	var jobs []*Job
+	for _, job := range s.Jobs {
+		if job.Status == string(Failed) {
+			jobs = append(jobs, job)
+		}
+	}
+	for _, downstream := range s.Downstream {
+		jobs = append(jobs, downstream.FailedJobs()...)
+	}
+	return jobs
+}
+
+// pipelineWatch tracks the state of a single pipeline being waited for.
+type pipelineWatch struct {
+	pid      interface{}
+	id       int
+	summary  *PipelineSummary
+	statuses map[int]string
+	done     bool
+}
+
+// WaitForPipeline waits until a pipeline reaches a terminal status (success,
+// failed, canceled, skipped or manual) and returns a summary of the pipeline
+// and its jobs. A failed pipeline is not an error; check the status of the
+// returned summary instead.
+//
+// The pipeline is polled with an adaptive interval: it is reset whenever a
+// change is observed, and doubled while nothing changes. Use the WithContext
+// request option to stop waiting.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/pipelines.html#get-a-single-pipeline
+func (s *PipelinesService) WaitForPipeline(pid interface{}, pipeline int, opt *WaitForPipelineOptions, options ...RequestOptionFunc) (*PipelineSummary, error) {
+	if opt == nil {
+		opt = &WaitForPipelineOptions{}
+	}
+
+	minInterval := opt.PollInterval
+	if minInterval <= 0 {
+		minInterval = defaultPipelinePollInterval
+	}
+	maxInterval := opt.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultPipelineMaxPollInterval
+	}
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+
+	root := &pipelineWatch{pid: pid, id: pipeline, summary: &PipelineSummary{}, statuses: make(map[int]string)}
+	watches := []*pipelineWatch{root}
+	seen := make(map[[2]int]bool)
+
+	interval := minInterval
+	for {
+		var ctx context.Context
+		changed := false
+		done := true
+
+		// Downstream pipelines discovered while polling are appended to the
+		// watches, and polled in the same iteration.
+		for i := 0; i < len(watches); i++ {
+			w := watches[i]
+			if w.done {
+				continue
+			}
+
+			reqCtx, c, err := s.pollPipeline(w, opt, options)
+			if err != nil {
+				return nil, err
+			}
+			ctx = reqCtx
+			changed = changed || c
+
+			if opt.FollowDownstream {
+				for _, bridge := range w.summary.Bridges {
+					downstream := bridge.DownstreamPipeline
+					if downstream == nil || seen[[2]int{downstream.ProjectID, downstream.ID}] {
+						continue
+					}
+					seen[[2]int{downstream.ProjectID, downstream.ID}] = true
+
+					dw := &pipelineWatch{
+						pid:      downstream.ProjectID,
+						id:       downstream.ID,
+						summary:  &PipelineSummary{},
+						statuses: make(map[int]string),
+					}
+					w.summary.Downstream = append(w.summary.Downstream, dw.summary)
+					watches = append(watches, dw)
+				}
+			}
+
+			done = done && w.done
+		}
+
+		if done {
+			return root.summary, nil
+		}
+
+		if changed {
+			interval = minInterval
+		} else if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// pollPipeline updates the state of the watched pipeline and its jobs, and
+// reports whether anything changed. It returns the context of the requests.
+func (s *PipelinesService) pollPipeline(w *pipelineWatch, opt *WaitForPipelineOptions, options []RequestOptionFunc) (context.Context, bool, error) {
+	p, resp, err := s.GetPipeline(w.pid, w.id, options...)
+	if err != nil {
+		return nil, false, err
+	}
+	ctx := resp.Request.Context()
+
+	changed := w.summary.Pipeline == nil || w.summary.Pipeline.Status != p.Status
+	w.summary.Pipeline = p
+
+	// List the jobs after getting the pipeline, so the final job states are
+	// known when the pipeline is in a terminal state.
+	terminal := isTerminalBuildState(p.Status) || p.Status == string(Manual)
+
+	jobs, err := All(ctx, func(pageOptions ...RequestOptionFunc) ([]*Job, *Response, error) {
+		lopt := &ListJobsOptions{ListOptions: ListOptions{PerPage: 100}}
+		return s.client.Jobs.ListPipelineJobs(w.pid, w.id, lopt, append(pageOptions, options...)...)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	w.summary.Jobs = jobs
+
+	for _, job := range jobs {
+		if previous := w.statuses[job.ID]; previous != job.Status {
+			w.statuses[job.ID] = job.Status
+			changed = true
+			if opt.OnJob != nil {
+				opt.OnJob(&PipelineJobEvent{
+					ProjectID:      p.ProjectID,
+					PipelineID:     p.ID,
+					Job:            job,
+					PreviousStatus: previous,
+					Status:         job.Status,
+				})
+			}
+		}
+	}
+
+	if opt.FollowDownstream {
+		bridges, err := All(ctx, func(pageOptions ...RequestOptionFunc) ([]*Bridge, *Response, error) {
+			lopt := &ListJobsOptions{ListOptions: ListOptions{PerPage: 100}}
+			return s.client.Jobs.ListPipelineBridges(w.pid, w.id, lopt, append(pageOptions, options...)...)
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		w.summary.Bridges = bridges
+
+		for _, bridge := range bridges {
+			if previous := w.statuses[bridge.ID]; previous != bridge.Status {
+				w.statuses[bridge.ID] = bridge.Status
+				changed = true
+				if opt.OnJob != nil {
+					opt.OnJob(&PipelineJobEvent{
+						ProjectID:      p.ProjectID,
+						PipelineID:     p.ID,
+						Bridge:         bridge,
+						PreviousStatus: previous,
+						Status:         bridge.Status,
+					})
+				}
+			}
+		}
+	}
+
+	w.done = terminal
+
+	return ctx, changed, nil
+}
@@ -0,0 +1,118 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForPipeline(t *testing.T) {
+	mux, client := setup(t)
+
+	var polls int
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		polls++
+		if polls < 3 {
+			fmt.Fprint(w, `{"id":1,"project_id":1,"status":"running"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"project_id":1,"status":"failed","duration":90}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		switch polls {
+		case 1:
+			fmt.Fprint(w, `[{"id":1,"name":"build","status":"running"}]`)
+		case 2:
+			fmt.Fprint(w, `[{"id":1,"name":"build","status":"success"},{"id":2,"name":"test","status":"running"}]`)
+		default:
+			fmt.Fprint(w, `[{"id":1,"name":"build","status":"success"},{"id":2,"name":"test","status":"failed","failure_reason":"script_failure"}]`)
+		}
+	})
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1/bridges", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":3,"name":"deploy","status":"success","downstream_pipeline":{"id":5,"project_id":2}}]`)
+	})
+
+	mux.HandleFunc("/api/v4/projects/2/pipelines/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":5,"project_id":2,"status":"failed"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/2/pipelines/5/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":4,"name":"rollout","status":"failed","failure_reason":"stuck_or_timeout_failure"}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/2/pipelines/5/bridges", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	var events []string
+	summary, err := client.Pipelines.WaitForPipeline(1, 1, &WaitForPipelineOptions{
+		PollInterval:     time.Millisecond,
+		FollowDownstream: true,
+		OnJob: func(e *PipelineJobEvent) {
+			var name string
+			if e.Job != nil {
+				name = e.Job.Name
+			} else {
+				name = e.Bridge.Name
+			}
+			events = append(events, fmt.Sprintf("%d/%s: %q -> %q", e.PipelineID, name, e.PreviousStatus, e.Status))
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`1/build: "" -> "running"`,
+		`1/deploy: "" -> "success"`,
+		`5/rollout: "" -> "failed"`,
+		`1/build: "running" -> "success"`,
+		`1/test: "" -> "running"`,
+		`1/test: "running" -> "failed"`,
+	}, events)
+
+	assert.Equal(t, "failed", summary.Status())
+	assert.Equal(t, 90*time.Second, summary.Duration())
+	require.Len(t, summary.Downstream, 1)
+	assert.Equal(t, "failed", summary.Downstream[0].Status())
+
+	failed := summary.FailedJobs()
+	require.Len(t, failed, 2)
+	assert.Equal(t, "script_failure", failed[0].FailureReason)
+	assert.Equal(t, "stuck_or_timeout_failure", failed[1].FailureReason)
+}
+
+func TestWaitForPipelineCanceled(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"project_id":1,"status":"running"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/pipelines/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Pipelines.WaitForPipeline(1, 1, &WaitForPipelineOptions{PollInterval: time.Hour}, WithContext(ctx))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -160,6 +160,26 @@ func (s *ProjectImportExportService) ExportDownload(pid interface{}, options ...
 	return b.Bytes(), resp, err
 }
 
+// StreamExportDownload streams the finished export to the provided io.Writer.
+// If the download is interrupted, it is resumed using a HTTP Range request.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/project_import_export.html#export-download
+func (s *ProjectImportExportService) StreamExportDownload(pid interface{}, w io.Writer, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("projects/%s/export/download", PathEscape(project))
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.stream(req, w)
+}
+
 // ImportFileOptions represents the available ImportFile() options.
 //
 // GitLab API docs:
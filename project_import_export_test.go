@@ -126,6 +126,29 @@ func TestProjectImportExportService_ExportDownload(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
 
+func TestProjectImportExportService_StreamExportDownload(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/export/download", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, "file.tar.gz")
+	})
+
+	var b bytes.Buffer
+	resp, err := client.ProjectImportExport.StreamExportDownload(1, &b)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, "file.tar.gz", b.String())
+
+	resp, err = client.ProjectImportExport.StreamExportDownload(1.01, &b)
+	require.EqualError(t, err, "invalid ID type 1.01, the ID must be an int or a string")
+	require.Nil(t, resp)
+
+	resp, err = client.ProjectImportExport.StreamExportDownload(2, &b)
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestProjectImportExportService_ImportFile(t *testing.T) {
 	mux, client := setup(t)
 
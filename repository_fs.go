@@ -0,0 +1,401 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RepositoryFS is a read-only fs.FS backed by the repository of a project at
+// a given ref. It implements fs.ReadDirFS, fs.ReadFileFS and fs.StatFS, so it
+// can be used with fs.WalkDir, template.ParseFS and the like.
+//
+// Directories are listed lazily using ListTree. File sizes are taken from the
+// file metadata, and file contents are only fetched using RawBlobContent when
+// a file is read. Both are cached by blob SHA. Use a commit SHA as ref to get
+// a consistent view of the repository; with a branch name, directories that
+// are listed at different times may come from different commits.
+//
+// Symlinks are not followed: opening a symlink reads the path it points to.
+// Submodules are reported as irregular files without content.
+//
+// Example usage:
+//
+//	fsys := client.Repositories.NewFS("group/project", "v1.0.0")
+//	tmpl, err := template.ParseFS(fsys, "templates/*.tmpl")
+type RepositoryFS struct {
+	repositories *RepositoriesService
+	pid          interface{}
+	ref          string
+	options      []RequestOptionFunc
+
+	mu    sync.Mutex
+	trees map[string][]*TreeNode
+	blobs map[string][]byte
+	sizes map[string]int64
+}
+
+// NewFS returns a RepositoryFS for the repository of the given project at the
+// given ref. The request options are used for every request.
+func (s *RepositoriesService) NewFS(pid interface{}, ref string, options ...RequestOptionFunc) *RepositoryFS {
+	return &RepositoryFS{
+		repositories: s,
+		pid:          pid,
+		ref:          ref,
+		options:      options,
+		trees:        make(map[string][]*TreeNode),
+		blobs:        make(map[string][]byte),
+		sizes:        make(map[string]int64),
+	}
+}
+
+// Open implements fs.FS.
+func (fsys *RepositoryFS) Open(name string) (fs.File, error) {
+	node, err := fsys.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.Type == "tree" {
+		entries, err := fsys.readDir("open", name, node)
+		if err != nil {
+			return nil, err
+		}
+		return &repositoryDir{info: &repositoryFileInfo{node: node}, entries: entries}, nil
+	}
+
+	return &repositoryFile{fsys: fsys, name: name, node: node}, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (fsys *RepositoryFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	node, err := fsys.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	return fsys.readDir("readdir", name, node)
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (fsys *RepositoryFS) ReadFile(name string) ([]byte, error) {
+	node, err := fsys.lookup("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	if node.Type == "tree" {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+
+	content, err := fsys.readBlob("readfile", name, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(nil), content...), nil
+}
+
+// Stat implements fs.StatFS. The size of files is taken from their metadata,
+// without fetching their content.
+func (fsys *RepositoryFS) Stat(name string) (fs.FileInfo, error) {
+	node, err := fsys.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return fsys.stat("stat", name, node)
+}
+
+func (fsys *RepositoryFS) stat(op, name string, node *TreeNode) (fs.FileInfo, error) {
+	if node.Type != "blob" {
+		return &repositoryFileInfo{node: node}, nil
+	}
+
+	fsys.mu.Lock()
+	content, ok := fsys.blobs[node.ID]
+	size, known := fsys.sizes[node.ID]
+	fsys.mu.Unlock()
+	if ok {
+		return &repositoryFileInfo{node: node, size: int64(len(content))}, nil
+	}
+	if known {
+		return &repositoryFileInfo{node: node, size: size}, nil
+	}
+
+	f, _, err := fsys.repositories.client.RepositoryFiles.GetFileMetaData(fsys.pid, name, &GetFileMetaDataOptions{
+		Ref: Ptr(fsys.ref),
+	}, fsys.options...)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			err = fs.ErrNotExist
+		}
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+
+	fsys.mu.Lock()
+	fsys.sizes[node.ID] = int64(f.Size)
+	fsys.mu.Unlock()
+
+	return &repositoryFileInfo{node: node, size: int64(f.Size)}, nil
+}
+
+// lookup returns the tree node of the given path, listing its parent
+// directories as needed.
+func (fsys *RepositoryFS) lookup(op, name string) (*TreeNode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return &TreeNode{Name: ".", Type: "tree", Path: ".", Mode: "040000"}, nil
+	}
+
+	parent, err := fsys.lookup(op, path.Dir(name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err != nil || parent.Type != "tree" {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	nodes, err := fsys.listTree(op, path.Dir(name))
+	if err != nil {
+		return nil, err
+	}
+
+	base := path.Base(name)
+	i := sort.Search(len(nodes), func(i int) bool { return nodes[i].Name >= base })
+	if i == len(nodes) || nodes[i].Name != base {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	return nodes[i], nil
+}
+
+// listTree returns the nodes of the given directory sorted by name.
+func (fsys *RepositoryFS) listTree(op, dir string) ([]*TreeNode, error) {
+	fsys.mu.Lock()
+	nodes, ok := fsys.trees[dir]
+	fsys.mu.Unlock()
+	if ok {
+		return nodes, nil
+	}
+
+	opt := &ListTreeOptions{
+		ListOptions: ListOptions{PerPage: 100},
+		Ref:         Ptr(fsys.ref),
+	}
+	if dir != "." {
+		opt.Path = Ptr(dir)
+	}
+
+	nodes, err := All(context.Background(), func(pageOptions ...RequestOptionFunc) ([]*TreeNode, *Response, error) {
+		return fsys.repositories.ListTree(fsys.pid, opt, append(pageOptions, fsys.options...)...)
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			err = fs.ErrNotExist
+		}
+		return nil, &fs.PathError{Op: op, Path: dir, Err: err}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	fsys.mu.Lock()
+	fsys.trees[dir] = nodes
+	fsys.mu.Unlock()
+
+	return nodes, nil
+}
+
+// readDir returns the entries of the given directory sorted by name.
+func (fsys *RepositoryFS) readDir(op, name string, node *TreeNode) ([]fs.DirEntry, error) {
+	if node.Type != "tree" {
+		return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("not a directory")}
+	}
+
+	nodes, err := fsys.listTree(op, name)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]fs.DirEntry, 0, len(nodes))
+	for _, node := range nodes {
+		entries = append(entries, &repositoryDirEntry{fsys: fsys, name: path.Join(name, node.Name), node: node})
+	}
+
+	return entries, nil
+}
+
+// readBlob returns the content of the given file.
+func (fsys *RepositoryFS) readBlob(op, name string, node *TreeNode) ([]byte, error) {
+	if node.Type != "blob" {
+		return nil, nil
+	}
+
+	fsys.mu.Lock()
+	content, ok := fsys.blobs[node.ID]
+	fsys.mu.Unlock()
+	if ok {
+		return content, nil
+	}
+
+	content, _, err := fsys.repositories.RawBlobContent(fsys.pid, node.ID, fsys.options...)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			err = fs.ErrNotExist
+		}
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+
+	fsys.mu.Lock()
+	fsys.blobs[node.ID] = content
+	fsys.mu.Unlock()
+
+	return content, nil
+}
+
+// treeNodeMode returns the file mode of a tree node.
+func treeNodeMode(node *TreeNode) fs.FileMode {
+	switch node.Mode {
+	case "040000":
+		return fs.ModeDir | 0o755
+	case "100755":
+		return 0o755
+	case "120000":
+		return fs.ModeSymlink | 0o777
+	case "160000":
+		return fs.ModeIrregular
+	default:
+		return 0o644
+	}
+}
+
+// repositoryFileInfo implements fs.FileInfo for a tree node.
+type repositoryFileInfo struct {
+	node *TreeNode
+	size int64
+}
+
+func (fi *repositoryFileInfo) Name() string       { return fi.node.Name }
+func (fi *repositoryFileInfo) Size() int64        { return fi.size }
+func (fi *repositoryFileInfo) Mode() fs.FileMode  { return treeNodeMode(fi.node) }
+func (fi *repositoryFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi *repositoryFileInfo) IsDir() bool        { return fi.node.Type == "tree" }
+func (fi *repositoryFileInfo) Sys() interface{}   { return fi.node }
+
+// repositoryDirEntry implements fs.DirEntry for a tree node.
+type repositoryDirEntry struct {
+	fsys *RepositoryFS
+	name string
+	node *TreeNode
+}
+
+func (e *repositoryDirEntry) Name() string      { return e.node.Name }
+func (e *repositoryDirEntry) IsDir() bool       { return e.node.Type == "tree" }
+func (e *repositoryDirEntry) Type() fs.FileMode { return treeNodeMode(e.node).Type() }
+
+func (e *repositoryDirEntry) Info() (fs.FileInfo, error) {
+	return e.fsys.stat("stat", e.name, e.node)
+}
+
+// repositoryFile implements fs.File for a file. Its content is fetched when
+// it is first read.
+type repositoryFile struct {
+	fsys   *RepositoryFS
+	name   string
+	node   *TreeNode
+	reader *bytes.Reader
+}
+
+func (f *repositoryFile) Stat() (fs.FileInfo, error) {
+	if f.reader != nil {
+		return &repositoryFileInfo{node: f.node, size: f.reader.Size()}, nil
+	}
+	return f.fsys.stat("stat", f.name, f.node)
+}
+
+func (f *repositoryFile) Close() error { return nil }
+
+func (f *repositoryFile) Read(p []byte) (int, error) {
+	r, err := f.content()
+	if err != nil {
+		return 0, err
+	}
+	return r.Read(p)
+}
+
+func (f *repositoryFile) ReadAt(p []byte, off int64) (int, error) {
+	r, err := f.content()
+	if err != nil {
+		return 0, err
+	}
+	return r.ReadAt(p, off)
+}
+
+func (f *repositoryFile) Seek(offset int64, whence int) (int64, error) {
+	r, err := f.content()
+	if err != nil {
+		return 0, err
+	}
+	return r.Seek(offset, whence)
+}
+
+// content returns a reader for the content of the file, fetching it if
+// needed.
+func (f *repositoryFile) content() (*bytes.Reader, error) {
+	if f.reader == nil {
+		content, err := f.fsys.readBlob("read", f.name, f.node)
+		if err != nil {
+			return nil, err
+		}
+		f.reader = bytes.NewReader(content)
+	}
+	return f.reader, nil
+}
+
+// repositoryDir implements fs.ReadDirFile for a directory.
+type repositoryDir struct {
+	info    *repositoryFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *repositoryDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *repositoryDir) Close() error               { return nil }
+
+func (d *repositoryDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.node.Path, Err: errors.New("is a directory")}
+}
+
+func (d *repositoryDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries := d.entries[d.offset:]
+	if n > 0 {
+		if len(entries) == 0 {
+			return nil, io.EOF
+		}
+		if n < len(entries) {
+			entries = entries[:n]
+		}
+	}
+	d.offset += len(entries)
+	return entries, nil
+}
@@ -0,0 +1,184 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handleRepositoryFS serves a small repository at the ref "v1.0.0" and
+// returns a counter of the number of blob requests.
+func handleRepositoryFS(t *testing.T, mux *http.ServeMux) *int {
+	blobs := map[string]string{
+		"b1": "# Project\n",
+		"b2": "#!/bin/sh\n",
+		"b3": "Hello {{.}}\n",
+		"b4": "README.md",
+	}
+	trees := map[string][]*TreeNode{
+		"": {
+			{ID: "b1", Name: "README.md", Type: "blob", Path: "README.md", Mode: "100644"},
+			{ID: "b2", Name: "build.sh", Type: "blob", Path: "build.sh", Mode: "100755"},
+			{ID: "b4", Name: "link.md", Type: "blob", Path: "link.md", Mode: "120000"},
+			{ID: "c1", Name: "vendor", Type: "commit", Path: "vendor", Mode: "160000"},
+			{ID: "t1", Name: "templates", Type: "tree", Path: "templates", Mode: "040000"},
+		},
+		"templates": {
+			{ID: "b3", Name: "hello.tmpl", Type: "blob", Path: "templates/hello.tmpl", Mode: "100644"},
+			{ID: "b1", Name: "README.md", Type: "blob", Path: "templates/README.md", Mode: "100644"},
+		},
+	}
+
+	mux.HandleFunc("/api/v4/projects/1/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, "v1.0.0", r.URL.Query().Get("ref"))
+
+		nodes, ok := trees[r.URL.Query().Get("path")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(nodes))
+	})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/files/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodHead)
+		assert.Equal(t, "v1.0.0", r.URL.Query().Get("ref"))
+
+		name := strings.TrimPrefix(r.URL.Path, "/api/v4/projects/1/repository/files/")
+		dir := strings.TrimSuffix(path.Dir(name), ".")
+		for _, node := range trees[dir] {
+			if node.Path == name {
+				w.Header().Set("X-Gitlab-Size", strconv.Itoa(len(blobs[node.ID])))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var requests int
+	mux.HandleFunc("/api/v4/projects/1/repository/blobs/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		requests++
+
+		sha := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v4/projects/1/repository/blobs/"), "/raw")
+		w.Write([]byte(blobs[sha]))
+	})
+
+	return &requests
+}
+
+func TestRepositoryFS(t *testing.T) {
+	mux, client := setup(t)
+	requests := handleRepositoryFS(t, mux)
+
+	fsys := client.Repositories.NewFS(1, "v1.0.0")
+	require.NoError(t, fstest.TestFS(fsys, "README.md", "build.sh", "link.md", "vendor", "templates/hello.tmpl"))
+
+	// Blobs are only fetched once, even when shared by multiple files, and
+	// only when read. The symlink is never read.
+	assert.Equal(t, 3, *requests)
+}
+
+func TestRepositoryFSModes(t *testing.T) {
+	mux, client := setup(t)
+	handleRepositoryFS(t, mux)
+
+	fsys := client.Repositories.NewFS(1, "v1.0.0")
+
+	tests := map[string]fs.FileMode{
+		".":         fs.ModeDir | 0o755,
+		"README.md": 0o644,
+		"build.sh":  0o755,
+		"link.md":   fs.ModeSymlink | 0o777,
+		"vendor":    fs.ModeIrregular,
+		"templates": fs.ModeDir | 0o755,
+	}
+	for name, want := range tests {
+		info, err := fsys.Stat(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, info.Mode(), name)
+	}
+
+	content, err := fs.ReadFile(fsys, "link.md")
+	require.NoError(t, err)
+	assert.Equal(t, "README.md", string(content))
+
+	_, err = fsys.Stat("missing/file.txt")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	_, err = fsys.Open("README.md/file.txt")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestRepositoryFSStat(t *testing.T) {
+	mux, client := setup(t)
+	requests := handleRepositoryFS(t, mux)
+
+	fsys := client.Repositories.NewFS(1, "v1.0.0")
+
+	info, err := fsys.Stat("templates/hello.tmpl")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("Hello {{.}}\n")), info.Size())
+
+	entries, err := fsys.ReadDir(".")
+	require.NoError(t, err)
+	info, err = entries[0].Info()
+	require.NoError(t, err)
+	assert.Equal(t, "README.md", info.Name())
+	assert.Equal(t, int64(len("# Project\n")), info.Size())
+
+	f, err := fsys.Open("build.sh")
+	require.NoError(t, err)
+	info, err = f.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("#!/bin/sh\n")), info.Size())
+
+	// Only reading a file fetches its content.
+	assert.Equal(t, 0, *requests)
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n", string(content))
+	assert.Equal(t, 1, *requests)
+	require.NoError(t, f.Close())
+}
+
+func TestRepositoryFSTemplates(t *testing.T) {
+	mux, client := setup(t)
+	handleRepositoryFS(t, mux)
+
+	tmpl, err := template.ParseFS(client.Repositories.NewFS(1, "v1.0.0"), "templates/*.tmpl")
+	require.NoError(t, err)
+
+	var b strings.Builder
+	require.NoError(t, tmpl.ExecuteTemplate(&b, "hello.tmpl", "world"))
+	assert.Equal(t, "Hello world\n", b.String())
+}
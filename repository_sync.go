@@ -0,0 +1,427 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// SyncDirectoryOptions represents the available options for syncing a local
+// directory to a repository.
+type SyncDirectoryOptions struct {
+	// Ref is the branch the directory is synced to. Required.
+	Ref *string
+
+	// Path is the directory in the repository that mirrors the local
+	// directory. Defaults to the root of the repository.
+	Path *string
+
+	// Branch is the branch the changes are committed to. It is created from
+	// Ref if it doesn't exist yet. Defaults to Ref.
+	Branch *string
+
+	CommitMessage *string
+	AuthorEmail   *string
+	AuthorName    *string
+
+	// MaxPayloadSize is the maximum size of the file contents sent in a
+	// single commit, see CommitBuilderOptions.
+	MaxPayloadSize *int
+
+	// DryRun only computes the changes, without committing them.
+	DryRun *bool
+
+	// MergeRequest, if set, opens a merge request from Branch into Ref after
+	// committing the changes. The source and target branches are filled in
+	// automatically, and the title defaults to the commit message. It
+	// requires a Branch other than Ref.
+	MergeRequest *CreateMergeRequestOptions
+}
+
+// SyncChange represents a single change made to sync a directory.
+type SyncChange struct {
+	Action       FileActionValue
+	Path         string
+	PreviousPath string
+}
+
+func (c *SyncChange) String() string {
+	if c.PreviousPath != "" {
+		return fmt.Sprintf("%s %s -> %s", c.Action, c.PreviousPath, c.Path)
+	}
+	return fmt.Sprintf("%s %s", c.Action, c.Path)
+}
+
+// SyncDirectoryResult represents the result of syncing a directory.
+type SyncDirectoryResult struct {
+	// Changes contains the changes needed to sync the directory, ordered by
+	// path. It is empty if the directory is already in sync.
+	Changes []*SyncChange
+
+	// Commits contains the created commits, unless it was a dry run.
+	Commits []*Commit
+
+	// MergeRequest is the merge request that was opened, if requested.
+	MergeRequest *MergeRequest
+}
+
+// localFile is a file of the local directory.
+type localFile struct {
+	content    []byte
+	blobID     string
+	executable bool
+}
+
+// SyncDirectory mirrors the files of the local directory into a directory of
+// the repository. Files are only created, updated, moved or deleted when
+// needed, and all changes are committed at once.
+//
+// Unchanged files are detected by comparing git blob IDs, so only the
+// repository tree is requested for them. For every changed file the last
+// commit ID is pinned, so the commit fails with a *ConflictError when one of
+// those files is changed concurrently.
+//
+// Example usage:
+//
+//	result, _, err := client.Repositories.SyncDirectory("group/config", os.DirFS("generated"), &gitlab.SyncDirectoryOptions{
+//	    Ref:           gitlab.Ptr("main"),
+//	    Path:          gitlab.Ptr("clusters/prod"),
+//	    Branch:        gitlab.Ptr("sync/prod"),
+//	    CommitMessage: gitlab.Ptr("Sync generated config"),
+//	    MergeRequest:  &gitlab.CreateMergeRequestOptions{},
+//	})
+func (s *RepositoriesService) SyncDirectory(pid interface{}, fsys fs.FS, opt *SyncDirectoryOptions, options ...RequestOptionFunc) (*SyncDirectoryResult, *Response, error) {
+	if opt == nil || opt.Ref == nil || *opt.Ref == "" {
+		return nil, nil, errors.New("ref is required")
+	}
+
+	ref := *opt.Ref
+	branch := ref
+	if opt.Branch != nil && *opt.Branch != "" {
+		branch = *opt.Branch
+	}
+	if opt.MergeRequest != nil && branch == ref {
+		return nil, nil, errors.New("merge request requires a branch other than ref")
+	}
+	dir := ""
+	if opt.Path != nil {
+		dir = strings.Trim(*opt.Path, "/")
+	}
+
+	// Compare against the branch we commit to, unless it still needs to be
+	// created.
+	base := branch
+	var startBranch *string
+	if branch != ref {
+		_, resp, err := s.client.Branches.GetBranch(pid, branch, options...)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			base = ref
+			startBranch = Ptr(ref)
+		case err != nil:
+			return nil, resp, err
+		}
+	}
+
+	local, err := readLocalFiles(fsys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remote, resp, err := s.listBlobs(pid, base, dir, options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	lastCommitID := func(name string) (string, string, *Response, error) {
+		f, resp, err := s.client.RepositoryFiles.GetFileMetaData(pid, name, &GetFileMetaDataOptions{Ref: Ptr(base)}, options...)
+		if err != nil {
+			return "", "", resp, err
+		}
+		return f.LastCommitID, f.SHA256, resp, nil
+	}
+
+	var actions []*CommitActionOptions
+	var created []string
+	for _, name := range sortedKeys(local) {
+		file := local[name]
+		node, ok := remote[name]
+		if !ok {
+			created = append(created, name)
+			continue
+		}
+
+		executable := node.Mode == "100755"
+		if node.ID == file.blobID {
+			if executable != file.executable {
+				id, _, resp, err := lastCommitID(path.Join(dir, name))
+				if err != nil {
+					return nil, resp, err
+				}
+				actions = append(actions, &CommitActionOptions{
+					Action:          FileAction(FileChmod),
+					FilePath:        Ptr(path.Join(dir, name)),
+					LastCommitID:    Ptr(id),
+					ExecuteFilemode: Ptr(file.executable),
+				})
+			}
+			continue
+		}
+
+		id, sum, resp, err := lastCommitID(path.Join(dir, name))
+		if err != nil {
+			return nil, resp, err
+		}
+		if sum == sha256Hex(file.content) && executable == file.executable {
+			continue
+		}
+
+		content, encoding := encodeCommitContent(file.content)
+		actions = append(actions, &CommitActionOptions{
+			Action:          FileAction(FileUpdate),
+			FilePath:        Ptr(path.Join(dir, name)),
+			Content:         Ptr(content),
+			Encoding:        encoding,
+			LastCommitID:    Ptr(id),
+			ExecuteFilemode: Ptr(file.executable),
+		})
+	}
+
+	// Remote files that are no longer present locally are either deleted,
+	// or moved if a new file has exactly the same content.
+	removed := make(map[string][]string)
+	for _, name := range sortedKeys(remote) {
+		if _, ok := local[name]; !ok {
+			removed[remote[name].ID] = append(removed[remote[name].ID], name)
+		}
+	}
+
+	for _, name := range created {
+		file := local[name]
+		action := &CommitActionOptions{
+			Action:   FileAction(FileCreate),
+			FilePath: Ptr(path.Join(dir, name)),
+		}
+		var chmod *CommitActionOptions
+
+		if previous := removed[file.blobID]; len(previous) > 0 {
+			removed[file.blobID] = previous[1:]
+
+			id, _, resp, err := lastCommitID(path.Join(dir, previous[0]))
+			if err != nil {
+				return nil, resp, err
+			}
+			action.Action = FileAction(FileMove)
+			action.PreviousPath = Ptr(path.Join(dir, previous[0]))
+			action.LastCommitID = Ptr(id)
+
+			// A move keeps the mode of the file, so it is changed after
+			// moving the file if needed.
+			if executable := remote[previous[0]].Mode == "100755"; executable != file.executable {
+				chmod = &CommitActionOptions{
+					Action:          FileAction(FileChmod),
+					FilePath:        Ptr(path.Join(dir, name)),
+					ExecuteFilemode: Ptr(file.executable),
+				}
+			}
+		} else {
+			content, encoding := encodeCommitContent(file.content)
+			action.Content = Ptr(content)
+			action.Encoding = encoding
+			if file.executable {
+				action.ExecuteFilemode = Ptr(true)
+			}
+		}
+
+		actions = append(actions, action)
+		if chmod != nil {
+			actions = append(actions, chmod)
+		}
+	}
+
+	for _, names := range removed {
+		for _, name := range names {
+			id, _, resp, err := lastCommitID(path.Join(dir, name))
+			if err != nil {
+				return nil, resp, err
+			}
+			actions = append(actions, &CommitActionOptions{
+				Action:       FileAction(FileDelete),
+				FilePath:     Ptr(path.Join(dir, name)),
+				LastCommitID: Ptr(id),
+			})
+		}
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		return *actions[i].FilePath < *actions[j].FilePath
+	})
+
+	result := &SyncDirectoryResult{}
+	for _, action := range actions {
+		change := &SyncChange{Action: *action.Action, Path: *action.FilePath}
+		if action.PreviousPath != nil {
+			change.PreviousPath = *action.PreviousPath
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	if len(actions) == 0 || (opt.DryRun != nil && *opt.DryRun) {
+		return result, resp, nil
+	}
+
+	result.Commits, resp, err = createCommits(s.client.Commits, pid, branch, actions, &CommitBuilderOptions{
+		CommitMessage:  opt.CommitMessage,
+		StartBranch:    startBranch,
+		AuthorEmail:    opt.AuthorEmail,
+		AuthorName:     opt.AuthorName,
+		MaxPayloadSize: opt.MaxPayloadSize,
+	}, options)
+	if err != nil {
+		return result, resp, err
+	}
+
+	if opt.MergeRequest != nil {
+		mopt := *opt.MergeRequest
+		if mopt.SourceBranch == nil {
+			mopt.SourceBranch = Ptr(branch)
+		}
+		if mopt.TargetBranch == nil {
+			mopt.TargetBranch = Ptr(ref)
+		}
+		if mopt.Title == nil {
+			mopt.Title = opt.CommitMessage
+		}
+
+		result.MergeRequest, resp, err = s.client.MergeRequests.CreateMergeRequest(pid, &mopt, options...)
+		if err != nil {
+			return result, resp, err
+		}
+	}
+
+	return result, resp, nil
+}
+
+// listBlobs returns the blobs in the given directory of the repository,
+// keyed by their path relative to the directory.
+func (s *RepositoriesService) listBlobs(pid interface{}, ref, dir string, options []RequestOptionFunc) (map[string]*TreeNode, *Response, error) {
+	opt := &ListTreeOptions{
+		ListOptions: ListOptions{PerPage: 100},
+		Ref:         Ptr(ref),
+		Recursive:   Ptr(true),
+	}
+	if dir != "" {
+		opt.Path = Ptr(dir)
+	}
+
+	var lastResp *Response
+	nodes, err := All(context.Background(), func(pageOptions ...RequestOptionFunc) ([]*TreeNode, *Response, error) {
+		nodes, resp, err := s.ListTree(pid, opt, append(pageOptions, options...)...)
+		lastResp = resp
+		return nodes, resp, err
+	})
+	if err != nil {
+		// GitLab returns 404 for directories that don't exist (yet).
+		if errors.Is(err, ErrNotFound) && dir != "" {
+			return map[string]*TreeNode{}, lastResp, nil
+		}
+		return nil, lastResp, err
+	}
+
+	blobs := make(map[string]*TreeNode)
+	for _, node := range nodes {
+		// Symlinks and submodules are not synced.
+		if node.Type != "blob" || node.Mode == "120000" {
+			continue
+		}
+		name := node.Path
+		if dir != "" {
+			name = strings.TrimPrefix(name, dir+"/")
+		}
+		blobs[name] = node
+	}
+
+	return blobs, lastResp, nil
+}
+
+// readLocalFiles reads all regular files of the file system.
+func readLocalFiles(fsys fs.FS) (map[string]*localFile, error) {
+	files := make(map[string]*localFile)
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		files[name] = &localFile{
+			content:    content,
+			blobID:     gitBlobID(content),
+			executable: info.Mode()&0o111 != 0,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// gitBlobID returns the ID git uses for a blob with the given content.
+func gitBlobID(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// sortedKeys returns the keys of the map in sorted order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,234 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handleSyncTree serves a repository tree containing the files of the given
+// file system in the config directory.
+func handleSyncTree(t *testing.T, mux *http.ServeMux, fsys fstest.MapFS) {
+	mux.HandleFunc("/api/v4/projects/1/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, "main", r.URL.Query().Get("ref"))
+		assert.Equal(t, "config", r.URL.Query().Get("path"))
+		assert.Equal(t, "true", r.URL.Query().Get("recursive"))
+
+		nodes := []*TreeNode{{Type: "tree", Path: "config/sub", Mode: "040000"}}
+		for name, f := range fsys {
+			mode := "100644"
+			if f.Mode&0o111 != 0 {
+				mode = "100755"
+			}
+			nodes = append(nodes, &TreeNode{ID: gitBlobID(f.Data), Type: "blob", Path: "config/" + name, Mode: mode})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(nodes))
+	})
+}
+
+func TestSyncDirectory(t *testing.T) {
+	mux, client := setup(t)
+
+	handleSyncTree(t, mux, fstest.MapFS{
+		"app.yaml":     {Data: []byte("replicas: 1\n")},
+		"run.sh":       {Data: []byte("#!/bin/sh\n")},
+		"same.yaml":    {Data: []byte("unchanged\n")},
+		"old/db.yaml":  {Data: []byte("db: postgres\n")},
+		"obsolete.txt": {Data: []byte("obsolete\n")},
+	})
+	handleFileMetaData(t, mux, map[string]string{
+		"config/app.yaml":     "aaa",
+		"config/run.sh":       "bbb",
+		"config/old/db.yaml":  "ccc",
+		"config/obsolete.txt": "ddd",
+	})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/branches/sync", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"404 Branch Not Found"}`)
+	})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var opt CreateCommitOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opt))
+
+		assert.Equal(t, "sync", *opt.Branch)
+		assert.Equal(t, "main", *opt.StartBranch)
+		assert.Equal(t, "Sync config", *opt.CommitMessage)
+		assert.Equal(t, []*CommitActionOptions{
+			{
+				Action:          FileAction(FileUpdate),
+				FilePath:        Ptr("config/app.yaml"),
+				Content:         Ptr("replicas: 3\n"),
+				LastCommitID:    Ptr("aaa"),
+				ExecuteFilemode: Ptr(false),
+			},
+			{
+				Action:       FileAction(FileMove),
+				FilePath:     Ptr("config/db.yaml"),
+				PreviousPath: Ptr("config/old/db.yaml"),
+				LastCommitID: Ptr("ccc"),
+			},
+			{
+				Action:   FileAction(FileCreate),
+				FilePath: Ptr("config/new.yaml"),
+				Content:  Ptr("new\n"),
+			},
+			{
+				Action:       FileAction(FileDelete),
+				FilePath:     Ptr("config/obsolete.txt"),
+				LastCommitID: Ptr("ddd"),
+			},
+			{
+				Action:          FileAction(FileChmod),
+				FilePath:        Ptr("config/run.sh"),
+				LastCommitID:    Ptr("bbb"),
+				ExecuteFilemode: Ptr(true),
+			},
+		}, opt.Actions)
+
+		fmt.Fprint(w, `{"id":"eee"}`)
+	})
+
+	mux.HandleFunc("/api/v4/projects/1/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var opt CreateMergeRequestOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opt))
+
+		assert.Equal(t, "sync", *opt.SourceBranch)
+		assert.Equal(t, "main", *opt.TargetBranch)
+		assert.Equal(t, "Sync config", *opt.Title)
+
+		fmt.Fprint(w, `{"iid":7}`)
+	})
+
+	local := fstest.MapFS{
+		"app.yaml":  {Data: []byte("replicas: 3\n")},
+		"run.sh":    {Data: []byte("#!/bin/sh\n"), Mode: 0o755},
+		"same.yaml": {Data: []byte("unchanged\n")},
+		"db.yaml":   {Data: []byte("db: postgres\n")},
+		"new.yaml":  {Data: []byte("new\n")},
+	}
+
+	result, _, err := client.Repositories.SyncDirectory(1, local, &SyncDirectoryOptions{
+		Ref:           Ptr("main"),
+		Path:          Ptr("/config/"),
+		Branch:        Ptr("sync"),
+		CommitMessage: Ptr("Sync config"),
+		MergeRequest:  &CreateMergeRequestOptions{},
+	})
+	require.NoError(t, err)
+
+	var changes []string
+	for _, change := range result.Changes {
+		changes = append(changes, change.String())
+	}
+	assert.Equal(t, []string{
+		"update config/app.yaml",
+		"move config/old/db.yaml -> config/db.yaml",
+		"create config/new.yaml",
+		"delete config/obsolete.txt",
+		"chmod config/run.sh",
+	}, changes)
+
+	require.Len(t, result.Commits, 1)
+	assert.Equal(t, "eee", result.Commits[0].ID)
+	require.NotNil(t, result.MergeRequest)
+	assert.Equal(t, 7, result.MergeRequest.IID)
+}
+
+func TestSyncDirectoryDryRun(t *testing.T) {
+	mux, client := setup(t)
+
+	handleSyncTree(t, mux, fstest.MapFS{
+		"app.yaml": {Data: []byte("replicas: 1\n")},
+	})
+
+	mux.HandleFunc("/api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unexpected commit")
+	})
+
+	local := fstest.MapFS{
+		"app.yaml": {Data: []byte("replicas: 1\n")},
+	}
+
+	result, _, err := client.Repositories.SyncDirectory(1, local, &SyncDirectoryOptions{
+		Ref:  Ptr("main"),
+		Path: Ptr("config"),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Changes)
+
+	local["new.yaml"] = &fstest.MapFile{Data: []byte("new\n")}
+
+	result, _, err = client.Repositories.SyncDirectory(1, local, &SyncDirectoryOptions{
+		Ref:    Ptr("main"),
+		Path:   Ptr("config"),
+		DryRun: Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*SyncChange{{Action: FileCreate, Path: "config/new.yaml"}}, result.Changes)
+	assert.Empty(t, result.Commits)
+}
+
+func TestSyncDirectoryMoveChmod(t *testing.T) {
+	mux, client := setup(t)
+
+	handleSyncTree(t, mux, fstest.MapFS{
+		"old.sh": {Data: []byte("#!/bin/sh\n")},
+	})
+	handleFileMetaData(t, mux, map[string]string{
+		"config/old.sh": "aaa",
+	})
+
+	local := fstest.MapFS{
+		"new.sh": {Data: []byte("#!/bin/sh\n"), Mode: 0o755},
+	}
+
+	result, _, err := client.Repositories.SyncDirectory(1, local, &SyncDirectoryOptions{
+		Ref:    Ptr("main"),
+		Path:   Ptr("config"),
+		DryRun: Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*SyncChange{
+		{Action: FileMove, Path: "config/new.sh", PreviousPath: "config/old.sh"},
+		{Action: FileChmod, Path: "config/new.sh"},
+	}, result.Changes)
+}
+
+func TestSyncDirectoryMergeRequestWithoutBranch(t *testing.T) {
+	_, client := setup(t)
+
+	_, _, err := client.Repositories.SyncDirectory(1, fstest.MapFS{}, &SyncDirectoryOptions{
+		Ref:          Ptr("main"),
+		Branch:       Ptr("main"),
+		MergeRequest: &CreateMergeRequestOptions{},
+	})
+	assert.EqualError(t, err, "merge request requires a branch other than ref")
+}
@@ -19,6 +19,7 @@ package gitlab
 import (
 	"context"
 	"net/url"
+	"strconv"
 
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 )
@@ -74,6 +75,18 @@ func WithKeysetPaginationParameters(nextLink string) RequestOptionFunc {
 	}
 }
 
+// WithOffsetPaginationParameters takes a page number and modifies the "page"
+// query parameter of an offset-based paginated request, overriding any value
+// set using the ListOptions of the request.
+func WithOffsetPaginationParameters(page int) RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		q := req.URL.Query()
+		q.Set("page", strconv.Itoa(page))
+		req.URL.RawQuery = q.Encode()
+		return nil
+	}
+}
+
 // WithSudo takes either a username or user ID and sets the SUDO request header.
 func WithSudo(uid interface{}) RequestOptionFunc {
 	return func(req *retryablehttp.Request) error {
@@ -0,0 +1,178 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// ResponseCache describes the interface that all (custom) response caches
+// must implement. Implementations must be safe for concurrent use.
+//
+// The keys are derived from the request URL and a hash of the credentials
+// used for the request, so cached responses are never shared between users.
+type ResponseCache interface {
+	Get(ctx context.Context, key string) (*CachedResponse, bool)
+	Set(ctx context.Context, key string, resp *CachedResponse)
+}
+
+// CachedResponse represents a response stored in a ResponseCache.
+type CachedResponse struct {
+	ETag   string      `json:"etag"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// LRUResponseCache is an in-memory ResponseCache that evicts the least
+// recently used responses once it holds the maximum number of responses.
+type LRUResponseCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewLRUResponseCache returns a new in-memory response cache holding at most
+// size responses.
+func NewLRUResponseCache(size int) *LRUResponseCache {
+	return &LRUResponseCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the response stored under the given key.
+func (c *LRUResponseCache) Get(_ context.Context, key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+
+	return e.Value.(*lruEntry).resp, true
+}
+
+// Set stores the response under the given key.
+func (c *LRUResponseCache) Set(_ context.Context, key string, resp *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).resp = resp
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, resp: resp})
+
+	for c.size > 0 && c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (c *LRUResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// authHeaders are the headers that identify who makes a request.
+var authHeaders = []string{"Authorization", "JOB-TOKEN", "PRIVATE-TOKEN", "SUDO"}
+
+// responseCacheKey returns the cache key for the given request, which
+// consists of the request URL and a hash of the credentials used.
+func responseCacheKey(req *retryablehttp.Request) string {
+	h := sha256.New()
+	for _, name := range authHeaders {
+		for _, v := range req.Header.Values(name) {
+			io.WriteString(h, name+":"+v+"\n")
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)) + " " + req.URL.String()
+}
+
+// conditionalRequest makes the request conditional if the response cache
+// holds a response for it, and returns the cache key and cached response.
+// Any validator set for a previously cached response is replaced, so it can
+// be called again when the credentials of the request changed.
+func (c *Client) conditionalRequest(req *retryablehttp.Request, previous *CachedResponse) (string, *CachedResponse) {
+	if previous != nil && req.Header.Get("If-None-Match") == previous.ETag {
+		req.Header.Del("If-None-Match")
+	}
+
+	key := responseCacheKey(req)
+	cached, _ := c.responseCache.Get(req.Context(), key)
+	if cached != nil && req.Header.Get("If-None-Match") == "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	return key, cached
+}
+
+// applyResponseCache updates the given response using the response cache.
+// A 304 Not Modified response gets the cached body and headers, while a
+// successful response with an ETag header is stored in the cache.
+func (c *Client) applyResponseCache(ctx context.Context, key string, cached *CachedResponse, resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		header := cached.Header.Clone()
+		for k, v := range resp.Header {
+			header[k] = v
+		}
+		resp.Header = header
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		c.responseCache.Set(ctx, key, &CachedResponse{
+			ETag:   resp.Header.Get("ETag"),
+			Header: resp.Header.Clone(),
+			Body:   body,
+		})
+	}
+
+	return nil
+}
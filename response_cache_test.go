@@ -0,0 +1,155 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestResponseCache(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var conditional, full int
+	mux.HandleFunc("/api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", `W/"v1"`)
+		fmt.Fprint(w, `{"id":1,"name":"test"}`)
+	})
+
+	cache := NewLRUResponseCache(10)
+	client, err := NewClient("token", WithBaseURL(server.URL), WithResponseCache(cache))
+	require.NoError(t, err)
+
+	project, resp, err := client.Projects.GetProject(1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "test", project.Name)
+
+	project, resp, err = client.Projects.GetProject(1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, "test", project.Name)
+
+	assert.Equal(t, 1, full)
+	assert.Equal(t, 1, conditional)
+	assert.Equal(t, 1, cache.Len())
+
+	// Requests made with other credentials don't use the cached response.
+	other, err := NewClient("other-token", WithBaseURL(server.URL), WithResponseCache(cache))
+	require.NoError(t, err)
+
+	_, resp, err = other.Projects.GetProject(1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, full)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestResponseCacheAfterReauthentication(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"access-1","refresh_token":"refresh-1","token_type":"Bearer","expires_in":7200}`)
+	})
+
+	var conditional, full int
+	mux.HandleFunc("/api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"401 Unauthorized"}`)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", `W/"v1"`)
+		fmt.Fprint(w, `{"id":1,"name":"test"}`)
+	})
+
+	// The initial access token looks valid, but is rejected by the server.
+	config := &oauth2.Config{ClientID: "id", ClientSecret: "secret", Endpoint: OAuthEndpoint(server.URL)}
+	ts := NewOAuthRefreshTokenSource(context.Background(), config, &oauth2.Token{
+		AccessToken:  "access-0",
+		RefreshToken: "refresh-0",
+		Expiry:       time.Now().Add(time.Hour),
+	}, nil)
+
+	cache := NewLRUResponseCache(10)
+	client, err := NewOAuthTokenSourceClient(ts, WithBaseURL(server.URL), WithResponseCache(cache))
+	require.NoError(t, err)
+
+	_, resp, err := client.Projects.GetProject(1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The response is stored for the new token, so it is reused.
+	project, resp, err := client.Projects.GetProject(1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, "test", project.Name)
+
+	assert.Equal(t, 1, full)
+	assert.Equal(t, 1, conditional)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestLRUResponseCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUResponseCache(2)
+
+	cache.Set(ctx, "a", &CachedResponse{ETag: "a"})
+	cache.Set(ctx, "b", &CachedResponse{ETag: "b"})
+
+	// Use "a", so "b" becomes the least recently used entry.
+	_, ok := cache.Get(ctx, "a")
+	require.True(t, ok)
+
+	cache.Set(ctx, "c", &CachedResponse{ETag: "c"})
+
+	_, ok = cache.Get(ctx, "b")
+	assert.False(t, ok)
+	_, ok = cache.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok = cache.Get(ctx, "c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+}
@@ -0,0 +1,338 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Command geninterfaces generates an interface for every service of the
+// gitlab.Client, containing all exported methods of the service, together
+// with a gitlabmock.Client that wires the generated mocks into a client.
+//
+// It is run using go generate from the root of the repository:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = `//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Code generated by geninterfaces. DO NOT EDIT.
+
+`
+
+// field is a service field of the gitlab.Client.
+type field struct {
+	name    string
+	service string
+}
+
+// method is an exported method of a service.
+type method struct {
+	name      string
+	signature string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("geninterfaces: ")
+
+	fset := token.NewFileSet()
+	files, err := parseFiles(fset, ".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fields, err := clientFields(files)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	services := make(map[string]bool)
+	for _, f := range fields {
+		services[f.service] = true
+	}
+
+	methods, imports, err := serviceMethods(fset, files, services)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := write("service_interfaces.go", interfacesFile(fields, methods, imports)); err != nil {
+		log.Fatal(err)
+	}
+	if err := write(filepath.Join("gitlabmock", "client.go"), mockClientFile(fields)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseFiles parses all non-test Go files of the package in dir, ordered by
+// file name so the output is stable.
+func parseFiles(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || filepath.Base(name) == "service_interfaces.go" {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if f.Name.Name == "gitlab" {
+			files = append(files, f)
+		}
+	}
+
+	return files, nil
+}
+
+// clientFields returns the service fields of the Client struct, which are
+// all fields with a type named "<Service>Interface".
+func clientFields(files []*ast.File) ([]field, error) {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok || ts.Name.Name != "Client" {
+					continue
+				}
+
+				var fields []field
+				for _, fl := range st.Fields.List {
+					ident, ok := fl.Type.(*ast.Ident)
+					if !ok || !strings.HasSuffix(ident.Name, "ServiceInterface") {
+						continue
+					}
+					for _, name := range fl.Names {
+						fields = append(fields, field{
+							name:    name.Name,
+							service: strings.TrimSuffix(ident.Name, "Interface"),
+						})
+					}
+				}
+				return fields, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("type Client not found")
+}
+
+// serviceMethods returns the exported methods of the given services, in the
+// order in which they are declared, and the imports used by their signatures.
+func serviceMethods(fset *token.FileSet, files []*ast.File, services map[string]bool) (map[string][]method, map[string]string, error) {
+	methods := make(map[string][]method)
+	imports := make(map[string]string)
+
+	for _, f := range files {
+		fileImports := make(map[string]string)
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			name := filepath.Base(path)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			fileImports[name] = path
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			// Most services use pointer receivers, but not all of them.
+			typ := fn.Recv.List[0].Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			recv, ok := typ.(*ast.Ident)
+			if !ok || !services[recv.Name] {
+				continue
+			}
+
+			var err error
+			ast.Inspect(fn.Type, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if pkg, ok := sel.X.(*ast.Ident); ok {
+					path, ok := fileImports[pkg.Name]
+					if !ok {
+						err = fmt.Errorf("%s: unknown package %s", fset.Position(sel.Pos()), pkg.Name)
+					}
+					imports[pkg.Name] = path
+				}
+				return false
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, fset, fn.Type); err != nil {
+				return nil, nil, err
+			}
+
+			methods[recv.Name] = append(methods[recv.Name], method{
+				name:      fn.Name.Name,
+				signature: strings.TrimPrefix(buf.String(), "func"),
+			})
+		}
+	}
+
+	return methods, imports, nil
+}
+
+// interfacesFile returns the source of the file declaring the interfaces.
+func interfacesFile(fields []field, methods map[string][]method, imports map[string]string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package gitlab\n\n")
+
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for name, path := range imports {
+			if filepath.Base(path) == name {
+				fmt.Fprintf(&buf, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&buf, "\t%s %q\n", name, path)
+			}
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range sortedFields(fields) {
+		if seen[f.service] {
+			continue
+		}
+		seen[f.service] = true
+
+		doc := fmt.Sprintf("// %sInterface defines all the API methods of the %s.", f.service, f.service)
+		if len(doc) > 80 {
+			doc = strings.Replace(doc, " of the ", "\n// of the ", 1)
+		}
+		buf.WriteString(doc + "\n")
+		fmt.Fprintf(&buf, "type %sInterface interface {\n", f.service)
+		for _, m := range methods[f.service] {
+			fmt.Fprintf(&buf, "\t%s%s\n", m.name, m.signature)
+		}
+		buf.WriteString("}\n\n")
+		fmt.Fprintf(&buf, "var _ %sInterface = (*%s)(nil)\n\n", f.service, f.service)
+	}
+
+	return buf.Bytes()
+}
+
+// mockClientFile returns the source of the gitlabmock.Client.
+func mockClientFile(fields []field) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString(`package gitlabmock
+
+import (
+	"github.com/xanzy/go-gitlab"
+	"go.uber.org/mock/gomock"
+)
+
+// Client is a gitlab.Client with all services replaced by mocks. Use the
+// mocks to set expectations and pass the embedded *gitlab.Client to the
+// code under test.
+type Client struct {
+	*gitlab.Client
+
+`)
+	for _, f := range fields {
+		fmt.Fprintf(&buf, "\t%s *Mock%sInterface\n", f.name, f.service)
+	}
+	buf.WriteString(`}
+
+// NewClient returns a new Client using mocks created with the given
+// controller.
+func NewClient(ctrl *gomock.Controller) *Client {
+	client, err := gitlab.NewClient("")
+	if err != nil {
+		panic(err)
+	}
+
+	c := &Client{Client: client}
+`)
+	for _, f := range fields {
+		fmt.Fprintf(&buf, "\tc.%s = NewMock%sInterface(ctrl)\n", f.name, f.service)
+		fmt.Fprintf(&buf, "\tc.Client.%s = c.%s\n", f.name, f.name)
+	}
+	buf.WriteString("\n\treturn c\n}\n")
+
+	return buf.Bytes()
+}
+
+// sortedFields returns the fields sorted by service name.
+func sortedFields(fields []field) []field {
+	sorted := append([]field(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].service < sorted[j].service
+	})
+	return sorted
+}
+
+// write formats the source and writes it to the named file.
+func write(name string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("formatting %s: %v", name, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, formatted, 0o644)
+}
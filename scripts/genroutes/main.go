@@ -0,0 +1,186 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Command genroutes generates the list of API routes used by the client,
+// which is used to turn request paths into low cardinality path templates
+// for instrumentation.
+//
+// Routes are collected from the string literals of the package that look
+// like API paths, such as the format strings passed to fmt.Sprintf by the
+// service methods. Every segment containing a formatting verb is a path
+// parameter and is replaced by ":id".
+//
+// It is run using go generate from the root of the repository:
+//
+//	go generate ./...
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = `//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Code generated by genroutes. DO NOT EDIT.
+
+`
+
+const output = "api_routes.go"
+
+var (
+	// routePattern matches literals that look like API paths: a static first
+	// segment, followed by static segments and segments with a verb.
+	routePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(/([a-z0-9_.\-]+|[^/%\s]*%[sdv][^/%\s]*))*$`)
+
+	verbPattern = regexp.MustCompile(`%[sdv]`)
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("genroutes: ")
+
+	names, err := filepath.Glob("*.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	routes := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if f.Name.Name != "gitlab" {
+			continue
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			for _, lit := range pathLiterals(n) {
+				s, err := strconv.Unquote(lit.Value)
+				if err == nil && routePattern.MatchString(s) {
+					routes[template(s)] = true
+				}
+			}
+			return true
+		})
+	}
+
+	sorted := make([]string, 0, len(routes))
+	for route := range routes {
+		sorted = append(sorted, route)
+	}
+	sort.Strings(sorted)
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package gitlab\n\n")
+	b.WriteString("// apiRoutes contains the paths of all API routes used by the client,\n")
+	b.WriteString("// with every path parameter replaced by \":id\".\n")
+	b.WriteString("var apiRoutes = []string{\n")
+	for _, route := range sorted {
+		fmt.Fprintf(&b, "\t%q,\n", route)
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// pathLiterals returns the string literals of the node that are used as a
+// request path: the format passed to fmt.Sprintf, the path passed to
+// NewRequest or UploadRequest and the value assigned to u.
+func pathLiterals(n ast.Node) []*ast.BasicLit {
+	var exprs []ast.Expr
+
+	switch n := n.(type) {
+	case *ast.CallExpr:
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		switch {
+		case isIdent(sel.X, "fmt") && sel.Sel.Name == "Sprintf" && len(n.Args) > 0:
+			exprs = append(exprs, n.Args[0])
+		case (sel.Sel.Name == "NewRequest" || sel.Sel.Name == "UploadRequest") && len(n.Args) > 1:
+			exprs = append(exprs, n.Args[1])
+		}
+	case *ast.AssignStmt:
+		for i, lhs := range n.Lhs {
+			if isIdent(lhs, "u") && i < len(n.Rhs) {
+				exprs = append(exprs, n.Rhs[i])
+			}
+		}
+	}
+
+	var lits []*ast.BasicLit
+	for _, expr := range exprs {
+		if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			lits = append(lits, lit)
+		}
+	}
+	return lits
+}
+
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// template replaces every segment of the path containing a verb by ":id".
+func template(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if verbPattern.MatchString(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
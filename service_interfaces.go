@@ -0,0 +1,1878 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Code generated by geninterfaces. DO NOT EDIT.
+
+package gitlab
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+)
+
+// AccessRequestsServiceInterface defines all the API methods
+// of the AccessRequestsService.
+type AccessRequestsServiceInterface interface {
+	ListProjectAccessRequests(pid interface{}, opt *ListAccessRequestsOptions, options ...RequestOptionFunc) ([]*AccessRequest, *Response, error)
+	ListGroupAccessRequests(gid interface{}, opt *ListAccessRequestsOptions, options ...RequestOptionFunc) ([]*AccessRequest, *Response, error)
+	RequestProjectAccess(pid interface{}, options ...RequestOptionFunc) (*AccessRequest, *Response, error)
+	RequestGroupAccess(gid interface{}, options ...RequestOptionFunc) (*AccessRequest, *Response, error)
+	ApproveProjectAccessRequest(pid interface{}, user int, opt *ApproveAccessRequestOptions, options ...RequestOptionFunc) (*AccessRequest, *Response, error)
+	ApproveGroupAccessRequest(gid interface{}, user int, opt *ApproveAccessRequestOptions, options ...RequestOptionFunc) (*AccessRequest, *Response, error)
+	DenyProjectAccessRequest(pid interface{}, user int, options ...RequestOptionFunc) (*Response, error)
+	DenyGroupAccessRequest(gid interface{}, user int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ AccessRequestsServiceInterface = (*AccessRequestsService)(nil)
+
+// AppearanceServiceInterface defines all the API methods
+// of the AppearanceService.
+type AppearanceServiceInterface interface {
+	GetAppearance(options ...RequestOptionFunc) (*Appearance, *Response, error)
+	ChangeAppearance(opt *ChangeAppearanceOptions, options ...RequestOptionFunc) (*Appearance, *Response, error)
+}
+
+var _ AppearanceServiceInterface = (*AppearanceService)(nil)
+
+// ApplicationsServiceInterface defines all the API methods
+// of the ApplicationsService.
+type ApplicationsServiceInterface interface {
+	CreateApplication(opt *CreateApplicationOptions, options ...RequestOptionFunc) (*Application, *Response, error)
+	ListApplications(opt *ListApplicationsOptions, options ...RequestOptionFunc) ([]*Application, *Response, error)
+	DeleteApplication(application int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ApplicationsServiceInterface = (*ApplicationsService)(nil)
+
+// AuditEventsServiceInterface defines all the API methods
+// of the AuditEventsService.
+type AuditEventsServiceInterface interface {
+	ListInstanceAuditEvents(opt *ListAuditEventsOptions, options ...RequestOptionFunc) ([]*AuditEvent, *Response, error)
+	GetInstanceAuditEvent(event int, options ...RequestOptionFunc) (*AuditEvent, *Response, error)
+	ListGroupAuditEvents(gid interface{}, opt *ListAuditEventsOptions, options ...RequestOptionFunc) ([]*AuditEvent, *Response, error)
+	GetGroupAuditEvent(gid interface{}, event int, options ...RequestOptionFunc) (*AuditEvent, *Response, error)
+	ListProjectAuditEvents(pid interface{}, opt *ListAuditEventsOptions, options ...RequestOptionFunc) ([]*AuditEvent, *Response, error)
+	GetProjectAuditEvent(pid interface{}, event int, options ...RequestOptionFunc) (*AuditEvent, *Response, error)
+}
+
+var _ AuditEventsServiceInterface = (*AuditEventsService)(nil)
+
+// AvatarRequestsServiceInterface defines all the API methods
+// of the AvatarRequestsService.
+type AvatarRequestsServiceInterface interface {
+	GetAvatar(opt *GetAvatarOptions, options ...RequestOptionFunc) (*Avatar, *Response, error)
+}
+
+var _ AvatarRequestsServiceInterface = (*AvatarRequestsService)(nil)
+
+// AwardEmojiServiceInterface defines all the API methods
+// of the AwardEmojiService.
+type AwardEmojiServiceInterface interface {
+	ListMergeRequestAwardEmoji(pid interface{}, mergeRequestIID int, opt *ListAwardEmojiOptions, options ...RequestOptionFunc) ([]*AwardEmoji, *Response, error)
+	ListIssueAwardEmoji(pid interface{}, issueIID int, opt *ListAwardEmojiOptions, options ...RequestOptionFunc) ([]*AwardEmoji, *Response, error)
+	ListSnippetAwardEmoji(pid interface{}, snippetID int, opt *ListAwardEmojiOptions, options ...RequestOptionFunc) ([]*AwardEmoji, *Response, error)
+	GetMergeRequestAwardEmoji(pid interface{}, mergeRequestIID, awardID int, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	GetIssueAwardEmoji(pid interface{}, issueIID, awardID int, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	GetSnippetAwardEmoji(pid interface{}, snippetID, awardID int, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	CreateMergeRequestAwardEmoji(pid interface{}, mergeRequestIID int, opt *CreateAwardEmojiOptions, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	CreateIssueAwardEmoji(pid interface{}, issueIID int, opt *CreateAwardEmojiOptions, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	CreateSnippetAwardEmoji(pid interface{}, snippetID int, opt *CreateAwardEmojiOptions, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	DeleteIssueAwardEmoji(pid interface{}, issueIID, awardID int, options ...RequestOptionFunc) (*Response, error)
+	DeleteMergeRequestAwardEmoji(pid interface{}, mergeRequestIID, awardID int, options ...RequestOptionFunc) (*Response, error)
+	DeleteSnippetAwardEmoji(pid interface{}, snippetID, awardID int, options ...RequestOptionFunc) (*Response, error)
+	ListIssuesAwardEmojiOnNote(pid interface{}, issueID, noteID int, opt *ListAwardEmojiOptions, options ...RequestOptionFunc) ([]*AwardEmoji, *Response, error)
+	ListMergeRequestAwardEmojiOnNote(pid interface{}, mergeRequestIID, noteID int, opt *ListAwardEmojiOptions, options ...RequestOptionFunc) ([]*AwardEmoji, *Response, error)
+	ListSnippetAwardEmojiOnNote(pid interface{}, snippetIID, noteID int, opt *ListAwardEmojiOptions, options ...RequestOptionFunc) ([]*AwardEmoji, *Response, error)
+	GetIssuesAwardEmojiOnNote(pid interface{}, issueID, noteID, awardID int, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	GetMergeRequestAwardEmojiOnNote(pid interface{}, mergeRequestIID, noteID, awardID int, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	GetSnippetAwardEmojiOnNote(pid interface{}, snippetIID, noteID, awardID int, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	CreateIssuesAwardEmojiOnNote(pid interface{}, issueID, noteID int, opt *CreateAwardEmojiOptions, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	CreateMergeRequestAwardEmojiOnNote(pid interface{}, mergeRequestIID, noteID int, opt *CreateAwardEmojiOptions, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	CreateSnippetAwardEmojiOnNote(pid interface{}, snippetIID, noteID int, opt *CreateAwardEmojiOptions, options ...RequestOptionFunc) (*AwardEmoji, *Response, error)
+	DeleteIssuesAwardEmojiOnNote(pid interface{}, issueID, noteID, awardID int, options ...RequestOptionFunc) (*Response, error)
+	DeleteMergeRequestAwardEmojiOnNote(pid interface{}, mergeRequestIID, noteID, awardID int, options ...RequestOptionFunc) (*Response, error)
+	DeleteSnippetAwardEmojiOnNote(pid interface{}, snippetIID, noteID, awardID int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ AwardEmojiServiceInterface = (*AwardEmojiService)(nil)
+
+// BranchesServiceInterface defines all the API methods of the BranchesService.
+type BranchesServiceInterface interface {
+	ListBranches(pid interface{}, opts *ListBranchesOptions, options ...RequestOptionFunc) ([]*Branch, *Response, error)
+	GetBranch(pid interface{}, branch string, options ...RequestOptionFunc) (*Branch, *Response, error)
+	ProtectBranch(pid interface{}, branch string, opts *ProtectBranchOptions, options ...RequestOptionFunc) (*Branch, *Response, error)
+	UnprotectBranch(pid interface{}, branch string, options ...RequestOptionFunc) (*Branch, *Response, error)
+	CreateBranch(pid interface{}, opt *CreateBranchOptions, options ...RequestOptionFunc) (*Branch, *Response, error)
+	DeleteBranch(pid interface{}, branch string, options ...RequestOptionFunc) (*Response, error)
+	DeleteMergedBranches(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ BranchesServiceInterface = (*BranchesService)(nil)
+
+// BroadcastMessagesServiceInterface defines all the API methods
+// of the BroadcastMessagesService.
+type BroadcastMessagesServiceInterface interface {
+	ListBroadcastMessages(opt *ListBroadcastMessagesOptions, options ...RequestOptionFunc) ([]*BroadcastMessage, *Response, error)
+	GetBroadcastMessage(broadcast int, options ...RequestOptionFunc) (*BroadcastMessage, *Response, error)
+	CreateBroadcastMessage(opt *CreateBroadcastMessageOptions, options ...RequestOptionFunc) (*BroadcastMessage, *Response, error)
+	UpdateBroadcastMessage(broadcast int, opt *UpdateBroadcastMessageOptions, options ...RequestOptionFunc) (*BroadcastMessage, *Response, error)
+	DeleteBroadcastMessage(broadcast int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ BroadcastMessagesServiceInterface = (*BroadcastMessagesService)(nil)
+
+// CIYMLTemplatesServiceInterface defines all the API methods
+// of the CIYMLTemplatesService.
+type CIYMLTemplatesServiceInterface interface {
+	ListAllTemplates(opt *ListCIYMLTemplatesOptions, options ...RequestOptionFunc) ([]*CIYMLTemplateListItem, *Response, error)
+	GetTemplate(key string, options ...RequestOptionFunc) (*CIYMLTemplate, *Response, error)
+}
+
+var _ CIYMLTemplatesServiceInterface = (*CIYMLTemplatesService)(nil)
+
+// ClusterAgentsServiceInterface defines all the API methods
+// of the ClusterAgentsService.
+type ClusterAgentsServiceInterface interface {
+	ListAgents(pid interface{}, opt *ListAgentsOptions, options ...RequestOptionFunc) ([]*Agent, *Response, error)
+	GetAgent(pid interface{}, id int, options ...RequestOptionFunc) (*Agent, *Response, error)
+	RegisterAgent(pid interface{}, opt *RegisterAgentOptions, options ...RequestOptionFunc) (*Agent, *Response, error)
+	DeleteAgent(pid interface{}, id int, options ...RequestOptionFunc) (*Response, error)
+	ListAgentTokens(pid interface{}, aid int, opt *ListAgentTokensOptions, options ...RequestOptionFunc) ([]*AgentToken, *Response, error)
+	GetAgentToken(pid interface{}, aid int, id int, options ...RequestOptionFunc) (*AgentToken, *Response, error)
+	CreateAgentToken(pid interface{}, aid int, opt *CreateAgentTokenOptions, options ...RequestOptionFunc) (*AgentToken, *Response, error)
+	RevokeAgentToken(pid interface{}, aid int, id int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ClusterAgentsServiceInterface = (*ClusterAgentsService)(nil)
+
+// CommitsServiceInterface defines all the API methods of the CommitsService.
+type CommitsServiceInterface interface {
+	NewCommitBuilder(pid interface{}, branch string) *CommitBuilder
+	ListCommits(pid interface{}, opt *ListCommitsOptions, options ...RequestOptionFunc) ([]*Commit, *Response, error)
+	GetCommitRefs(pid interface{}, sha string, opt *GetCommitRefsOptions, options ...RequestOptionFunc) ([]*CommitRef, *Response, error)
+	GetCommit(pid interface{}, sha string, opt *GetCommitOptions, options ...RequestOptionFunc) (*Commit, *Response, error)
+	CreateCommit(pid interface{}, opt *CreateCommitOptions, options ...RequestOptionFunc) (*Commit, *Response, error)
+	GetCommitDiff(pid interface{}, sha string, opt *GetCommitDiffOptions, options ...RequestOptionFunc) ([]*Diff, *Response, error)
+	GetCommitComments(pid interface{}, sha string, opt *GetCommitCommentsOptions, options ...RequestOptionFunc) ([]*CommitComment, *Response, error)
+	PostCommitComment(pid interface{}, sha string, opt *PostCommitCommentOptions, options ...RequestOptionFunc) (*CommitComment, *Response, error)
+	GetCommitStatuses(pid interface{}, sha string, opt *GetCommitStatusesOptions, options ...RequestOptionFunc) ([]*CommitStatus, *Response, error)
+	SetCommitStatus(pid interface{}, sha string, opt *SetCommitStatusOptions, options ...RequestOptionFunc) (*CommitStatus, *Response, error)
+	ListMergeRequestsByCommit(pid interface{}, sha string, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	CherryPickCommit(pid interface{}, sha string, opt *CherryPickCommitOptions, options ...RequestOptionFunc) (*Commit, *Response, error)
+	RevertCommit(pid interface{}, sha string, opt *RevertCommitOptions, options ...RequestOptionFunc) (*Commit, *Response, error)
+	GetGPGSignature(pid interface{}, sha string, options ...RequestOptionFunc) (*GPGSignature, *Response, error)
+}
+
+var _ CommitsServiceInterface = (*CommitsService)(nil)
+
+// ContainerRegistryServiceInterface defines all the API methods
+// of the ContainerRegistryService.
+type ContainerRegistryServiceInterface interface {
+	ListProjectRegistryRepositories(pid interface{}, opt *ListRegistryRepositoriesOptions, options ...RequestOptionFunc) ([]*RegistryRepository, *Response, error)
+	ListGroupRegistryRepositories(gid interface{}, opt *ListRegistryRepositoriesOptions, options ...RequestOptionFunc) ([]*RegistryRepository, *Response, error)
+	GetSingleRegistryRepository(pid interface{}, opt *GetSingleRegistryRepositoryOptions, options ...RequestOptionFunc) (*RegistryRepository, *Response, error)
+	DeleteRegistryRepository(pid interface{}, repository int, options ...RequestOptionFunc) (*Response, error)
+	ListRegistryRepositoryTags(pid interface{}, repository int, opt *ListRegistryRepositoryTagsOptions, options ...RequestOptionFunc) ([]*RegistryRepositoryTag, *Response, error)
+	GetRegistryRepositoryTagDetail(pid interface{}, repository int, tagName string, options ...RequestOptionFunc) (*RegistryRepositoryTag, *Response, error)
+	DeleteRegistryRepositoryTag(pid interface{}, repository int, tagName string, options ...RequestOptionFunc) (*Response, error)
+	DeleteRegistryRepositoryTags(pid interface{}, repository int, opt *DeleteRegistryRepositoryTagsOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ContainerRegistryServiceInterface = (*ContainerRegistryService)(nil)
+
+// CustomAttributesServiceInterface defines all the API methods
+// of the CustomAttributesService.
+type CustomAttributesServiceInterface interface {
+	ListCustomUserAttributes(user int, options ...RequestOptionFunc) ([]*CustomAttribute, *Response, error)
+	ListCustomGroupAttributes(group int, options ...RequestOptionFunc) ([]*CustomAttribute, *Response, error)
+	ListCustomProjectAttributes(project int, options ...RequestOptionFunc) ([]*CustomAttribute, *Response, error)
+	GetCustomUserAttribute(user int, key string, options ...RequestOptionFunc) (*CustomAttribute, *Response, error)
+	GetCustomGroupAttribute(group int, key string, options ...RequestOptionFunc) (*CustomAttribute, *Response, error)
+	GetCustomProjectAttribute(project int, key string, options ...RequestOptionFunc) (*CustomAttribute, *Response, error)
+	SetCustomUserAttribute(user int, c CustomAttribute, options ...RequestOptionFunc) (*CustomAttribute, *Response, error)
+	SetCustomGroupAttribute(group int, c CustomAttribute, options ...RequestOptionFunc) (*CustomAttribute, *Response, error)
+	SetCustomProjectAttribute(project int, c CustomAttribute, options ...RequestOptionFunc) (*CustomAttribute, *Response, error)
+	DeleteCustomUserAttribute(user int, key string, options ...RequestOptionFunc) (*Response, error)
+	DeleteCustomGroupAttribute(group int, key string, options ...RequestOptionFunc) (*Response, error)
+	DeleteCustomProjectAttribute(project int, key string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ CustomAttributesServiceInterface = (*CustomAttributesService)(nil)
+
+// DORAMetricsServiceInterface defines all the API methods
+// of the DORAMetricsService.
+type DORAMetricsServiceInterface interface {
+	GetProjectDORAMetrics(pid interface{}, opt GetDORAMetricsOptions, options ...RequestOptionFunc) ([]DORAMetric, *Response, error)
+	GetGroupDORAMetrics(gid interface{}, opt GetDORAMetricsOptions, options ...RequestOptionFunc) ([]DORAMetric, *Response, error)
+}
+
+var _ DORAMetricsServiceInterface = (*DORAMetricsService)(nil)
+
+// DependencyListExportServiceInterface defines all the API methods
+// of the DependencyListExportService.
+type DependencyListExportServiceInterface interface {
+	CreateDependencyListExport(pipelineID int, opt *CreateDependencyListExportOptions, options ...RequestOptionFunc) (*DependencyListExport, *Response, error)
+	GetDependencyListExport(id int, options ...RequestOptionFunc) (*DependencyListExport, *Response, error)
+	DownloadDependencyListExport(id int, options ...RequestOptionFunc) (io.Reader, *Response, error)
+}
+
+var _ DependencyListExportServiceInterface = (*DependencyListExportService)(nil)
+
+// DeployKeysServiceInterface defines all the API methods
+// of the DeployKeysService.
+type DeployKeysServiceInterface interface {
+	ListAllDeployKeys(opt *ListInstanceDeployKeysOptions, options ...RequestOptionFunc) ([]*InstanceDeployKey, *Response, error)
+	ListProjectDeployKeys(pid interface{}, opt *ListProjectDeployKeysOptions, options ...RequestOptionFunc) ([]*ProjectDeployKey, *Response, error)
+	GetDeployKey(pid interface{}, deployKey int, options ...RequestOptionFunc) (*ProjectDeployKey, *Response, error)
+	AddDeployKey(pid interface{}, opt *AddDeployKeyOptions, options ...RequestOptionFunc) (*ProjectDeployKey, *Response, error)
+	DeleteDeployKey(pid interface{}, deployKey int, options ...RequestOptionFunc) (*Response, error)
+	EnableDeployKey(pid interface{}, deployKey int, options ...RequestOptionFunc) (*ProjectDeployKey, *Response, error)
+	UpdateDeployKey(pid interface{}, deployKey int, opt *UpdateDeployKeyOptions, options ...RequestOptionFunc) (*ProjectDeployKey, *Response, error)
+}
+
+var _ DeployKeysServiceInterface = (*DeployKeysService)(nil)
+
+// DeployTokensServiceInterface defines all the API methods
+// of the DeployTokensService.
+type DeployTokensServiceInterface interface {
+	ListAllDeployTokens(options ...RequestOptionFunc) ([]*DeployToken, *Response, error)
+	ListProjectDeployTokens(pid interface{}, opt *ListProjectDeployTokensOptions, options ...RequestOptionFunc) ([]*DeployToken, *Response, error)
+	GetProjectDeployToken(pid interface{}, deployToken int, options ...RequestOptionFunc) (*DeployToken, *Response, error)
+	CreateProjectDeployToken(pid interface{}, opt *CreateProjectDeployTokenOptions, options ...RequestOptionFunc) (*DeployToken, *Response, error)
+	DeleteProjectDeployToken(pid interface{}, deployToken int, options ...RequestOptionFunc) (*Response, error)
+	ListGroupDeployTokens(gid interface{}, opt *ListGroupDeployTokensOptions, options ...RequestOptionFunc) ([]*DeployToken, *Response, error)
+	GetGroupDeployToken(gid interface{}, deployToken int, options ...RequestOptionFunc) (*DeployToken, *Response, error)
+	CreateGroupDeployToken(gid interface{}, opt *CreateGroupDeployTokenOptions, options ...RequestOptionFunc) (*DeployToken, *Response, error)
+	DeleteGroupDeployToken(gid interface{}, deployToken int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ DeployTokensServiceInterface = (*DeployTokensService)(nil)
+
+// DeploymentMergeRequestsServiceInterface defines all the API methods
+// of the DeploymentMergeRequestsService.
+type DeploymentMergeRequestsServiceInterface interface {
+	ListDeploymentMergeRequests(pid interface{}, deployment int, opts *ListMergeRequestsOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+}
+
+var _ DeploymentMergeRequestsServiceInterface = (*DeploymentMergeRequestsService)(nil)
+
+// DeploymentsServiceInterface defines all the API methods
+// of the DeploymentsService.
+type DeploymentsServiceInterface interface {
+	ListProjectDeployments(pid interface{}, opts *ListProjectDeploymentsOptions, options ...RequestOptionFunc) ([]*Deployment, *Response, error)
+	GetProjectDeployment(pid interface{}, deployment int, options ...RequestOptionFunc) (*Deployment, *Response, error)
+	CreateProjectDeployment(pid interface{}, opt *CreateProjectDeploymentOptions, options ...RequestOptionFunc) (*Deployment, *Response, error)
+	UpdateProjectDeployment(pid interface{}, deployment int, opt *UpdateProjectDeploymentOptions, options ...RequestOptionFunc) (*Deployment, *Response, error)
+	ApproveOrRejectProjectDeployment(pid interface{}, deployment int,
+		opt *ApproveOrRejectProjectDeploymentOptions, options ...RequestOptionFunc,
+	) (*Response, error)
+	DeleteProjectDeployment(pid interface{}, deployment int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ DeploymentsServiceInterface = (*DeploymentsService)(nil)
+
+// DiscussionsServiceInterface defines all the API methods
+// of the DiscussionsService.
+type DiscussionsServiceInterface interface {
+	ListIssueDiscussions(pid interface{}, issue int, opt *ListIssueDiscussionsOptions, options ...RequestOptionFunc) ([]*Discussion, *Response, error)
+	GetIssueDiscussion(pid interface{}, issue int, discussion string, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	CreateIssueDiscussion(pid interface{}, issue int, opt *CreateIssueDiscussionOptions, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	AddIssueDiscussionNote(pid interface{}, issue int, discussion string, opt *AddIssueDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateIssueDiscussionNote(pid interface{}, issue int, discussion string, note int, opt *UpdateIssueDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteIssueDiscussionNote(pid interface{}, issue int, discussion string, note int, options ...RequestOptionFunc) (*Response, error)
+	ListSnippetDiscussions(pid interface{}, snippet int, opt *ListSnippetDiscussionsOptions, options ...RequestOptionFunc) ([]*Discussion, *Response, error)
+	GetSnippetDiscussion(pid interface{}, snippet int, discussion string, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	CreateSnippetDiscussion(pid interface{}, snippet int, opt *CreateSnippetDiscussionOptions, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	AddSnippetDiscussionNote(pid interface{}, snippet int, discussion string, opt *AddSnippetDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateSnippetDiscussionNote(pid interface{}, snippet int, discussion string, note int, opt *UpdateSnippetDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteSnippetDiscussionNote(pid interface{}, snippet int, discussion string, note int, options ...RequestOptionFunc) (*Response, error)
+	ListGroupEpicDiscussions(gid interface{}, epic int, opt *ListGroupEpicDiscussionsOptions, options ...RequestOptionFunc) ([]*Discussion, *Response, error)
+	GetEpicDiscussion(gid interface{}, epic int, discussion string, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	CreateEpicDiscussion(gid interface{}, epic int, opt *CreateEpicDiscussionOptions, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	AddEpicDiscussionNote(gid interface{}, epic int, discussion string, opt *AddEpicDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateEpicDiscussionNote(gid interface{}, epic int, discussion string, note int, opt *UpdateEpicDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteEpicDiscussionNote(gid interface{}, epic int, discussion string, note int, options ...RequestOptionFunc) (*Response, error)
+	ListMergeRequestDiscussions(pid interface{}, mergeRequest int, opt *ListMergeRequestDiscussionsOptions, options ...RequestOptionFunc) ([]*Discussion, *Response, error)
+	GetMergeRequestDiscussion(pid interface{}, mergeRequest int, discussion string, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	CreateMergeRequestDiscussion(pid interface{}, mergeRequest int, opt *CreateMergeRequestDiscussionOptions, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	ResolveMergeRequestDiscussion(pid interface{}, mergeRequest int, discussion string, opt *ResolveMergeRequestDiscussionOptions, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	AddMergeRequestDiscussionNote(pid interface{}, mergeRequest int, discussion string, opt *AddMergeRequestDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateMergeRequestDiscussionNote(pid interface{}, mergeRequest int, discussion string, note int, opt *UpdateMergeRequestDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteMergeRequestDiscussionNote(pid interface{}, mergeRequest int, discussion string, note int, options ...RequestOptionFunc) (*Response, error)
+	ListCommitDiscussions(pid interface{}, commit string, opt *ListCommitDiscussionsOptions, options ...RequestOptionFunc) ([]*Discussion, *Response, error)
+	GetCommitDiscussion(pid interface{}, commit string, discussion string, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	CreateCommitDiscussion(pid interface{}, commit string, opt *CreateCommitDiscussionOptions, options ...RequestOptionFunc) (*Discussion, *Response, error)
+	AddCommitDiscussionNote(pid interface{}, commit string, discussion string, opt *AddCommitDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateCommitDiscussionNote(pid interface{}, commit string, discussion string, note int, opt *UpdateCommitDiscussionNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteCommitDiscussionNote(pid interface{}, commit string, discussion string, note int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ DiscussionsServiceInterface = (*DiscussionsService)(nil)
+
+// DockerfileTemplatesServiceInterface defines all the API methods
+// of the DockerfileTemplatesService.
+type DockerfileTemplatesServiceInterface interface {
+	ListTemplates(opt *ListDockerfileTemplatesOptions, options ...RequestOptionFunc) ([]*DockerfileTemplateListItem, *Response, error)
+	GetTemplate(key string, options ...RequestOptionFunc) (*DockerfileTemplate, *Response, error)
+}
+
+var _ DockerfileTemplatesServiceInterface = (*DockerfileTemplatesService)(nil)
+
+// DraftNotesServiceInterface defines all the API methods
+// of the DraftNotesService.
+type DraftNotesServiceInterface interface {
+	ListDraftNotes(pid interface{}, mergeRequest int, opt *ListDraftNotesOptions, options ...RequestOptionFunc) ([]*DraftNote, *Response, error)
+	GetDraftNote(pid interface{}, mergeRequest int, note int, options ...RequestOptionFunc) (*DraftNote, *Response, error)
+	CreateDraftNote(pid interface{}, mergeRequest int, opt *CreateDraftNoteOptions, options ...RequestOptionFunc) (*DraftNote, *Response, error)
+	UpdateDraftNote(pid interface{}, mergeRequest int, note int, opt *UpdateDraftNoteOptions, options ...RequestOptionFunc) (*DraftNote, *Response, error)
+	DeleteDraftNote(pid interface{}, mergeRequest int, note int, options ...RequestOptionFunc) (*Response, error)
+	PublishDraftNote(pid interface{}, mergeRequest int, note int, options ...RequestOptionFunc) (*Response, error)
+	PublishAllDraftNotes(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ DraftNotesServiceInterface = (*DraftNotesService)(nil)
+
+// EnvironmentsServiceInterface defines all the API methods
+// of the EnvironmentsService.
+type EnvironmentsServiceInterface interface {
+	ListEnvironments(pid interface{}, opts *ListEnvironmentsOptions, options ...RequestOptionFunc) ([]*Environment, *Response, error)
+	GetEnvironment(pid interface{}, environment int, options ...RequestOptionFunc) (*Environment, *Response, error)
+	CreateEnvironment(pid interface{}, opt *CreateEnvironmentOptions, options ...RequestOptionFunc) (*Environment, *Response, error)
+	EditEnvironment(pid interface{}, environment int, opt *EditEnvironmentOptions, options ...RequestOptionFunc) (*Environment, *Response, error)
+	DeleteEnvironment(pid interface{}, environment int, options ...RequestOptionFunc) (*Response, error)
+	StopEnvironment(pid interface{}, environmentID int, opt *StopEnvironmentOptions, options ...RequestOptionFunc) (*Environment, *Response, error)
+}
+
+var _ EnvironmentsServiceInterface = (*EnvironmentsService)(nil)
+
+// EpicIssuesServiceInterface defines all the API methods
+// of the EpicIssuesService.
+type EpicIssuesServiceInterface interface {
+	ListEpicIssues(gid interface{}, epic int, opt *ListOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	AssignEpicIssue(gid interface{}, epic, issue int, options ...RequestOptionFunc) (*EpicIssueAssignment, *Response, error)
+	RemoveEpicIssue(gid interface{}, epic, epicIssue int, options ...RequestOptionFunc) (*EpicIssueAssignment, *Response, error)
+	UpdateEpicIssueAssignment(gid interface{}, epic, epicIssue int, opt *UpdateEpicIsssueAssignmentOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+}
+
+var _ EpicIssuesServiceInterface = (*EpicIssuesService)(nil)
+
+// EpicsServiceInterface defines all the API methods of the EpicsService.
+type EpicsServiceInterface interface {
+	ListGroupEpics(gid interface{}, opt *ListGroupEpicsOptions, options ...RequestOptionFunc) ([]*Epic, *Response, error)
+	GetEpic(gid interface{}, epic int, options ...RequestOptionFunc) (*Epic, *Response, error)
+	GetEpicLinks(gid interface{}, epic int, options ...RequestOptionFunc) ([]*Epic, *Response, error)
+	CreateEpic(gid interface{}, opt *CreateEpicOptions, options ...RequestOptionFunc) (*Epic, *Response, error)
+	UpdateEpic(gid interface{}, epic int, opt *UpdateEpicOptions, options ...RequestOptionFunc) (*Epic, *Response, error)
+	DeleteEpic(gid interface{}, epic int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ EpicsServiceInterface = (*EpicsService)(nil)
+
+// ErrorTrackingServiceInterface defines all the API methods
+// of the ErrorTrackingService.
+type ErrorTrackingServiceInterface interface {
+	GetErrorTrackingSettings(pid interface{}, options ...RequestOptionFunc) (*ErrorTrackingSettings, *Response, error)
+	EnableDisableErrorTracking(pid interface{}, opt *EnableDisableErrorTrackingOptions, options ...RequestOptionFunc) (*ErrorTrackingSettings, *Response, error)
+	ListClientKeys(pid interface{}, opt *ListClientKeysOptions, options ...RequestOptionFunc) ([]*ErrorTrackingClientKey, *Response, error)
+	CreateClientKey(pid interface{}, options ...RequestOptionFunc) (*ErrorTrackingClientKey, *Response, error)
+	DeleteClientKey(pid interface{}, keyID int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ErrorTrackingServiceInterface = (*ErrorTrackingService)(nil)
+
+// EventsServiceInterface defines all the API methods of the EventsService.
+type EventsServiceInterface interface {
+	ListCurrentUserContributionEvents(opt *ListContributionEventsOptions, options ...RequestOptionFunc) ([]*ContributionEvent, *Response, error)
+	ListProjectVisibleEvents(pid interface{}, opt *ListProjectVisibleEventsOptions, options ...RequestOptionFunc) ([]*ProjectEvent, *Response, error)
+}
+
+var _ EventsServiceInterface = (*EventsService)(nil)
+
+// ExternalStatusChecksServiceInterface defines all the API methods
+// of the ExternalStatusChecksService.
+type ExternalStatusChecksServiceInterface interface {
+	ListMergeStatusChecks(pid interface{}, mr int, opt *ListOptions, options ...RequestOptionFunc) ([]*MergeStatusCheck, *Response, error)
+	SetExternalStatusCheckStatus(pid interface{}, mergeRequest int, opt *SetExternalStatusCheckStatusOptions, options ...RequestOptionFunc) (*Response, error)
+	ListProjectStatusChecks(pid interface{}, opt *ListOptions, options ...RequestOptionFunc) ([]*ProjectStatusCheck, *Response, error)
+	CreateExternalStatusCheck(pid interface{}, opt *CreateExternalStatusCheckOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteExternalStatusCheck(pid interface{}, check int, options ...RequestOptionFunc) (*Response, error)
+	UpdateExternalStatusCheck(pid interface{}, check int, opt *UpdateExternalStatusCheckOptions, options ...RequestOptionFunc) (*Response, error)
+	RetryFailedStatusCheckForAMergeRequest(pid interface{}, mergeRequest int, externalStatusCheck int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ExternalStatusChecksServiceInterface = (*ExternalStatusChecksService)(nil)
+
+// FeaturesServiceInterface defines all the API methods of the FeaturesService.
+type FeaturesServiceInterface interface {
+	ListFeatures(options ...RequestOptionFunc) ([]*Feature, *Response, error)
+	SetFeatureFlag(name string, value interface{}, options ...RequestOptionFunc) (*Feature, *Response, error)
+}
+
+var _ FeaturesServiceInterface = (*FeaturesService)(nil)
+
+// FreezePeriodsServiceInterface defines all the API methods
+// of the FreezePeriodsService.
+type FreezePeriodsServiceInterface interface {
+	ListFreezePeriods(pid interface{}, opt *ListFreezePeriodsOptions, options ...RequestOptionFunc) ([]*FreezePeriod, *Response, error)
+	GetFreezePeriod(pid interface{}, freezePeriod int, options ...RequestOptionFunc) (*FreezePeriod, *Response, error)
+	CreateFreezePeriodOptions(pid interface{}, opt *CreateFreezePeriodOptions, options ...RequestOptionFunc) (*FreezePeriod, *Response, error)
+	UpdateFreezePeriodOptions(pid interface{}, freezePeriod int, opt *UpdateFreezePeriodOptions, options ...RequestOptionFunc) (*FreezePeriod, *Response, error)
+	DeleteFreezePeriod(pid interface{}, freezePeriod int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ FreezePeriodsServiceInterface = (*FreezePeriodsService)(nil)
+
+// GenericPackagesServiceInterface defines all the API methods
+// of the GenericPackagesService.
+type GenericPackagesServiceInterface interface {
+	FormatPackageURL(pid interface{}, packageName, packageVersion, fileName string) (string, error)
+	PublishPackageFile(pid interface{}, packageName, packageVersion, fileName string, content io.Reader, opt *PublishPackageFileOptions, options ...RequestOptionFunc) (*GenericPackagesFile, *Response, error)
+	DownloadPackageFile(pid interface{}, packageName, packageVersion, fileName string, options ...RequestOptionFunc) ([]byte, *Response, error)
+}
+
+var _ GenericPackagesServiceInterface = (*GenericPackagesService)(nil)
+
+// GeoNodesServiceInterface defines all the API methods of the GeoNodesService.
+type GeoNodesServiceInterface interface {
+	CreateGeoNode(opt *CreateGeoNodesOptions, options ...RequestOptionFunc) (*GeoNode, *Response, error)
+	ListGeoNodes(opt *ListGeoNodesOptions, options ...RequestOptionFunc) ([]*GeoNode, *Response, error)
+	GetGeoNode(id int, options ...RequestOptionFunc) (*GeoNode, *Response, error)
+	EditGeoNode(id int, opt *UpdateGeoNodesOptions, options ...RequestOptionFunc) (*GeoNode, *Response, error)
+	DeleteGeoNode(id int, options ...RequestOptionFunc) (*Response, error)
+	RepairGeoNode(id int, options ...RequestOptionFunc) (*GeoNode, *Response, error)
+	RetrieveStatusOfAllGeoNodes(options ...RequestOptionFunc) ([]*GeoNodeStatus, *Response, error)
+	RetrieveStatusOfGeoNode(id int, options ...RequestOptionFunc) (*GeoNodeStatus, *Response, error)
+}
+
+var _ GeoNodesServiceInterface = (*GeoNodesService)(nil)
+
+// GitIgnoreTemplatesServiceInterface defines all the API methods
+// of the GitIgnoreTemplatesService.
+type GitIgnoreTemplatesServiceInterface interface {
+	ListTemplates(opt *ListTemplatesOptions, options ...RequestOptionFunc) ([]*GitIgnoreTemplateListItem, *Response, error)
+	GetTemplate(key string, options ...RequestOptionFunc) (*GitIgnoreTemplate, *Response, error)
+}
+
+var _ GitIgnoreTemplatesServiceInterface = (*GitIgnoreTemplatesService)(nil)
+
+// GroupAccessTokensServiceInterface defines all the API methods
+// of the GroupAccessTokensService.
+type GroupAccessTokensServiceInterface interface {
+	ListGroupAccessTokens(gid interface{}, opt *ListGroupAccessTokensOptions, options ...RequestOptionFunc) ([]*GroupAccessToken, *Response, error)
+	GetGroupAccessToken(gid interface{}, id int, options ...RequestOptionFunc) (*GroupAccessToken, *Response, error)
+	CreateGroupAccessToken(gid interface{}, opt *CreateGroupAccessTokenOptions, options ...RequestOptionFunc) (*GroupAccessToken, *Response, error)
+	RotateGroupAccessToken(gid interface{}, id int, opt *RotateGroupAccessTokenOptions, options ...RequestOptionFunc) (*GroupAccessToken, *Response, error)
+	RevokeGroupAccessToken(gid interface{}, id int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupAccessTokensServiceInterface = (*GroupAccessTokensService)(nil)
+
+// GroupBadgesServiceInterface defines all the API methods
+// of the GroupBadgesService.
+type GroupBadgesServiceInterface interface {
+	ListGroupBadges(gid interface{}, opt *ListGroupBadgesOptions, options ...RequestOptionFunc) ([]*GroupBadge, *Response, error)
+	GetGroupBadge(gid interface{}, badge int, options ...RequestOptionFunc) (*GroupBadge, *Response, error)
+	AddGroupBadge(gid interface{}, opt *AddGroupBadgeOptions, options ...RequestOptionFunc) (*GroupBadge, *Response, error)
+	EditGroupBadge(gid interface{}, badge int, opt *EditGroupBadgeOptions, options ...RequestOptionFunc) (*GroupBadge, *Response, error)
+	DeleteGroupBadge(gid interface{}, badge int, options ...RequestOptionFunc) (*Response, error)
+	PreviewGroupBadge(gid interface{}, opt *GroupBadgePreviewOptions, options ...RequestOptionFunc) (*GroupBadge, *Response, error)
+}
+
+var _ GroupBadgesServiceInterface = (*GroupBadgesService)(nil)
+
+// GroupClustersServiceInterface defines all the API methods
+// of the GroupClustersService.
+type GroupClustersServiceInterface interface {
+	ListClusters(pid interface{}, options ...RequestOptionFunc) ([]*GroupCluster, *Response, error)
+	GetCluster(pid interface{}, cluster int, options ...RequestOptionFunc) (*GroupCluster, *Response, error)
+	AddCluster(pid interface{}, opt *AddGroupClusterOptions, options ...RequestOptionFunc) (*GroupCluster, *Response, error)
+	EditCluster(pid interface{}, cluster int, opt *EditGroupClusterOptions, options ...RequestOptionFunc) (*GroupCluster, *Response, error)
+	DeleteCluster(pid interface{}, cluster int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupClustersServiceInterface = (*GroupClustersService)(nil)
+
+// GroupEpicBoardsServiceInterface defines all the API methods
+// of the GroupEpicBoardsService.
+type GroupEpicBoardsServiceInterface interface {
+	ListGroupEpicBoards(gid interface{}, opt *ListGroupEpicBoardsOptions, options ...RequestOptionFunc) ([]*GroupEpicBoard, *Response, error)
+	GetGroupEpicBoard(gid interface{}, board int, options ...RequestOptionFunc) (*GroupEpicBoard, *Response, error)
+}
+
+var _ GroupEpicBoardsServiceInterface = (*GroupEpicBoardsService)(nil)
+
+// GroupImportExportServiceInterface defines all the API methods
+// of the GroupImportExportService.
+type GroupImportExportServiceInterface interface {
+	ScheduleExport(gid interface{}, options ...RequestOptionFunc) (*Response, error)
+	ExportDownload(gid interface{}, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+	StreamExportDownload(gid interface{}, w io.Writer, options ...RequestOptionFunc) (*Response, error)
+	ImportFile(opt *GroupImportFileOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupImportExportServiceInterface = (*GroupImportExportService)(nil)
+
+// GroupIssueBoardsServiceInterface defines all the API methods
+// of the GroupIssueBoardsService.
+type GroupIssueBoardsServiceInterface interface {
+	ListGroupIssueBoards(gid interface{}, opt *ListGroupIssueBoardsOptions, options ...RequestOptionFunc) ([]*GroupIssueBoard, *Response, error)
+	CreateGroupIssueBoard(gid interface{}, opt *CreateGroupIssueBoardOptions, options ...RequestOptionFunc) (*GroupIssueBoard, *Response, error)
+	GetGroupIssueBoard(gid interface{}, board int, options ...RequestOptionFunc) (*GroupIssueBoard, *Response, error)
+	UpdateIssueBoard(gid interface{}, board int, opt *UpdateGroupIssueBoardOptions, options ...RequestOptionFunc) (*GroupIssueBoard, *Response, error)
+	DeleteIssueBoard(gid interface{}, board int, options ...RequestOptionFunc) (*Response, error)
+	ListGroupIssueBoardLists(gid interface{}, board int, opt *ListGroupIssueBoardListsOptions, options ...RequestOptionFunc) ([]*BoardList, *Response, error)
+	GetGroupIssueBoardList(gid interface{}, board, list int, options ...RequestOptionFunc) (*BoardList, *Response, error)
+	CreateGroupIssueBoardList(gid interface{}, board int, opt *CreateGroupIssueBoardListOptions, options ...RequestOptionFunc) (*BoardList, *Response, error)
+	UpdateIssueBoardList(gid interface{}, board, list int, opt *UpdateGroupIssueBoardListOptions, options ...RequestOptionFunc) ([]*BoardList, *Response, error)
+	DeleteGroupIssueBoardList(gid interface{}, board, list int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupIssueBoardsServiceInterface = (*GroupIssueBoardsService)(nil)
+
+// GroupIterationsServiceInterface defines all the API methods
+// of the GroupIterationsService.
+type GroupIterationsServiceInterface interface {
+	ListGroupIterations(gid interface{}, opt *ListGroupIterationsOptions, options ...RequestOptionFunc) ([]*GroupIteration, *Response, error)
+}
+
+var _ GroupIterationsServiceInterface = (*GroupIterationsService)(nil)
+
+// GroupLabelsServiceInterface defines all the API methods
+// of the GroupLabelsService.
+type GroupLabelsServiceInterface interface {
+	ListGroupLabels(gid interface{}, opt *ListGroupLabelsOptions, options ...RequestOptionFunc) ([]*GroupLabel, *Response, error)
+	GetGroupLabel(gid interface{}, lid interface{}, options ...RequestOptionFunc) (*GroupLabel, *Response, error)
+	CreateGroupLabel(gid interface{}, opt *CreateGroupLabelOptions, options ...RequestOptionFunc) (*GroupLabel, *Response, error)
+	DeleteGroupLabel(gid interface{}, lid interface{}, opt *DeleteGroupLabelOptions, options ...RequestOptionFunc) (*Response, error)
+	UpdateGroupLabel(gid interface{}, lid interface{}, opt *UpdateGroupLabelOptions, options ...RequestOptionFunc) (*GroupLabel, *Response, error)
+	SubscribeToGroupLabel(gid interface{}, lid interface{}, options ...RequestOptionFunc) (*GroupLabel, *Response, error)
+	UnsubscribeFromGroupLabel(gid interface{}, lid interface{}, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupLabelsServiceInterface = (*GroupLabelsService)(nil)
+
+// GroupMembersServiceInterface defines all the API methods
+// of the GroupMembersService.
+type GroupMembersServiceInterface interface {
+	GetGroupMember(gid interface{}, user int, options ...RequestOptionFunc) (*GroupMember, *Response, error)
+	GetInheritedGroupMember(gid interface{}, user int, options ...RequestOptionFunc) (*GroupMember, *Response, error)
+	AddGroupMember(gid interface{}, opt *AddGroupMemberOptions, options ...RequestOptionFunc) (*GroupMember, *Response, error)
+	ShareWithGroup(gid interface{}, opt *ShareWithGroupOptions, options ...RequestOptionFunc) (*Group, *Response, error)
+	DeleteShareWithGroup(gid interface{}, groupID int, options ...RequestOptionFunc) (*Response, error)
+	EditGroupMember(gid interface{}, user int, opt *EditGroupMemberOptions, options ...RequestOptionFunc) (*GroupMember, *Response, error)
+	RemoveGroupMember(gid interface{}, user int, opt *RemoveGroupMemberOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupMembersServiceInterface = (*GroupMembersService)(nil)
+
+// GroupMilestonesServiceInterface defines all the API methods
+// of the GroupMilestonesService.
+type GroupMilestonesServiceInterface interface {
+	ListGroupMilestones(gid interface{}, opt *ListGroupMilestonesOptions, options ...RequestOptionFunc) ([]*GroupMilestone, *Response, error)
+	GetGroupMilestone(gid interface{}, milestone int, options ...RequestOptionFunc) (*GroupMilestone, *Response, error)
+	CreateGroupMilestone(gid interface{}, opt *CreateGroupMilestoneOptions, options ...RequestOptionFunc) (*GroupMilestone, *Response, error)
+	UpdateGroupMilestone(gid interface{}, milestone int, opt *UpdateGroupMilestoneOptions, options ...RequestOptionFunc) (*GroupMilestone, *Response, error)
+	DeleteGroupMilestone(pid interface{}, milestone int, options ...RequestOptionFunc) (*Response, error)
+	GetGroupMilestoneIssues(gid interface{}, milestone int, opt *GetGroupMilestoneIssuesOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	GetGroupMilestoneMergeRequests(gid interface{}, milestone int, opt *GetGroupMilestoneMergeRequestsOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	GetGroupMilestoneBurndownChartEvents(gid interface{}, milestone int, opt *GetGroupMilestoneBurndownChartEventsOptions, options ...RequestOptionFunc) ([]*BurndownChartEvent, *Response, error)
+}
+
+var _ GroupMilestonesServiceInterface = (*GroupMilestonesService)(nil)
+
+// GroupProtectedEnvironmentsServiceInterface defines all the API methods
+// of the GroupProtectedEnvironmentsService.
+type GroupProtectedEnvironmentsServiceInterface interface {
+	ListGroupProtectedEnvironments(gid interface{}, opt *ListGroupProtectedEnvironmentsOptions, options ...RequestOptionFunc) ([]*GroupProtectedEnvironment, *Response, error)
+	GetGroupProtectedEnvironment(gid interface{}, environment string, options ...RequestOptionFunc) (*GroupProtectedEnvironment, *Response, error)
+	ProtectGroupEnvironment(gid interface{}, opt *ProtectGroupEnvironmentOptions, options ...RequestOptionFunc) (*GroupProtectedEnvironment, *Response, error)
+	UpdateGroupProtectedEnvironment(gid interface{}, environment string, opt *UpdateGroupProtectedEnvironmentOptions, options ...RequestOptionFunc) (*GroupProtectedEnvironment, *Response, error)
+	UnprotectGroupEnvironment(gid interface{}, environment string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupProtectedEnvironmentsServiceInterface = (*GroupProtectedEnvironmentsService)(nil)
+
+// GroupRepositoryStorageMoveServiceInterface defines all the API methods
+// of the GroupRepositoryStorageMoveService.
+type GroupRepositoryStorageMoveServiceInterface interface {
+	RetrieveAllStorageMoves(opts RetrieveAllGroupStorageMovesOptions, options ...RequestOptionFunc) ([]*GroupRepositoryStorageMove, *Response, error)
+	RetrieveAllStorageMovesForGroup(group int, opts RetrieveAllGroupStorageMovesOptions, options ...RequestOptionFunc) ([]*GroupRepositoryStorageMove, *Response, error)
+	GetStorageMove(repositoryStorage int, options ...RequestOptionFunc) (*GroupRepositoryStorageMove, *Response, error)
+	GetStorageMoveForGroup(group int, repositoryStorage int, options ...RequestOptionFunc) (*GroupRepositoryStorageMove, *Response, error)
+	ScheduleStorageMoveForGroup(group int, opts ScheduleStorageMoveForGroupOptions, options ...RequestOptionFunc) (*GroupRepositoryStorageMove, *Response, error)
+	ScheduleAllStorageMoves(opts ScheduleAllGroupStorageMovesOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupRepositoryStorageMoveServiceInterface = (*GroupRepositoryStorageMoveService)(nil)
+
+// GroupSSHCertificatesServiceInterface defines all the API methods
+// of the GroupSSHCertificatesService.
+type GroupSSHCertificatesServiceInterface interface {
+	ListGroupSSHCertificates(gid interface{}, options ...RequestOptionFunc) ([]*GroupSSHCertificate, *Response, error)
+	CreateGroupSSHCertificate(gid interface{}, opt *CreateGroupSSHCertificateOptions, options ...RequestOptionFunc) (*GroupSSHCertificate, *Response, error)
+	DeleteGroupSSHCertificate(gid interface{}, cert int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupSSHCertificatesServiceInterface = (*GroupSSHCertificatesService)(nil)
+
+// GroupVariablesServiceInterface defines all the API methods
+// of the GroupVariablesService.
+type GroupVariablesServiceInterface interface {
+	ListVariables(gid interface{}, opt *ListGroupVariablesOptions, options ...RequestOptionFunc) ([]*GroupVariable, *Response, error)
+	GetVariable(gid interface{}, key string, opt *GetGroupVariableOptions, options ...RequestOptionFunc) (*GroupVariable, *Response, error)
+	CreateVariable(gid interface{}, opt *CreateGroupVariableOptions, options ...RequestOptionFunc) (*GroupVariable, *Response, error)
+	UpdateVariable(gid interface{}, key string, opt *UpdateGroupVariableOptions, options ...RequestOptionFunc) (*GroupVariable, *Response, error)
+	RemoveVariable(gid interface{}, key string, options ...RequestOptionFunc) (*Response, error)
+	ExportVariables(gid interface{}, options ...RequestOptionFunc) (*VariableDocument, *Response, error)
+	ImportVariables(gid interface{}, doc *VariableDocument, opt *ImportVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error)
+	SyncVariables(gid interface{}, desired []*VariableSpec, opt *SyncVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error)
+}
+
+var _ GroupVariablesServiceInterface = (*GroupVariablesService)(nil)
+
+// GroupWikisServiceInterface defines all the API methods
+// of the GroupWikisService.
+type GroupWikisServiceInterface interface {
+	ListGroupWikis(gid interface{}, opt *ListGroupWikisOptions, options ...RequestOptionFunc) ([]*GroupWiki, *Response, error)
+	GetGroupWikiPage(gid interface{}, slug string, opt *GetGroupWikiPageOptions, options ...RequestOptionFunc) (*GroupWiki, *Response, error)
+	CreateGroupWikiPage(gid interface{}, opt *CreateGroupWikiPageOptions, options ...RequestOptionFunc) (*GroupWiki, *Response, error)
+	EditGroupWikiPage(gid interface{}, slug string, opt *EditGroupWikiPageOptions, options ...RequestOptionFunc) (*GroupWiki, *Response, error)
+	DeleteGroupWikiPage(gid interface{}, slug string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupWikisServiceInterface = (*GroupWikisService)(nil)
+
+// GroupsServiceInterface defines all the API methods of the GroupsService.
+type GroupsServiceInterface interface {
+	ListGroupHooks(gid interface{}, opt *ListGroupHooksOptions, options ...RequestOptionFunc) ([]*GroupHook, *Response, error)
+	GetGroupHook(pid interface{}, hook int, options ...RequestOptionFunc) (*GroupHook, *Response, error)
+	AddGroupHook(gid interface{}, opt *AddGroupHookOptions, options ...RequestOptionFunc) (*GroupHook, *Response, error)
+	EditGroupHook(pid interface{}, hook int, opt *EditGroupHookOptions, options ...RequestOptionFunc) (*GroupHook, *Response, error)
+	DeleteGroupHook(pid interface{}, hook int, options ...RequestOptionFunc) (*Response, error)
+	TriggerTestGroupHook(pid interface{}, hook int, trigger GroupHookTrigger, options ...RequestOptionFunc) (*Response, error)
+	SetGroupCustomHeader(gid interface{}, hook int, key string, opt *SetHookCustomHeaderOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteGroupCustomHeader(gid interface{}, hook int, key string, options ...RequestOptionFunc) (*Response, error)
+	ListGroupMembers(gid interface{}, opt *ListGroupMembersOptions, options ...RequestOptionFunc) ([]*GroupMember, *Response, error)
+	ListAllGroupMembers(gid interface{}, opt *ListGroupMembersOptions, options ...RequestOptionFunc) ([]*GroupMember, *Response, error)
+	ListBillableGroupMembers(gid interface{}, opt *ListBillableGroupMembersOptions, options ...RequestOptionFunc) ([]*BillableGroupMember, *Response, error)
+	ListMembershipsForBillableGroupMember(gid interface{}, user int, opt *ListMembershipsForBillableGroupMemberOptions, options ...RequestOptionFunc) ([]*BillableUserMembership, *Response, error)
+	RemoveBillableGroupMember(gid interface{}, user int, options ...RequestOptionFunc) (*Response, error)
+	ListServiceAccounts(gid interface{}, opt *ListServiceAccountsOptions, options ...RequestOptionFunc) ([]*GroupServiceAccount, *Response, error)
+	CreateServiceAccount(gid interface{}, opt *CreateServiceAccountOptions, options ...RequestOptionFunc) (*GroupServiceAccount, *Response, error)
+	CreateServiceAccountPersonalAccessToken(gid interface{}, serviceAccount int, opt *CreateServiceAccountPersonalAccessTokenOptions, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	RotateServiceAccountPersonalAccessToken(gid interface{}, serviceAccount, token int, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	DeleteServiceAccount(gid interface{}, serviceAccount int, options ...RequestOptionFunc) (*Response, error)
+	ListGroups(opt *ListGroupsOptions, options ...RequestOptionFunc) ([]*Group, *Response, error)
+	ListSubGroups(gid interface{}, opt *ListSubGroupsOptions, options ...RequestOptionFunc) ([]*Group, *Response, error)
+	ListDescendantGroups(gid interface{}, opt *ListDescendantGroupsOptions, options ...RequestOptionFunc) ([]*Group, *Response, error)
+	ListGroupProjects(gid interface{}, opt *ListGroupProjectsOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	GetGroup(gid interface{}, opt *GetGroupOptions, options ...RequestOptionFunc) (*Group, *Response, error)
+	DownloadAvatar(gid interface{}, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+	CreateGroup(opt *CreateGroupOptions, options ...RequestOptionFunc) (*Group, *Response, error)
+	TransferGroup(gid interface{}, pid interface{}, options ...RequestOptionFunc) (*Group, *Response, error)
+	TransferSubGroup(gid interface{}, opt *TransferSubGroupOptions, options ...RequestOptionFunc) (*Group, *Response, error)
+	UpdateGroup(gid interface{}, opt *UpdateGroupOptions, options ...RequestOptionFunc) (*Group, *Response, error)
+	UploadAvatar(gid interface{}, avatar io.Reader, filename string, options ...RequestOptionFunc) (*Group, *Response, error)
+	DeleteGroup(gid interface{}, opt *DeleteGroupOptions, options ...RequestOptionFunc) (*Response, error)
+	RestoreGroup(gid interface{}, options ...RequestOptionFunc) (*Group, *Response, error)
+	SearchGroup(query string, options ...RequestOptionFunc) ([]*Group, *Response, error)
+	ListProvisionedUsers(gid interface{}, opt *ListProvisionedUsersOptions, options ...RequestOptionFunc) ([]*User, *Response, error)
+	ListGroupLDAPLinks(gid interface{}, options ...RequestOptionFunc) ([]*LDAPGroupLink, *Response, error)
+	AddGroupLDAPLink(gid interface{}, opt *AddGroupLDAPLinkOptions, options ...RequestOptionFunc) (*LDAPGroupLink, *Response, error)
+	DeleteGroupLDAPLink(gid interface{}, cn string, options ...RequestOptionFunc) (*Response, error)
+	DeleteGroupLDAPLinkWithCNOrFilter(gid interface{}, opts *DeleteGroupLDAPLinkWithCNOrFilterOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteGroupLDAPLinkForProvider(gid interface{}, provider, cn string, options ...RequestOptionFunc) (*Response, error)
+	ListGroupSAMLLinks(gid interface{}, options ...RequestOptionFunc) ([]*SAMLGroupLink, *Response, error)
+	GetGroupSAMLLink(gid interface{}, samlGroupName string, options ...RequestOptionFunc) (*SAMLGroupLink, *Response, error)
+	AddGroupSAMLLink(gid interface{}, opt *AddGroupSAMLLinkOptions, options ...RequestOptionFunc) (*SAMLGroupLink, *Response, error)
+	DeleteGroupSAMLLink(gid interface{}, samlGroupName string, options ...RequestOptionFunc) (*Response, error)
+	ShareGroupWithGroup(gid interface{}, opt *ShareGroupWithGroupOptions, options ...RequestOptionFunc) (*Group, *Response, error)
+	UnshareGroupFromGroup(gid interface{}, groupID int, options ...RequestOptionFunc) (*Response, error)
+	GetGroupPushRules(gid interface{}, options ...RequestOptionFunc) (*GroupPushRules, *Response, error)
+	AddGroupPushRule(gid interface{}, opt *AddGroupPushRuleOptions, options ...RequestOptionFunc) (*GroupPushRules, *Response, error)
+	EditGroupPushRule(gid interface{}, opt *EditGroupPushRuleOptions, options ...RequestOptionFunc) (*GroupPushRules, *Response, error)
+	DeleteGroupPushRule(gid interface{}, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ GroupsServiceInterface = (*GroupsService)(nil)
+
+// ImportServiceInterface defines all the API methods of the ImportService.
+type ImportServiceInterface interface {
+	ImportRepositoryFromGitHub(opt *ImportRepositoryFromGitHubOptions, options ...RequestOptionFunc) (*GitHubImport, *Response, error)
+	CancelGitHubProjectImport(opt *CancelGitHubProjectImportOptions, options ...RequestOptionFunc) (*CancelledGitHubImport, *Response, error)
+	ImportGitHubGistsIntoGitLabSnippets(opt *ImportGitHubGistsIntoGitLabSnippetsOptions, options ...RequestOptionFunc) (*Response, error)
+	ImportRepositoryFromBitbucketServer(opt *ImportRepositoryFromBitbucketServerOptions, options ...RequestOptionFunc) (*BitbucketServerImport, *Response, error)
+	ImportRepositoryFromBitbucketCloud(opt *ImportRepositoryFromBitbucketCloudOptions, options ...RequestOptionFunc) (*BitbucketCloudImport, *Response, error)
+}
+
+var _ ImportServiceInterface = (*ImportService)(nil)
+
+// InstanceClustersServiceInterface defines all the API methods
+// of the InstanceClustersService.
+type InstanceClustersServiceInterface interface {
+	ListClusters(options ...RequestOptionFunc) ([]*InstanceCluster, *Response, error)
+	GetCluster(cluster int, options ...RequestOptionFunc) (*InstanceCluster, *Response, error)
+	AddCluster(opt *AddClusterOptions, options ...RequestOptionFunc) (*InstanceCluster, *Response, error)
+	EditCluster(cluster int, opt *EditClusterOptions, options ...RequestOptionFunc) (*InstanceCluster, *Response, error)
+	DeleteCluster(cluster int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ InstanceClustersServiceInterface = (*InstanceClustersService)(nil)
+
+// InstanceVariablesServiceInterface defines all the API methods
+// of the InstanceVariablesService.
+type InstanceVariablesServiceInterface interface {
+	ListVariables(opt *ListInstanceVariablesOptions, options ...RequestOptionFunc) ([]*InstanceVariable, *Response, error)
+	GetVariable(key string, options ...RequestOptionFunc) (*InstanceVariable, *Response, error)
+	CreateVariable(opt *CreateInstanceVariableOptions, options ...RequestOptionFunc) (*InstanceVariable, *Response, error)
+	UpdateVariable(key string, opt *UpdateInstanceVariableOptions, options ...RequestOptionFunc) (*InstanceVariable, *Response, error)
+	RemoveVariable(key string, options ...RequestOptionFunc) (*Response, error)
+	SyncVariables(desired []*VariableSpec, opt *SyncVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error)
+}
+
+var _ InstanceVariablesServiceInterface = (*InstanceVariablesService)(nil)
+
+// InvitesServiceInterface defines all the API methods of the InvitesService.
+type InvitesServiceInterface interface {
+	ListPendingGroupInvitations(gid interface{}, opt *ListPendingInvitationsOptions, options ...RequestOptionFunc) ([]*PendingInvite, *Response, error)
+	ListPendingProjectInvitations(pid interface{}, opt *ListPendingInvitationsOptions, options ...RequestOptionFunc) ([]*PendingInvite, *Response, error)
+	GroupInvites(gid interface{}, opt *InvitesOptions, options ...RequestOptionFunc) (*InvitesResult, *Response, error)
+	ProjectInvites(pid interface{}, opt *InvitesOptions, options ...RequestOptionFunc) (*InvitesResult, *Response, error)
+}
+
+var _ InvitesServiceInterface = (*InvitesService)(nil)
+
+// IssueBoardsServiceInterface defines all the API methods
+// of the IssueBoardsService.
+type IssueBoardsServiceInterface interface {
+	CreateIssueBoard(pid interface{}, opt *CreateIssueBoardOptions, options ...RequestOptionFunc) (*IssueBoard, *Response, error)
+	UpdateIssueBoard(pid interface{}, board int, opt *UpdateIssueBoardOptions, options ...RequestOptionFunc) (*IssueBoard, *Response, error)
+	DeleteIssueBoard(pid interface{}, board int, options ...RequestOptionFunc) (*Response, error)
+	ListIssueBoards(pid interface{}, opt *ListIssueBoardsOptions, options ...RequestOptionFunc) ([]*IssueBoard, *Response, error)
+	GetIssueBoard(pid interface{}, board int, options ...RequestOptionFunc) (*IssueBoard, *Response, error)
+	GetIssueBoardLists(pid interface{}, board int, opt *GetIssueBoardListsOptions, options ...RequestOptionFunc) ([]*BoardList, *Response, error)
+	GetIssueBoardList(pid interface{}, board, list int, options ...RequestOptionFunc) (*BoardList, *Response, error)
+	CreateIssueBoardList(pid interface{}, board int, opt *CreateIssueBoardListOptions, options ...RequestOptionFunc) (*BoardList, *Response, error)
+	UpdateIssueBoardList(pid interface{}, board, list int, opt *UpdateIssueBoardListOptions, options ...RequestOptionFunc) (*BoardList, *Response, error)
+	DeleteIssueBoardList(pid interface{}, board, list int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ IssueBoardsServiceInterface = (*IssueBoardsService)(nil)
+
+// IssueLinksServiceInterface defines all the API methods
+// of the IssueLinksService.
+type IssueLinksServiceInterface interface {
+	ListIssueRelations(pid interface{}, issue int, options ...RequestOptionFunc) ([]*IssueRelation, *Response, error)
+	GetIssueLink(pid interface{}, issue, issueLink int, options ...RequestOptionFunc) (*IssueLink, *Response, error)
+	CreateIssueLink(pid interface{}, issue int, opt *CreateIssueLinkOptions, options ...RequestOptionFunc) (*IssueLink, *Response, error)
+	DeleteIssueLink(pid interface{}, issue, issueLink int, options ...RequestOptionFunc) (*IssueLink, *Response, error)
+}
+
+var _ IssueLinksServiceInterface = (*IssueLinksService)(nil)
+
+// IssuesServiceInterface defines all the API methods of the IssuesService.
+type IssuesServiceInterface interface {
+	ListIssues(opt *ListIssuesOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	ListGroupIssues(pid interface{}, opt *ListGroupIssuesOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	ListProjectIssues(pid interface{}, opt *ListProjectIssuesOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	GetIssueByID(issue int, options ...RequestOptionFunc) (*Issue, *Response, error)
+	GetIssue(pid interface{}, issue int, options ...RequestOptionFunc) (*Issue, *Response, error)
+	CreateIssue(pid interface{}, opt *CreateIssueOptions, options ...RequestOptionFunc) (*Issue, *Response, error)
+	UpdateIssue(pid interface{}, issue int, opt *UpdateIssueOptions, options ...RequestOptionFunc) (*Issue, *Response, error)
+	DeleteIssue(pid interface{}, issue int, options ...RequestOptionFunc) (*Response, error)
+	ReorderIssue(pid interface{}, issue int, opt *ReorderIssueOptions, options ...RequestOptionFunc) (*Issue, *Response, error)
+	MoveIssue(pid interface{}, issue int, opt *MoveIssueOptions, options ...RequestOptionFunc) (*Issue, *Response, error)
+	SubscribeToIssue(pid interface{}, issue int, options ...RequestOptionFunc) (*Issue, *Response, error)
+	UnsubscribeFromIssue(pid interface{}, issue int, options ...RequestOptionFunc) (*Issue, *Response, error)
+	CreateTodo(pid interface{}, issue int, options ...RequestOptionFunc) (*Todo, *Response, error)
+	ListMergeRequestsClosingIssue(pid interface{}, issue int, opt *ListMergeRequestsClosingIssueOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	ListMergeRequestsRelatedToIssue(pid interface{}, issue int, opt *ListMergeRequestsRelatedToIssueOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	SetTimeEstimate(pid interface{}, issue int, opt *SetTimeEstimateOptions, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	ResetTimeEstimate(pid interface{}, issue int, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	AddSpentTime(pid interface{}, issue int, opt *AddSpentTimeOptions, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	ResetSpentTime(pid interface{}, issue int, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	GetTimeSpent(pid interface{}, issue int, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	GetParticipants(pid interface{}, issue int, options ...RequestOptionFunc) ([]*BasicUser, *Response, error)
+}
+
+var _ IssuesServiceInterface = (*IssuesService)(nil)
+
+// IssuesStatisticsServiceInterface defines all the API methods
+// of the IssuesStatisticsService.
+type IssuesStatisticsServiceInterface interface {
+	GetIssuesStatistics(opt *GetIssuesStatisticsOptions, options ...RequestOptionFunc) (*IssuesStatistics, *Response, error)
+	GetGroupIssuesStatistics(gid interface{}, opt *GetGroupIssuesStatisticsOptions, options ...RequestOptionFunc) (*IssuesStatistics, *Response, error)
+	GetProjectIssuesStatistics(pid interface{}, opt *GetProjectIssuesStatisticsOptions, options ...RequestOptionFunc) (*IssuesStatistics, *Response, error)
+}
+
+var _ IssuesStatisticsServiceInterface = (*IssuesStatisticsService)(nil)
+
+// JobTokenScopeServiceInterface defines all the API methods
+// of the JobTokenScopeService.
+type JobTokenScopeServiceInterface interface {
+	GetProjectJobTokenAccessSettings(pid interface{}, options ...RequestOptionFunc) (*JobTokenAccessSettings, *Response, error)
+	PatchProjectJobTokenAccessSettings(pid interface{}, opt *PatchProjectJobTokenAccessSettingsOptions, options ...RequestOptionFunc) (*Response, error)
+	GetProjectJobTokenInboundAllowList(pid interface{}, opt *GetJobTokenInboundAllowListOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	AddProjectToJobScopeAllowList(pid interface{}, opt *JobTokenInboundAllowOptions, options ...RequestOptionFunc) (*JobTokenInboundAllowItem, *Response, error)
+	RemoveProjectFromJobScopeAllowList(pid interface{}, targetProject int, options ...RequestOptionFunc) (*Response, error)
+	GetJobTokenAllowlistGroups(pid interface{}, opt *GetJobTokenAllowlistGroupsOptions, options ...RequestOptionFunc) ([]*Group, *Response, error)
+	AddGroupToJobTokenAllowlist(pid interface{}, opt *AddGroupToJobTokenAllowlistOptions, options ...RequestOptionFunc) (*JobTokenAllowlistItem, *Response, error)
+	RemoveGroupFromJobTokenAllowlist(pid interface{}, targetGroup int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ JobTokenScopeServiceInterface = (*JobTokenScopeService)(nil)
+
+// JobsServiceInterface defines all the API methods of the JobsService.
+type JobsServiceInterface interface {
+	TailTrace(pid interface{}, jobID int, w io.Writer, opt *TailTraceOptions, options ...RequestOptionFunc) (*Job, int64, error)
+	ListProjectJobs(pid interface{}, opts *ListJobsOptions, options ...RequestOptionFunc) ([]*Job, *Response, error)
+	ListPipelineJobs(pid interface{}, pipelineID int, opts *ListJobsOptions, options ...RequestOptionFunc) ([]*Job, *Response, error)
+	ListPipelineBridges(pid interface{}, pipelineID int, opts *ListJobsOptions, options ...RequestOptionFunc) ([]*Bridge, *Response, error)
+	GetJobTokensJob(opts *GetJobTokensJobOptions, options ...RequestOptionFunc) (*Job, *Response, error)
+	GetJob(pid interface{}, jobID int, options ...RequestOptionFunc) (*Job, *Response, error)
+	GetJobArtifacts(pid interface{}, jobID int, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+	StreamJobArtifacts(pid interface{}, jobID int, w io.Writer, options ...RequestOptionFunc) (*Response, error)
+	DownloadArtifactsFile(pid interface{}, refName string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+	StreamArtifactsFile(pid interface{}, refName string, w io.Writer, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*Response, error)
+	DownloadSingleArtifactsFile(pid interface{}, jobID int, artifactPath string, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+	StreamSingleArtifactsFile(pid interface{}, jobID int, artifactPath string, w io.Writer, options ...RequestOptionFunc) (*Response, error)
+	DownloadSingleArtifactsFileByTagOrBranch(pid interface{}, refName string, artifactPath string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+	StreamSingleArtifactsFileByTagOrBranch(pid interface{}, refName string, artifactPath string, w io.Writer, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*Response, error)
+	GetTraceFile(pid interface{}, jobID int, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+	StreamTraceFile(pid interface{}, jobID int, w io.Writer, options ...RequestOptionFunc) (*Response, error)
+	CancelJob(pid interface{}, jobID int, options ...RequestOptionFunc) (*Job, *Response, error)
+	RetryJob(pid interface{}, jobID int, options ...RequestOptionFunc) (*Job, *Response, error)
+	EraseJob(pid interface{}, jobID int, options ...RequestOptionFunc) (*Job, *Response, error)
+	KeepArtifacts(pid interface{}, jobID int, options ...RequestOptionFunc) (*Job, *Response, error)
+	PlayJob(pid interface{}, jobID int, opt *PlayJobOptions, options ...RequestOptionFunc) (*Job, *Response, error)
+	DeleteArtifacts(pid interface{}, jobID int, options ...RequestOptionFunc) (*Response, error)
+	DeleteProjectArtifacts(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ JobsServiceInterface = (*JobsService)(nil)
+
+// KeysServiceInterface defines all the API methods of the KeysService.
+type KeysServiceInterface interface {
+	GetKeyWithUser(key int, options ...RequestOptionFunc) (*Key, *Response, error)
+	GetKeyByFingerprint(opt *GetKeyByFingerprintOptions, options ...RequestOptionFunc) (*Key, *Response, error)
+}
+
+var _ KeysServiceInterface = (*KeysService)(nil)
+
+// LabelsServiceInterface defines all the API methods of the LabelsService.
+type LabelsServiceInterface interface {
+	ListLabels(pid interface{}, opt *ListLabelsOptions, options ...RequestOptionFunc) ([]*Label, *Response, error)
+	GetLabel(pid interface{}, lid interface{}, options ...RequestOptionFunc) (*Label, *Response, error)
+	CreateLabel(pid interface{}, opt *CreateLabelOptions, options ...RequestOptionFunc) (*Label, *Response, error)
+	DeleteLabel(pid interface{}, lid interface{}, opt *DeleteLabelOptions, options ...RequestOptionFunc) (*Response, error)
+	UpdateLabel(pid interface{}, lid interface{}, opt *UpdateLabelOptions, options ...RequestOptionFunc) (*Label, *Response, error)
+	SubscribeToLabel(pid interface{}, lid interface{}, options ...RequestOptionFunc) (*Label, *Response, error)
+	UnsubscribeFromLabel(pid interface{}, lid interface{}, options ...RequestOptionFunc) (*Response, error)
+	PromoteLabel(pid interface{}, lid interface{}, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ LabelsServiceInterface = (*LabelsService)(nil)
+
+// LicenseServiceInterface defines all the API methods of the LicenseService.
+type LicenseServiceInterface interface {
+	GetLicense(options ...RequestOptionFunc) (*License, *Response, error)
+	AddLicense(opt *AddLicenseOptions, options ...RequestOptionFunc) (*License, *Response, error)
+	DeleteLicense(licenseID int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ LicenseServiceInterface = (*LicenseService)(nil)
+
+// LicenseTemplatesServiceInterface defines all the API methods
+// of the LicenseTemplatesService.
+type LicenseTemplatesServiceInterface interface {
+	ListLicenseTemplates(opt *ListLicenseTemplatesOptions, options ...RequestOptionFunc) ([]*LicenseTemplate, *Response, error)
+	GetLicenseTemplate(template string, opt *GetLicenseTemplateOptions, options ...RequestOptionFunc) (*LicenseTemplate, *Response, error)
+}
+
+var _ LicenseTemplatesServiceInterface = (*LicenseTemplatesService)(nil)
+
+// ManagedLicensesServiceInterface defines all the API methods
+// of the ManagedLicensesService.
+type ManagedLicensesServiceInterface interface {
+	ListManagedLicenses(pid interface{}, options ...RequestOptionFunc) ([]*ManagedLicense, *Response, error)
+	GetManagedLicense(pid, mlid interface{}, options ...RequestOptionFunc) (*ManagedLicense, *Response, error)
+	AddManagedLicense(pid interface{}, opt *AddManagedLicenseOptions, options ...RequestOptionFunc) (*ManagedLicense, *Response, error)
+	DeleteManagedLicense(pid, mlid interface{}, options ...RequestOptionFunc) (*Response, error)
+	EditManagedLicense(pid, mlid interface{}, opt *EditManagedLicenceOptions, options ...RequestOptionFunc) (*ManagedLicense, *Response, error)
+}
+
+var _ ManagedLicensesServiceInterface = (*ManagedLicensesService)(nil)
+
+// MarkdownServiceInterface defines all the API methods of the MarkdownService.
+type MarkdownServiceInterface interface {
+	Render(opt *RenderOptions, options ...RequestOptionFunc) (*Markdown, *Response, error)
+}
+
+var _ MarkdownServiceInterface = (*MarkdownService)(nil)
+
+// MemberRolesServiceInterface defines all the API methods
+// of the MemberRolesService.
+type MemberRolesServiceInterface interface {
+	ListMemberRoles(gid interface{}, options ...RequestOptionFunc) ([]*MemberRole, *Response, error)
+	CreateMemberRole(gid interface{}, opt *CreateMemberRoleOptions, options ...RequestOptionFunc) (*MemberRole, *Response, error)
+	DeleteMemberRole(gid interface{}, memberRole int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ MemberRolesServiceInterface = (*MemberRolesService)(nil)
+
+// MergeRequestApprovalsServiceInterface defines all the API methods
+// of the MergeRequestApprovalsService.
+type MergeRequestApprovalsServiceInterface interface {
+	ApproveMergeRequest(pid interface{}, mr int, opt *ApproveMergeRequestOptions, options ...RequestOptionFunc) (*MergeRequestApprovals, *Response, error)
+	UnapproveMergeRequest(pid interface{}, mr int, options ...RequestOptionFunc) (*Response, error)
+	ResetApprovalsOfMergeRequest(pid interface{}, mr int, options ...RequestOptionFunc) (*Response, error)
+	GetConfiguration(pid interface{}, mr int, options ...RequestOptionFunc) (*MergeRequestApprovals, *Response, error)
+	ChangeApprovalConfiguration(pid interface{}, mergeRequest int, opt *ChangeMergeRequestApprovalConfigurationOptions, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	ChangeAllowedApprovers(pid interface{}, mergeRequest int, opt *ChangeMergeRequestAllowedApproversOptions, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	GetApprovalRules(pid interface{}, mergeRequest int, options ...RequestOptionFunc) ([]*MergeRequestApprovalRule, *Response, error)
+	GetApprovalState(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*MergeRequestApprovalState, *Response, error)
+	CreateApprovalRule(pid interface{}, mergeRequest int, opt *CreateMergeRequestApprovalRuleOptions, options ...RequestOptionFunc) (*MergeRequestApprovalRule, *Response, error)
+	UpdateApprovalRule(pid interface{}, mergeRequest int, approvalRule int, opt *UpdateMergeRequestApprovalRuleOptions, options ...RequestOptionFunc) (*MergeRequestApprovalRule, *Response, error)
+	DeleteApprovalRule(pid interface{}, mergeRequest int, approvalRule int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ MergeRequestApprovalsServiceInterface = (*MergeRequestApprovalsService)(nil)
+
+// MergeRequestsServiceInterface defines all the API methods
+// of the MergeRequestsService.
+type MergeRequestsServiceInterface interface {
+	ListMergeRequests(opt *ListMergeRequestsOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	ListProjectMergeRequests(pid interface{}, opt *ListProjectMergeRequestsOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	ListGroupMergeRequests(gid interface{}, opt *ListGroupMergeRequestsOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	GetMergeRequest(pid interface{}, mergeRequest int, opt *GetMergeRequestsOptions, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	GetMergeRequestApprovals(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*MergeRequestApprovals, *Response, error)
+	GetMergeRequestCommits(pid interface{}, mergeRequest int, opt *GetMergeRequestCommitsOptions, options ...RequestOptionFunc) ([]*Commit, *Response, error)
+	GetMergeRequestChanges(pid interface{}, mergeRequest int, opt *GetMergeRequestChangesOptions, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	ListMergeRequestDiffs(pid interface{}, mergeRequest int, opt *ListMergeRequestDiffsOptions, options ...RequestOptionFunc) ([]*MergeRequestDiff, *Response, error)
+	GetMergeRequestParticipants(pid interface{}, mergeRequest int, options ...RequestOptionFunc) ([]*BasicUser, *Response, error)
+	GetMergeRequestReviewers(pid interface{}, mergeRequest int, options ...RequestOptionFunc) ([]*MergeRequestReviewer, *Response, error)
+	ListMergeRequestPipelines(pid interface{}, mergeRequest int, options ...RequestOptionFunc) ([]*PipelineInfo, *Response, error)
+	CreateMergeRequestPipeline(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*PipelineInfo, *Response, error)
+	GetIssuesClosedOnMerge(pid interface{}, mergeRequest int, opt *GetIssuesClosedOnMergeOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	CreateMergeRequest(pid interface{}, opt *CreateMergeRequestOptions, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	UpdateMergeRequest(pid interface{}, mergeRequest int, opt *UpdateMergeRequestOptions, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	DeleteMergeRequest(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*Response, error)
+	AcceptMergeRequest(pid interface{}, mergeRequest int, opt *AcceptMergeRequestOptions, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	CancelMergeWhenPipelineSucceeds(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	RebaseMergeRequest(pid interface{}, mergeRequest int, opt *RebaseMergeRequestOptions, options ...RequestOptionFunc) (*Response, error)
+	GetMergeRequestDiffVersions(pid interface{}, mergeRequest int, opt *GetMergeRequestDiffVersionsOptions, options ...RequestOptionFunc) ([]*MergeRequestDiffVersion, *Response, error)
+	GetSingleMergeRequestDiffVersion(pid interface{}, mergeRequest, version int, opt *GetSingleMergeRequestDiffVersionOptions, options ...RequestOptionFunc) (*MergeRequestDiffVersion, *Response, error)
+	SubscribeToMergeRequest(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	UnsubscribeFromMergeRequest(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*MergeRequest, *Response, error)
+	CreateTodo(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*Todo, *Response, error)
+	SetTimeEstimate(pid interface{}, mergeRequest int, opt *SetTimeEstimateOptions, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	ResetTimeEstimate(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	AddSpentTime(pid interface{}, mergeRequest int, opt *AddSpentTimeOptions, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	ResetSpentTime(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	GetTimeSpent(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*TimeStats, *Response, error)
+	MergeWhenReady(pid interface{}, mergeRequest int, opt *MergeWhenReadyOptions, options ...RequestOptionFunc) (*MergeRequest, error)
+}
+
+var _ MergeRequestsServiceInterface = (*MergeRequestsService)(nil)
+
+// MergeTrainsServiceInterface defines all the API methods
+// of the MergeTrainsService.
+type MergeTrainsServiceInterface interface {
+	ListProjectMergeTrains(pid interface{}, opt *ListMergeTrainsOptions, options ...RequestOptionFunc) ([]*MergeTrain, *Response, error)
+	ListMergeRequestInMergeTrain(pid interface{}, targetBranch string, opts *ListMergeTrainsOptions, options ...RequestOptionFunc) ([]*MergeTrain, *Response, error)
+	GetMergeRequestOnAMergeTrain(pid interface{}, mergeRequest int, options ...RequestOptionFunc) (*MergeTrain, *Response, error)
+	AddMergeRequestToMergeTrain(pid interface{}, mergeRequest int, opts *AddMergeRequestToMergeTrainOptions, options ...RequestOptionFunc) ([]*MergeTrain, *Response, error)
+}
+
+var _ MergeTrainsServiceInterface = (*MergeTrainsService)(nil)
+
+// MetadataServiceInterface defines all the API methods of the MetadataService.
+type MetadataServiceInterface interface {
+	GetMetadata(options ...RequestOptionFunc) (*Metadata, *Response, error)
+}
+
+var _ MetadataServiceInterface = (*MetadataService)(nil)
+
+// MilestonesServiceInterface defines all the API methods
+// of the MilestonesService.
+type MilestonesServiceInterface interface {
+	ListMilestones(pid interface{}, opt *ListMilestonesOptions, options ...RequestOptionFunc) ([]*Milestone, *Response, error)
+	GetMilestone(pid interface{}, milestone int, options ...RequestOptionFunc) (*Milestone, *Response, error)
+	CreateMilestone(pid interface{}, opt *CreateMilestoneOptions, options ...RequestOptionFunc) (*Milestone, *Response, error)
+	UpdateMilestone(pid interface{}, milestone int, opt *UpdateMilestoneOptions, options ...RequestOptionFunc) (*Milestone, *Response, error)
+	DeleteMilestone(pid interface{}, milestone int, options ...RequestOptionFunc) (*Response, error)
+	GetMilestoneIssues(pid interface{}, milestone int, opt *GetMilestoneIssuesOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	GetMilestoneMergeRequests(pid interface{}, milestone int, opt *GetMilestoneMergeRequestsOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+}
+
+var _ MilestonesServiceInterface = (*MilestonesService)(nil)
+
+// NamespacesServiceInterface defines all the API methods
+// of the NamespacesService.
+type NamespacesServiceInterface interface {
+	ListNamespaces(opt *ListNamespacesOptions, options ...RequestOptionFunc) ([]*Namespace, *Response, error)
+	SearchNamespace(query string, options ...RequestOptionFunc) ([]*Namespace, *Response, error)
+	GetNamespace(id interface{}, options ...RequestOptionFunc) (*Namespace, *Response, error)
+	NamespaceExists(id interface{}, opt *NamespaceExistsOptions, options ...RequestOptionFunc) (*NamespaceExistance, *Response, error)
+}
+
+var _ NamespacesServiceInterface = (*NamespacesService)(nil)
+
+// NotesServiceInterface defines all the API methods of the NotesService.
+type NotesServiceInterface interface {
+	ListIssueNotes(pid interface{}, issue int, opt *ListIssueNotesOptions, options ...RequestOptionFunc) ([]*Note, *Response, error)
+	GetIssueNote(pid interface{}, issue, note int, options ...RequestOptionFunc) (*Note, *Response, error)
+	CreateIssueNote(pid interface{}, issue int, opt *CreateIssueNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateIssueNote(pid interface{}, issue, note int, opt *UpdateIssueNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteIssueNote(pid interface{}, issue, note int, options ...RequestOptionFunc) (*Response, error)
+	ListSnippetNotes(pid interface{}, snippet int, opt *ListSnippetNotesOptions, options ...RequestOptionFunc) ([]*Note, *Response, error)
+	GetSnippetNote(pid interface{}, snippet, note int, options ...RequestOptionFunc) (*Note, *Response, error)
+	CreateSnippetNote(pid interface{}, snippet int, opt *CreateSnippetNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateSnippetNote(pid interface{}, snippet, note int, opt *UpdateSnippetNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteSnippetNote(pid interface{}, snippet, note int, options ...RequestOptionFunc) (*Response, error)
+	ListMergeRequestNotes(pid interface{}, mergeRequest int, opt *ListMergeRequestNotesOptions, options ...RequestOptionFunc) ([]*Note, *Response, error)
+	GetMergeRequestNote(pid interface{}, mergeRequest, note int, options ...RequestOptionFunc) (*Note, *Response, error)
+	CreateMergeRequestNote(pid interface{}, mergeRequest int, opt *CreateMergeRequestNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateMergeRequestNote(pid interface{}, mergeRequest, note int, opt *UpdateMergeRequestNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteMergeRequestNote(pid interface{}, mergeRequest, note int, options ...RequestOptionFunc) (*Response, error)
+	ListEpicNotes(gid interface{}, epic int, opt *ListEpicNotesOptions, options ...RequestOptionFunc) ([]*Note, *Response, error)
+	GetEpicNote(gid interface{}, epic, note int, options ...RequestOptionFunc) (*Note, *Response, error)
+	CreateEpicNote(gid interface{}, epic int, opt *CreateEpicNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	UpdateEpicNote(gid interface{}, epic, note int, opt *UpdateEpicNoteOptions, options ...RequestOptionFunc) (*Note, *Response, error)
+	DeleteEpicNote(gid interface{}, epic, note int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ NotesServiceInterface = (*NotesService)(nil)
+
+// NotificationSettingsServiceInterface defines all the API methods
+// of the NotificationSettingsService.
+type NotificationSettingsServiceInterface interface {
+	GetGlobalSettings(options ...RequestOptionFunc) (*NotificationSettings, *Response, error)
+	UpdateGlobalSettings(opt *NotificationSettingsOptions, options ...RequestOptionFunc) (*NotificationSettings, *Response, error)
+	GetSettingsForGroup(gid interface{}, options ...RequestOptionFunc) (*NotificationSettings, *Response, error)
+	GetSettingsForProject(pid interface{}, options ...RequestOptionFunc) (*NotificationSettings, *Response, error)
+	UpdateSettingsForGroup(gid interface{}, opt *NotificationSettingsOptions, options ...RequestOptionFunc) (*NotificationSettings, *Response, error)
+	UpdateSettingsForProject(pid interface{}, opt *NotificationSettingsOptions, options ...RequestOptionFunc) (*NotificationSettings, *Response, error)
+}
+
+var _ NotificationSettingsServiceInterface = (*NotificationSettingsService)(nil)
+
+// PackagesServiceInterface defines all the API methods of the PackagesService.
+type PackagesServiceInterface interface {
+	ListProjectPackages(pid interface{}, opt *ListProjectPackagesOptions, options ...RequestOptionFunc) ([]*Package, *Response, error)
+	ListGroupPackages(gid interface{}, opt *ListGroupPackagesOptions, options ...RequestOptionFunc) ([]*GroupPackage, *Response, error)
+	ListPackageFiles(pid interface{}, pkg int, opt *ListPackageFilesOptions, options ...RequestOptionFunc) ([]*PackageFile, *Response, error)
+	DeleteProjectPackage(pid interface{}, pkg int, options ...RequestOptionFunc) (*Response, error)
+	DeletePackageFile(pid interface{}, pkg, file int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ PackagesServiceInterface = (*PackagesService)(nil)
+
+// PagesDomainsServiceInterface defines all the API methods
+// of the PagesDomainsService.
+type PagesDomainsServiceInterface interface {
+	ListPagesDomains(pid interface{}, opt *ListPagesDomainsOptions, options ...RequestOptionFunc) ([]*PagesDomain, *Response, error)
+	ListAllPagesDomains(options ...RequestOptionFunc) ([]*PagesDomain, *Response, error)
+	GetPagesDomain(pid interface{}, domain string, options ...RequestOptionFunc) (*PagesDomain, *Response, error)
+	CreatePagesDomain(pid interface{}, opt *CreatePagesDomainOptions, options ...RequestOptionFunc) (*PagesDomain, *Response, error)
+	UpdatePagesDomain(pid interface{}, domain string, opt *UpdatePagesDomainOptions, options ...RequestOptionFunc) (*PagesDomain, *Response, error)
+	DeletePagesDomain(pid interface{}, domain string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ PagesDomainsServiceInterface = (*PagesDomainsService)(nil)
+
+// PagesServiceInterface defines all the API methods of the PagesService.
+type PagesServiceInterface interface {
+	UnpublishPages(gid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetPages(gid interface{}, options ...RequestOptionFunc) (*Pages, *Response, error)
+	UpdatePages(pid interface{}, opt UpdatePagesOptions, options ...RequestOptionFunc) (*Pages, *Response, error)
+}
+
+var _ PagesServiceInterface = (*PagesService)(nil)
+
+// PersonalAccessTokensServiceInterface defines all the API methods
+// of the PersonalAccessTokensService.
+type PersonalAccessTokensServiceInterface interface {
+	ListPersonalAccessTokens(opt *ListPersonalAccessTokensOptions, options ...RequestOptionFunc) ([]*PersonalAccessToken, *Response, error)
+	GetSinglePersonalAccessTokenByID(token int, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	GetSinglePersonalAccessToken(options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	RotatePersonalAccessToken(token int, opt *RotatePersonalAccessTokenOptions, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	RotatePersonalAccessTokenByID(token int, opt *RotatePersonalAccessTokenOptions, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	RotatePersonalAccessTokenSelf(opt *RotatePersonalAccessTokenOptions, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	RevokePersonalAccessToken(token int, options ...RequestOptionFunc) (*Response, error)
+	RevokePersonalAccessTokenByID(token int, options ...RequestOptionFunc) (*Response, error)
+	RevokePersonalAccessTokenSelf(options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ PersonalAccessTokensServiceInterface = (*PersonalAccessTokensService)(nil)
+
+// PipelineSchedulesServiceInterface defines all the API methods
+// of the PipelineSchedulesService.
+type PipelineSchedulesServiceInterface interface {
+	ListPipelineSchedules(pid interface{}, opt *ListPipelineSchedulesOptions, options ...RequestOptionFunc) ([]*PipelineSchedule, *Response, error)
+	GetPipelineSchedule(pid interface{}, schedule int, options ...RequestOptionFunc) (*PipelineSchedule, *Response, error)
+	ListPipelinesTriggeredBySchedule(pid interface{}, schedule int, opt *ListPipelinesTriggeredByScheduleOptions, options ...RequestOptionFunc) ([]*Pipeline, *Response, error)
+	CreatePipelineSchedule(pid interface{}, opt *CreatePipelineScheduleOptions, options ...RequestOptionFunc) (*PipelineSchedule, *Response, error)
+	EditPipelineSchedule(pid interface{}, schedule int, opt *EditPipelineScheduleOptions, options ...RequestOptionFunc) (*PipelineSchedule, *Response, error)
+	TakeOwnershipOfPipelineSchedule(pid interface{}, schedule int, options ...RequestOptionFunc) (*PipelineSchedule, *Response, error)
+	DeletePipelineSchedule(pid interface{}, schedule int, options ...RequestOptionFunc) (*Response, error)
+	RunPipelineSchedule(pid interface{}, schedule int, options ...RequestOptionFunc) (*Response, error)
+	CreatePipelineScheduleVariable(pid interface{}, schedule int, opt *CreatePipelineScheduleVariableOptions, options ...RequestOptionFunc) (*PipelineVariable, *Response, error)
+	EditPipelineScheduleVariable(pid interface{}, schedule int, key string, opt *EditPipelineScheduleVariableOptions, options ...RequestOptionFunc) (*PipelineVariable, *Response, error)
+	DeletePipelineScheduleVariable(pid interface{}, schedule int, key string, options ...RequestOptionFunc) (*PipelineVariable, *Response, error)
+}
+
+var _ PipelineSchedulesServiceInterface = (*PipelineSchedulesService)(nil)
+
+// PipelineTriggersServiceInterface defines all the API methods
+// of the PipelineTriggersService.
+type PipelineTriggersServiceInterface interface {
+	ListPipelineTriggers(pid interface{}, opt *ListPipelineTriggersOptions, options ...RequestOptionFunc) ([]*PipelineTrigger, *Response, error)
+	GetPipelineTrigger(pid interface{}, trigger int, options ...RequestOptionFunc) (*PipelineTrigger, *Response, error)
+	AddPipelineTrigger(pid interface{}, opt *AddPipelineTriggerOptions, options ...RequestOptionFunc) (*PipelineTrigger, *Response, error)
+	EditPipelineTrigger(pid interface{}, trigger int, opt *EditPipelineTriggerOptions, options ...RequestOptionFunc) (*PipelineTrigger, *Response, error)
+	TakeOwnershipOfPipelineTrigger(pid interface{}, trigger int, options ...RequestOptionFunc) (*PipelineTrigger, *Response, error)
+	DeletePipelineTrigger(pid interface{}, trigger int, options ...RequestOptionFunc) (*Response, error)
+	RunPipelineTrigger(pid interface{}, opt *RunPipelineTriggerOptions, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+}
+
+var _ PipelineTriggersServiceInterface = (*PipelineTriggersService)(nil)
+
+// PipelinesServiceInterface defines all the API methods
+// of the PipelinesService.
+type PipelinesServiceInterface interface {
+	GetPipelineGraph(pid interface{}, pipeline int, opt *GetPipelineGraphOptions, options ...RequestOptionFunc) (*PipelineGraph, *Response, error)
+	WaitForPipeline(pid interface{}, pipeline int, opt *WaitForPipelineOptions, options ...RequestOptionFunc) (*PipelineSummary, error)
+	ListProjectPipelines(pid interface{}, opt *ListProjectPipelinesOptions, options ...RequestOptionFunc) ([]*PipelineInfo, *Response, error)
+	GetPipeline(pid interface{}, pipeline int, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+	GetPipelineVariables(pid interface{}, pipeline int, options ...RequestOptionFunc) ([]*PipelineVariable, *Response, error)
+	GetPipelineTestReport(pid interface{}, pipeline int, options ...RequestOptionFunc) (*PipelineTestReport, *Response, error)
+	GetLatestPipeline(pid interface{}, opt *GetLatestPipelineOptions, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+	CreatePipeline(pid interface{}, opt *CreatePipelineOptions, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+	RetryPipelineBuild(pid interface{}, pipeline int, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+	CancelPipelineBuild(pid interface{}, pipeline int, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+	DeletePipeline(pid interface{}, pipeline int, options ...RequestOptionFunc) (*Response, error)
+	UpdatePipelineMetadata(pid interface{}, pipeline int, opt *UpdatePipelineMetadataOptions, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+	GetTestReportHistory(pid interface{}, opt *GetTestReportHistoryOptions, options ...RequestOptionFunc) (*TestReportHistory, *Response, error)
+}
+
+var _ PipelinesServiceInterface = (*PipelinesService)(nil)
+
+// PlanLimitsServiceInterface defines all the API methods
+// of the PlanLimitsService.
+type PlanLimitsServiceInterface interface {
+	GetCurrentPlanLimits(opt *GetCurrentPlanLimitsOptions, options ...RequestOptionFunc) (*PlanLimit, *Response, error)
+	ChangePlanLimits(opt *ChangePlanLimitOptions, options ...RequestOptionFunc) (*PlanLimit, *Response, error)
+}
+
+var _ PlanLimitsServiceInterface = (*PlanLimitsService)(nil)
+
+// ProjectAccessTokensServiceInterface defines all the API methods
+// of the ProjectAccessTokensService.
+type ProjectAccessTokensServiceInterface interface {
+	ListProjectAccessTokens(pid interface{}, opt *ListProjectAccessTokensOptions, options ...RequestOptionFunc) ([]*ProjectAccessToken, *Response, error)
+	GetProjectAccessToken(pid interface{}, id int, options ...RequestOptionFunc) (*ProjectAccessToken, *Response, error)
+	CreateProjectAccessToken(pid interface{}, opt *CreateProjectAccessTokenOptions, options ...RequestOptionFunc) (*ProjectAccessToken, *Response, error)
+	RotateProjectAccessToken(pid interface{}, id int, opt *RotateProjectAccessTokenOptions, options ...RequestOptionFunc) (*ProjectAccessToken, *Response, error)
+	RevokeProjectAccessToken(pid interface{}, id int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProjectAccessTokensServiceInterface = (*ProjectAccessTokensService)(nil)
+
+// ProjectBadgesServiceInterface defines all the API methods
+// of the ProjectBadgesService.
+type ProjectBadgesServiceInterface interface {
+	ListProjectBadges(pid interface{}, opt *ListProjectBadgesOptions, options ...RequestOptionFunc) ([]*ProjectBadge, *Response, error)
+	GetProjectBadge(pid interface{}, badge int, options ...RequestOptionFunc) (*ProjectBadge, *Response, error)
+	AddProjectBadge(pid interface{}, opt *AddProjectBadgeOptions, options ...RequestOptionFunc) (*ProjectBadge, *Response, error)
+	EditProjectBadge(pid interface{}, badge int, opt *EditProjectBadgeOptions, options ...RequestOptionFunc) (*ProjectBadge, *Response, error)
+	DeleteProjectBadge(pid interface{}, badge int, options ...RequestOptionFunc) (*Response, error)
+	PreviewProjectBadge(pid interface{}, opt *ProjectBadgePreviewOptions, options ...RequestOptionFunc) (*ProjectBadge, *Response, error)
+}
+
+var _ ProjectBadgesServiceInterface = (*ProjectBadgesService)(nil)
+
+// ProjectClustersServiceInterface defines all the API methods
+// of the ProjectClustersService.
+type ProjectClustersServiceInterface interface {
+	ListClusters(pid interface{}, options ...RequestOptionFunc) ([]*ProjectCluster, *Response, error)
+	GetCluster(pid interface{}, cluster int, options ...RequestOptionFunc) (*ProjectCluster, *Response, error)
+	AddCluster(pid interface{}, opt *AddClusterOptions, options ...RequestOptionFunc) (*ProjectCluster, *Response, error)
+	EditCluster(pid interface{}, cluster int, opt *EditClusterOptions, options ...RequestOptionFunc) (*ProjectCluster, *Response, error)
+	DeleteCluster(pid interface{}, cluster int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProjectClustersServiceInterface = (*ProjectClustersService)(nil)
+
+// ProjectFeatureFlagServiceInterface defines all the API methods
+// of the ProjectFeatureFlagService.
+type ProjectFeatureFlagServiceInterface interface {
+	ListProjectFeatureFlags(pid interface{}, opt *ListProjectFeatureFlagOptions, options ...RequestOptionFunc) ([]*ProjectFeatureFlag, *Response, error)
+	GetProjectFeatureFlag(pid interface{}, name string, options ...RequestOptionFunc) (*ProjectFeatureFlag, *Response, error)
+	CreateProjectFeatureFlag(pid interface{}, opt *CreateProjectFeatureFlagOptions, options ...RequestOptionFunc) (*ProjectFeatureFlag, *Response, error)
+	UpdateProjectFeatureFlag(pid interface{}, name string, opt *UpdateProjectFeatureFlagOptions, options ...RequestOptionFunc) (*ProjectFeatureFlag, *Response, error)
+	DeleteProjectFeatureFlag(pid interface{}, name string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProjectFeatureFlagServiceInterface = (*ProjectFeatureFlagService)(nil)
+
+// ProjectImportExportServiceInterface defines all the API methods
+// of the ProjectImportExportService.
+type ProjectImportExportServiceInterface interface {
+	ScheduleExport(pid interface{}, opt *ScheduleExportOptions, options ...RequestOptionFunc) (*Response, error)
+	ExportStatus(pid interface{}, options ...RequestOptionFunc) (*ExportStatus, *Response, error)
+	ExportDownload(pid interface{}, options ...RequestOptionFunc) ([]byte, *Response, error)
+	StreamExportDownload(pid interface{}, w io.Writer, options ...RequestOptionFunc) (*Response, error)
+	ImportFromFile(archive io.Reader, opt *ImportFileOptions, options ...RequestOptionFunc) (*ImportStatus, *Response, error)
+	ImportStatus(pid interface{}, options ...RequestOptionFunc) (*ImportStatus, *Response, error)
+}
+
+var _ ProjectImportExportServiceInterface = (*ProjectImportExportService)(nil)
+
+// ProjectIterationsServiceInterface defines all the API methods
+// of the ProjectIterationsService.
+type ProjectIterationsServiceInterface interface {
+	ListProjectIterations(pid interface{}, opt *ListProjectIterationsOptions, options ...RequestOptionFunc) ([]*ProjectIteration, *Response, error)
+}
+
+var _ ProjectIterationsServiceInterface = (*ProjectIterationsService)(nil)
+
+// ProjectMarkdownUploadsServiceInterface defines all the API methods
+// of the ProjectMarkdownUploadsService.
+type ProjectMarkdownUploadsServiceInterface interface {
+	UploadProjectMarkdown(pid interface{}, content io.Reader, options ...RequestOptionFunc) (*ProjectMarkdownUploadedFile, *Response, error)
+	ListProjectMarkdownUploads(pid interface{}, options ...RequestOptionFunc) ([]*ProjectMarkdownUpload, *Response, error)
+	DownloadProjectMarkdownUploadByID(pid interface{}, uploadID int, options ...RequestOptionFunc) ([]byte, *Response, error)
+	DownloadProjectMarkdownUploadBySecretAndFilename(pid interface{}, secret string, filename string, options ...RequestOptionFunc) ([]byte, *Response, error)
+	DeleteProjectMarkdownUploadByID(pid interface{}, uploadID int, options ...RequestOptionFunc) (*Response, error)
+	DeleteProjectMarkdownUploadBySecretAndFilename(pid interface{}, secret string, filename string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProjectMarkdownUploadsServiceInterface = (*ProjectMarkdownUploadsService)(nil)
+
+// ProjectMembersServiceInterface defines all the API methods
+// of the ProjectMembersService.
+type ProjectMembersServiceInterface interface {
+	ListProjectMembers(pid interface{}, opt *ListProjectMembersOptions, options ...RequestOptionFunc) ([]*ProjectMember, *Response, error)
+	ListAllProjectMembers(pid interface{}, opt *ListProjectMembersOptions, options ...RequestOptionFunc) ([]*ProjectMember, *Response, error)
+	GetProjectMember(pid interface{}, user int, options ...RequestOptionFunc) (*ProjectMember, *Response, error)
+	GetInheritedProjectMember(pid interface{}, user int, options ...RequestOptionFunc) (*ProjectMember, *Response, error)
+	AddProjectMember(pid interface{}, opt *AddProjectMemberOptions, options ...RequestOptionFunc) (*ProjectMember, *Response, error)
+	EditProjectMember(pid interface{}, user int, opt *EditProjectMemberOptions, options ...RequestOptionFunc) (*ProjectMember, *Response, error)
+	DeleteProjectMember(pid interface{}, user int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProjectMembersServiceInterface = (*ProjectMembersService)(nil)
+
+// ProjectMirrorServiceInterface defines all the API methods
+// of the ProjectMirrorService.
+type ProjectMirrorServiceInterface interface {
+	ListProjectMirror(pid interface{}, opt *ListProjectMirrorOptions, options ...RequestOptionFunc) ([]*ProjectMirror, *Response, error)
+	GetProjectMirror(pid interface{}, mirror int, options ...RequestOptionFunc) (*ProjectMirror, *Response, error)
+	AddProjectMirror(pid interface{}, opt *AddProjectMirrorOptions, options ...RequestOptionFunc) (*ProjectMirror, *Response, error)
+	EditProjectMirror(pid interface{}, mirror int, opt *EditProjectMirrorOptions, options ...RequestOptionFunc) (*ProjectMirror, *Response, error)
+	DeleteProjectMirror(pid interface{}, mirror int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProjectMirrorServiceInterface = (*ProjectMirrorService)(nil)
+
+// ProjectRepositoryStorageMoveServiceInterface defines all the API methods
+// of the ProjectRepositoryStorageMoveService.
+type ProjectRepositoryStorageMoveServiceInterface interface {
+	RetrieveAllStorageMoves(opts RetrieveAllProjectStorageMovesOptions, options ...RequestOptionFunc) ([]*ProjectRepositoryStorageMove, *Response, error)
+	RetrieveAllStorageMovesForProject(project int, opts RetrieveAllProjectStorageMovesOptions, options ...RequestOptionFunc) ([]*ProjectRepositoryStorageMove, *Response, error)
+	GetStorageMove(repositoryStorage int, options ...RequestOptionFunc) (*ProjectRepositoryStorageMove, *Response, error)
+	GetStorageMoveForProject(project int, repositoryStorage int, options ...RequestOptionFunc) (*ProjectRepositoryStorageMove, *Response, error)
+	ScheduleStorageMoveForProject(project int, opts ScheduleStorageMoveForProjectOptions, options ...RequestOptionFunc) (*ProjectRepositoryStorageMove, *Response, error)
+	ScheduleAllStorageMoves(opts ScheduleAllProjectStorageMovesOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProjectRepositoryStorageMoveServiceInterface = (*ProjectRepositoryStorageMoveService)(nil)
+
+// ProjectSnippetsServiceInterface defines all the API methods
+// of the ProjectSnippetsService.
+type ProjectSnippetsServiceInterface interface {
+	ListSnippets(pid interface{}, opt *ListProjectSnippetsOptions, options ...RequestOptionFunc) ([]*Snippet, *Response, error)
+	GetSnippet(pid interface{}, snippet int, options ...RequestOptionFunc) (*Snippet, *Response, error)
+	CreateSnippet(pid interface{}, opt *CreateProjectSnippetOptions, options ...RequestOptionFunc) (*Snippet, *Response, error)
+	UpdateSnippet(pid interface{}, snippet int, opt *UpdateProjectSnippetOptions, options ...RequestOptionFunc) (*Snippet, *Response, error)
+	DeleteSnippet(pid interface{}, snippet int, options ...RequestOptionFunc) (*Response, error)
+	SnippetContent(pid interface{}, snippet int, options ...RequestOptionFunc) ([]byte, *Response, error)
+}
+
+var _ ProjectSnippetsServiceInterface = (*ProjectSnippetsService)(nil)
+
+// ProjectTemplatesServiceInterface defines all the API methods
+// of the ProjectTemplatesService.
+type ProjectTemplatesServiceInterface interface {
+	ListTemplates(pid interface{}, templateType string, opt *ListProjectTemplatesOptions, options ...RequestOptionFunc) ([]*ProjectTemplate, *Response, error)
+	GetProjectTemplate(pid interface{}, templateType string, templateName string, options ...RequestOptionFunc) (*ProjectTemplate, *Response, error)
+}
+
+var _ ProjectTemplatesServiceInterface = (*ProjectTemplatesService)(nil)
+
+// ProjectVariablesServiceInterface defines all the API methods
+// of the ProjectVariablesService.
+type ProjectVariablesServiceInterface interface {
+	ListVariables(pid interface{}, opt *ListProjectVariablesOptions, options ...RequestOptionFunc) ([]*ProjectVariable, *Response, error)
+	GetVariable(pid interface{}, key string, opt *GetProjectVariableOptions, options ...RequestOptionFunc) (*ProjectVariable, *Response, error)
+	CreateVariable(pid interface{}, opt *CreateProjectVariableOptions, options ...RequestOptionFunc) (*ProjectVariable, *Response, error)
+	UpdateVariable(pid interface{}, key string, opt *UpdateProjectVariableOptions, options ...RequestOptionFunc) (*ProjectVariable, *Response, error)
+	RemoveVariable(pid interface{}, key string, opt *RemoveProjectVariableOptions, options ...RequestOptionFunc) (*Response, error)
+	ExportVariables(pid interface{}, options ...RequestOptionFunc) (*VariableDocument, *Response, error)
+	ImportVariables(pid interface{}, doc *VariableDocument, opt *ImportVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error)
+	SyncVariables(pid interface{}, desired []*VariableSpec, opt *SyncVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error)
+}
+
+var _ ProjectVariablesServiceInterface = (*ProjectVariablesService)(nil)
+
+// ProjectVulnerabilitiesServiceInterface defines all the API methods
+// of the ProjectVulnerabilitiesService.
+type ProjectVulnerabilitiesServiceInterface interface {
+	ListProjectVulnerabilities(pid interface{}, opt *ListProjectVulnerabilitiesOptions, options ...RequestOptionFunc) ([]*ProjectVulnerability, *Response, error)
+	CreateVulnerability(pid interface{}, opt *CreateVulnerabilityOptions, options ...RequestOptionFunc) (*ProjectVulnerability, *Response, error)
+}
+
+var _ ProjectVulnerabilitiesServiceInterface = (*ProjectVulnerabilitiesService)(nil)
+
+// ProjectsServiceInterface defines all the API methods of the ProjectsService.
+type ProjectsServiceInterface interface {
+	ListProjects(opt *ListProjectsOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	ListUserProjects(uid interface{}, opt *ListProjectsOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	ListUserContributedProjects(uid interface{}, opt *ListProjectsOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	ListUserStarredProjects(uid interface{}, opt *ListProjectsOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	ListProjectsUsers(pid interface{}, opt *ListProjectUserOptions, options ...RequestOptionFunc) ([]*ProjectUser, *Response, error)
+	ListProjectsGroups(pid interface{}, opt *ListProjectGroupOptions, options ...RequestOptionFunc) ([]*ProjectGroup, *Response, error)
+	GetProjectLanguages(pid interface{}, options ...RequestOptionFunc) (*ProjectLanguages, *Response, error)
+	GetProject(pid interface{}, opt *GetProjectOptions, options ...RequestOptionFunc) (*Project, *Response, error)
+	CreateProject(opt *CreateProjectOptions, options ...RequestOptionFunc) (*Project, *Response, error)
+	CreateProjectForUser(user int, opt *CreateProjectForUserOptions, options ...RequestOptionFunc) (*Project, *Response, error)
+	EditProject(pid interface{}, opt *EditProjectOptions, options ...RequestOptionFunc) (*Project, *Response, error)
+	ForkProject(pid interface{}, opt *ForkProjectOptions, options ...RequestOptionFunc) (*Project, *Response, error)
+	StarProject(pid interface{}, options ...RequestOptionFunc) (*Project, *Response, error)
+	ListProjectsInvitedGroups(pid interface{}, opt *ListProjectInvidedGroupOptions, options ...RequestOptionFunc) ([]*ProjectGroup, *Response, error)
+	UnstarProject(pid interface{}, options ...RequestOptionFunc) (*Project, *Response, error)
+	ArchiveProject(pid interface{}, options ...RequestOptionFunc) (*Project, *Response, error)
+	UnarchiveProject(pid interface{}, options ...RequestOptionFunc) (*Project, *Response, error)
+	DeleteProject(pid interface{}, opt *DeleteProjectOptions, options ...RequestOptionFunc) (*Response, error)
+	ShareProjectWithGroup(pid interface{}, opt *ShareWithGroupOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteSharedProjectFromGroup(pid interface{}, groupID int, options ...RequestOptionFunc) (*Response, error)
+	ListProjectHooks(pid interface{}, opt *ListProjectHooksOptions, options ...RequestOptionFunc) ([]*ProjectHook, *Response, error)
+	GetProjectHook(pid interface{}, hook int, options ...RequestOptionFunc) (*ProjectHook, *Response, error)
+	AddProjectHook(pid interface{}, opt *AddProjectHookOptions, options ...RequestOptionFunc) (*ProjectHook, *Response, error)
+	EditProjectHook(pid interface{}, hook int, opt *EditProjectHookOptions, options ...RequestOptionFunc) (*ProjectHook, *Response, error)
+	DeleteProjectHook(pid interface{}, hook int, options ...RequestOptionFunc) (*Response, error)
+	TriggerTestProjectHook(pid interface{}, hook int, event ProjectHookEvent, options ...RequestOptionFunc) (*Response, error)
+	SetProjectCustomHeader(pid interface{}, hook int, key string, opt *SetHookCustomHeaderOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteProjectCustomHeader(pid interface{}, hook int, key string, options ...RequestOptionFunc) (*Response, error)
+	CreateProjectForkRelation(pid interface{}, fork int, options ...RequestOptionFunc) (*ProjectForkRelation, *Response, error)
+	DeleteProjectForkRelation(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	UploadFile(pid interface{}, content io.Reader, filename string, options ...RequestOptionFunc) (*ProjectFile, *Response, error)
+	UploadAvatar(pid interface{}, avatar io.Reader, filename string, options ...RequestOptionFunc) (*Project, *Response, error)
+	ListProjectForks(pid interface{}, opt *ListProjectsOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	GetProjectPushRules(pid interface{}, options ...RequestOptionFunc) (*ProjectPushRules, *Response, error)
+	AddProjectPushRule(pid interface{}, opt *AddProjectPushRuleOptions, options ...RequestOptionFunc) (*ProjectPushRules, *Response, error)
+	EditProjectPushRule(pid interface{}, opt *EditProjectPushRuleOptions, options ...RequestOptionFunc) (*ProjectPushRules, *Response, error)
+	DeleteProjectPushRule(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetApprovalConfiguration(pid interface{}, options ...RequestOptionFunc) (*ProjectApprovals, *Response, error)
+	ChangeApprovalConfiguration(pid interface{}, opt *ChangeApprovalConfigurationOptions, options ...RequestOptionFunc) (*ProjectApprovals, *Response, error)
+	GetProjectApprovalRules(pid interface{}, opt *GetProjectApprovalRulesListsOptions, options ...RequestOptionFunc) ([]*ProjectApprovalRule, *Response, error)
+	GetProjectApprovalRule(pid interface{}, ruleID int, options ...RequestOptionFunc) (*ProjectApprovalRule, *Response, error)
+	CreateProjectApprovalRule(pid interface{}, opt *CreateProjectLevelRuleOptions, options ...RequestOptionFunc) (*ProjectApprovalRule, *Response, error)
+	UpdateProjectApprovalRule(pid interface{}, approvalRule int, opt *UpdateProjectLevelRuleOptions, options ...RequestOptionFunc) (*ProjectApprovalRule, *Response, error)
+	DeleteProjectApprovalRule(pid interface{}, approvalRule int, options ...RequestOptionFunc) (*Response, error)
+	ChangeAllowedApprovers(pid interface{}, opt *ChangeAllowedApproversOptions, options ...RequestOptionFunc) (*ProjectApprovals, *Response, error)
+	GetProjectPullMirrorDetails(pid interface{}, options ...RequestOptionFunc) (*ProjectPullMirrorDetails, *Response, error)
+	StartMirroringProject(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	TransferProject(pid interface{}, opt *TransferProjectOptions, options ...RequestOptionFunc) (*Project, *Response, error)
+	StartHousekeepingProject(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetRepositoryStorage(pid interface{}, options ...RequestOptionFunc) (*ProjectReposityStorage, *Response, error)
+}
+
+var _ ProjectsServiceInterface = (*ProjectsService)(nil)
+
+// ProtectedBranchesServiceInterface defines all the API methods
+// of the ProtectedBranchesService.
+type ProtectedBranchesServiceInterface interface {
+	ListProtectedBranches(pid interface{}, opt *ListProtectedBranchesOptions, options ...RequestOptionFunc) ([]*ProtectedBranch, *Response, error)
+	GetProtectedBranch(pid interface{}, branch string, options ...RequestOptionFunc) (*ProtectedBranch, *Response, error)
+	ProtectRepositoryBranches(pid interface{}, opt *ProtectRepositoryBranchesOptions, options ...RequestOptionFunc) (*ProtectedBranch, *Response, error)
+	UnprotectRepositoryBranches(pid interface{}, branch string, options ...RequestOptionFunc) (*Response, error)
+	UpdateProtectedBranch(pid interface{}, branch string, opt *UpdateProtectedBranchOptions, options ...RequestOptionFunc) (*ProtectedBranch, *Response, error)
+	RequireCodeOwnerApprovals(pid interface{}, branch string, opt *RequireCodeOwnerApprovalsOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProtectedBranchesServiceInterface = (*ProtectedBranchesService)(nil)
+
+// ProtectedEnvironmentsServiceInterface defines all the API methods
+// of the ProtectedEnvironmentsService.
+type ProtectedEnvironmentsServiceInterface interface {
+	ListProtectedEnvironments(pid interface{}, opt *ListProtectedEnvironmentsOptions, options ...RequestOptionFunc) ([]*ProtectedEnvironment, *Response, error)
+	GetProtectedEnvironment(pid interface{}, environment string, options ...RequestOptionFunc) (*ProtectedEnvironment, *Response, error)
+	ProtectRepositoryEnvironments(pid interface{}, opt *ProtectRepositoryEnvironmentsOptions, options ...RequestOptionFunc) (*ProtectedEnvironment, *Response, error)
+	UpdateProtectedEnvironments(pid interface{}, environment string, opt *UpdateProtectedEnvironmentsOptions, options ...RequestOptionFunc) (*ProtectedEnvironment, *Response, error)
+	UnprotectEnvironment(pid interface{}, environment string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProtectedEnvironmentsServiceInterface = (*ProtectedEnvironmentsService)(nil)
+
+// ProtectedTagsServiceInterface defines all the API methods
+// of the ProtectedTagsService.
+type ProtectedTagsServiceInterface interface {
+	ListProtectedTags(pid interface{}, opt *ListProtectedTagsOptions, options ...RequestOptionFunc) ([]*ProtectedTag, *Response, error)
+	GetProtectedTag(pid interface{}, tag string, options ...RequestOptionFunc) (*ProtectedTag, *Response, error)
+	ProtectRepositoryTags(pid interface{}, opt *ProtectRepositoryTagsOptions, options ...RequestOptionFunc) (*ProtectedTag, *Response, error)
+	UnprotectRepositoryTags(pid interface{}, tag string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ProtectedTagsServiceInterface = (*ProtectedTagsService)(nil)
+
+// ReleaseLinksServiceInterface defines all the API methods
+// of the ReleaseLinksService.
+type ReleaseLinksServiceInterface interface {
+	ListReleaseLinks(pid interface{}, tagName string, opt *ListReleaseLinksOptions, options ...RequestOptionFunc) ([]*ReleaseLink, *Response, error)
+	GetReleaseLink(pid interface{}, tagName string, link int, options ...RequestOptionFunc) (*ReleaseLink, *Response, error)
+	CreateReleaseLink(pid interface{}, tagName string, opt *CreateReleaseLinkOptions, options ...RequestOptionFunc) (*ReleaseLink, *Response, error)
+	UpdateReleaseLink(pid interface{}, tagName string, link int, opt *UpdateReleaseLinkOptions, options ...RequestOptionFunc) (*ReleaseLink, *Response, error)
+	DeleteReleaseLink(pid interface{}, tagName string, link int, options ...RequestOptionFunc) (*ReleaseLink, *Response, error)
+}
+
+var _ ReleaseLinksServiceInterface = (*ReleaseLinksService)(nil)
+
+// ReleasesServiceInterface defines all the API methods of the ReleasesService.
+type ReleasesServiceInterface interface {
+	ListReleases(pid interface{}, opt *ListReleasesOptions, options ...RequestOptionFunc) ([]*Release, *Response, error)
+	GetRelease(pid interface{}, tagName string, options ...RequestOptionFunc) (*Release, *Response, error)
+	GetLatestRelease(pid interface{}, options ...RequestOptionFunc) (*Release, *Response, error)
+	CreateRelease(pid interface{}, opts *CreateReleaseOptions, options ...RequestOptionFunc) (*Release, *Response, error)
+	UpdateRelease(pid interface{}, tagName string, opts *UpdateReleaseOptions, options ...RequestOptionFunc) (*Release, *Response, error)
+	DeleteRelease(pid interface{}, tagName string, options ...RequestOptionFunc) (*Release, *Response, error)
+}
+
+var _ ReleasesServiceInterface = (*ReleasesService)(nil)
+
+// RepositoriesServiceInterface defines all the API methods
+// of the RepositoriesService.
+type RepositoriesServiceInterface interface {
+	ListTree(pid interface{}, opt *ListTreeOptions, options ...RequestOptionFunc) ([]*TreeNode, *Response, error)
+	Blob(pid interface{}, sha string, options ...RequestOptionFunc) ([]byte, *Response, error)
+	RawBlobContent(pid interface{}, sha string, options ...RequestOptionFunc) ([]byte, *Response, error)
+	Archive(pid interface{}, opt *ArchiveOptions, options ...RequestOptionFunc) ([]byte, *Response, error)
+	StreamArchive(pid interface{}, w io.Writer, opt *ArchiveOptions, options ...RequestOptionFunc) (*Response, error)
+	Compare(pid interface{}, opt *CompareOptions, options ...RequestOptionFunc) (*Compare, *Response, error)
+	Contributors(pid interface{}, opt *ListContributorsOptions, options ...RequestOptionFunc) ([]*Contributor, *Response, error)
+	MergeBase(pid interface{}, opt *MergeBaseOptions, options ...RequestOptionFunc) (*Commit, *Response, error)
+	AddChangelog(pid interface{}, opt *AddChangelogOptions, options ...RequestOptionFunc) (*Response, error)
+	GenerateChangelogData(pid interface{}, opt GenerateChangelogDataOptions, options ...RequestOptionFunc) (*ChangelogData, *Response, error)
+	NewFS(pid interface{}, ref string, options ...RequestOptionFunc) *RepositoryFS
+	SyncDirectory(pid interface{}, fsys fs.FS, opt *SyncDirectoryOptions, options ...RequestOptionFunc) (*SyncDirectoryResult, *Response, error)
+}
+
+var _ RepositoriesServiceInterface = (*RepositoriesService)(nil)
+
+// RepositoryFilesServiceInterface defines all the API methods
+// of the RepositoryFilesService.
+type RepositoryFilesServiceInterface interface {
+	GetFile(pid interface{}, fileName string, opt *GetFileOptions, options ...RequestOptionFunc) (*File, *Response, error)
+	GetFileMetaData(pid interface{}, fileName string, opt *GetFileMetaDataOptions, options ...RequestOptionFunc) (*File, *Response, error)
+	GetFileBlame(pid interface{}, file string, opt *GetFileBlameOptions, options ...RequestOptionFunc) ([]*FileBlameRange, *Response, error)
+	GetRawFile(pid interface{}, fileName string, opt *GetRawFileOptions, options ...RequestOptionFunc) ([]byte, *Response, error)
+	CreateFile(pid interface{}, fileName string, opt *CreateFileOptions, options ...RequestOptionFunc) (*FileInfo, *Response, error)
+	UpdateFile(pid interface{}, fileName string, opt *UpdateFileOptions, options ...RequestOptionFunc) (*FileInfo, *Response, error)
+	DeleteFile(pid interface{}, fileName string, opt *DeleteFileOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ RepositoryFilesServiceInterface = (*RepositoryFilesService)(nil)
+
+// RepositorySubmodulesServiceInterface defines all the API methods
+// of the RepositorySubmodulesService.
+type RepositorySubmodulesServiceInterface interface {
+	UpdateSubmodule(pid interface{}, submodule string, opt *UpdateSubmoduleOptions, options ...RequestOptionFunc) (*SubmoduleCommit, *Response, error)
+}
+
+var _ RepositorySubmodulesServiceInterface = (*RepositorySubmodulesService)(nil)
+
+// ResourceGroupServiceInterface defines all the API methods
+// of the ResourceGroupService.
+type ResourceGroupServiceInterface interface {
+	GetAllResourceGroupsForAProject(pid interface{}, options ...RequestOptionFunc) ([]*ResourceGroup, *Response, error)
+	GetASpecificResourceGroup(pid interface{}, key string, options ...RequestOptionFunc) (*ResourceGroup, *Response, error)
+	ListUpcomingJobsForASpecificResourceGroup(pid interface{}, key string, options ...RequestOptionFunc) ([]*Job, *Response, error)
+	EditAnExistingResourceGroup(pid interface{}, key string, opts *EditAnExistingResourceGroupOptions, options ...RequestOptionFunc) (*ResourceGroup, *Response, error)
+}
+
+var _ ResourceGroupServiceInterface = (*ResourceGroupService)(nil)
+
+// ResourceIterationEventsServiceInterface defines all the API methods
+// of the ResourceIterationEventsService.
+type ResourceIterationEventsServiceInterface interface {
+	ListIssueIterationEvents(pid interface{}, issue int, opt *ListIterationEventsOptions, options ...RequestOptionFunc) ([]*IterationEvent, *Response, error)
+	GetIssueIterationEvent(pid interface{}, issue int, event int, options ...RequestOptionFunc) (*IterationEvent, *Response, error)
+}
+
+var _ ResourceIterationEventsServiceInterface = (*ResourceIterationEventsService)(nil)
+
+// ResourceLabelEventsServiceInterface defines all the API methods
+// of the ResourceLabelEventsService.
+type ResourceLabelEventsServiceInterface interface {
+	ListIssueLabelEvents(pid interface{}, issue int, opt *ListLabelEventsOptions, options ...RequestOptionFunc) ([]*LabelEvent, *Response, error)
+	GetIssueLabelEvent(pid interface{}, issue int, event int, options ...RequestOptionFunc) (*LabelEvent, *Response, error)
+	ListGroupEpicLabelEvents(gid interface{}, epic int, opt *ListLabelEventsOptions, options ...RequestOptionFunc) ([]*LabelEvent, *Response, error)
+	GetGroupEpicLabelEvent(gid interface{}, epic int, event int, options ...RequestOptionFunc) (*LabelEvent, *Response, error)
+	ListMergeRequestsLabelEvents(pid interface{}, request int, opt *ListLabelEventsOptions, options ...RequestOptionFunc) ([]*LabelEvent, *Response, error)
+	GetMergeRequestLabelEvent(pid interface{}, request int, event int, options ...RequestOptionFunc) (*LabelEvent, *Response, error)
+}
+
+var _ ResourceLabelEventsServiceInterface = (*ResourceLabelEventsService)(nil)
+
+// ResourceMilestoneEventsServiceInterface defines all the API methods
+// of the ResourceMilestoneEventsService.
+type ResourceMilestoneEventsServiceInterface interface {
+	ListIssueMilestoneEvents(pid interface{}, issue int, opt *ListMilestoneEventsOptions, options ...RequestOptionFunc) ([]*MilestoneEvent, *Response, error)
+	GetIssueMilestoneEvent(pid interface{}, issue int, event int, options ...RequestOptionFunc) (*MilestoneEvent, *Response, error)
+	ListMergeMilestoneEvents(pid interface{}, request int, opt *ListMilestoneEventsOptions, options ...RequestOptionFunc) ([]*MilestoneEvent, *Response, error)
+	GetMergeRequestMilestoneEvent(pid interface{}, request int, event int, options ...RequestOptionFunc) (*MilestoneEvent, *Response, error)
+}
+
+var _ ResourceMilestoneEventsServiceInterface = (*ResourceMilestoneEventsService)(nil)
+
+// ResourceStateEventsServiceInterface defines all the API methods
+// of the ResourceStateEventsService.
+type ResourceStateEventsServiceInterface interface {
+	ListIssueStateEvents(pid interface{}, issue int, opt *ListStateEventsOptions, options ...RequestOptionFunc) ([]*StateEvent, *Response, error)
+	GetIssueStateEvent(pid interface{}, issue int, event int, options ...RequestOptionFunc) (*StateEvent, *Response, error)
+	ListMergeStateEvents(pid interface{}, request int, opt *ListStateEventsOptions, options ...RequestOptionFunc) ([]*StateEvent, *Response, error)
+	GetMergeRequestStateEvent(pid interface{}, request int, event int, options ...RequestOptionFunc) (*StateEvent, *Response, error)
+}
+
+var _ ResourceStateEventsServiceInterface = (*ResourceStateEventsService)(nil)
+
+// ResourceWeightEventsServiceInterface defines all the API methods
+// of the ResourceWeightEventsService.
+type ResourceWeightEventsServiceInterface interface {
+	ListIssueWeightEvents(pid interface{}, issue int, opt *ListWeightEventsOptions, options ...RequestOptionFunc) ([]*WeightEvent, *Response, error)
+}
+
+var _ ResourceWeightEventsServiceInterface = (*ResourceWeightEventsService)(nil)
+
+// RunnersServiceInterface defines all the API methods of the RunnersService.
+type RunnersServiceInterface interface {
+	ListRunners(opt *ListRunnersOptions, options ...RequestOptionFunc) ([]*Runner, *Response, error)
+	ListAllRunners(opt *ListRunnersOptions, options ...RequestOptionFunc) ([]*Runner, *Response, error)
+	GetRunnerDetails(rid interface{}, options ...RequestOptionFunc) (*RunnerDetails, *Response, error)
+	UpdateRunnerDetails(rid interface{}, opt *UpdateRunnerDetailsOptions, options ...RequestOptionFunc) (*RunnerDetails, *Response, error)
+	RemoveRunner(rid interface{}, options ...RequestOptionFunc) (*Response, error)
+	ListRunnerJobs(rid interface{}, opt *ListRunnerJobsOptions, options ...RequestOptionFunc) ([]*Job, *Response, error)
+	ListProjectRunners(pid interface{}, opt *ListProjectRunnersOptions, options ...RequestOptionFunc) ([]*Runner, *Response, error)
+	EnableProjectRunner(pid interface{}, opt *EnableProjectRunnerOptions, options ...RequestOptionFunc) (*Runner, *Response, error)
+	DisableProjectRunner(pid interface{}, runner int, options ...RequestOptionFunc) (*Response, error)
+	ListGroupsRunners(gid interface{}, opt *ListGroupsRunnersOptions, options ...RequestOptionFunc) ([]*Runner, *Response, error)
+	RegisterNewRunner(opt *RegisterNewRunnerOptions, options ...RequestOptionFunc) (*Runner, *Response, error)
+	DeleteRegisteredRunner(opt *DeleteRegisteredRunnerOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteRegisteredRunnerByID(rid int, options ...RequestOptionFunc) (*Response, error)
+	VerifyRegisteredRunner(opt *VerifyRegisteredRunnerOptions, options ...RequestOptionFunc) (*Response, error)
+	ResetInstanceRunnerRegistrationToken(options ...RequestOptionFunc) (*RunnerRegistrationToken, *Response, error)
+	ResetGroupRunnerRegistrationToken(gid interface{}, options ...RequestOptionFunc) (*RunnerRegistrationToken, *Response, error)
+	ResetProjectRunnerRegistrationToken(pid interface{}, options ...RequestOptionFunc) (*RunnerRegistrationToken, *Response, error)
+	ResetRunnerAuthenticationToken(rid int, options ...RequestOptionFunc) (*RunnerAuthenticationToken, *Response, error)
+}
+
+var _ RunnersServiceInterface = (*RunnersService)(nil)
+
+// SearchServiceInterface defines all the API methods of the SearchService.
+type SearchServiceInterface interface {
+	Projects(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	ProjectsByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Project, *Response, error)
+	Issues(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	IssuesByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	IssuesByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Issue, *Response, error)
+	MergeRequests(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	MergeRequestsByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	MergeRequestsByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*MergeRequest, *Response, error)
+	Milestones(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Milestone, *Response, error)
+	MilestonesByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Milestone, *Response, error)
+	MilestonesByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Milestone, *Response, error)
+	SnippetTitles(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Snippet, *Response, error)
+	SnippetBlobs(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Snippet, *Response, error)
+	NotesByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Note, *Response, error)
+	WikiBlobs(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Wiki, *Response, error)
+	WikiBlobsByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Wiki, *Response, error)
+	WikiBlobsByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Wiki, *Response, error)
+	Commits(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Commit, *Response, error)
+	CommitsByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Commit, *Response, error)
+	CommitsByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Commit, *Response, error)
+	Blobs(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Blob, *Response, error)
+	BlobsByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Blob, *Response, error)
+	BlobsByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*Blob, *Response, error)
+	Users(query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*User, *Response, error)
+	UsersByGroup(gid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*User, *Response, error)
+	UsersByProject(pid interface{}, query string, opt *SearchOptions, options ...RequestOptionFunc) ([]*User, *Response, error)
+}
+
+var _ SearchServiceInterface = (*SearchService)(nil)
+
+// ServicesServiceInterface defines all the API methods of the ServicesService.
+type ServicesServiceInterface interface {
+	ListServices(pid interface{}, options ...RequestOptionFunc) ([]*Service, *Response, error)
+	GetCustomIssueTrackerService(pid interface{}, options ...RequestOptionFunc) (*CustomIssueTrackerService, *Response, error)
+	SetCustomIssueTrackerService(pid interface{}, opt *SetCustomIssueTrackerServiceOptions, options ...RequestOptionFunc) (*CustomIssueTrackerService, *Response, error)
+	DeleteCustomIssueTrackerService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetDataDogService(pid interface{}, options ...RequestOptionFunc) (*DataDogService, *Response, error)
+	SetDataDogService(pid interface{}, opt *SetDataDogServiceOptions, options ...RequestOptionFunc) (*DataDogService, *Response, error)
+	DeleteDataDogService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetDiscordService(pid interface{}, options ...RequestOptionFunc) (*DiscordService, *Response, error)
+	SetDiscordService(pid interface{}, opt *SetDiscordServiceOptions, options ...RequestOptionFunc) (*DiscordService, *Response, error)
+	DeleteDiscordService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetDroneCIService(pid interface{}, options ...RequestOptionFunc) (*DroneCIService, *Response, error)
+	SetDroneCIService(pid interface{}, opt *SetDroneCIServiceOptions, options ...RequestOptionFunc) (*DroneCIService, *Response, error)
+	DeleteDroneCIService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetEmailsOnPushService(pid interface{}, options ...RequestOptionFunc) (*EmailsOnPushService, *Response, error)
+	SetEmailsOnPushService(pid interface{}, opt *SetEmailsOnPushServiceOptions, options ...RequestOptionFunc) (*EmailsOnPushService, *Response, error)
+	DeleteEmailsOnPushService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetExternalWikiService(pid interface{}, options ...RequestOptionFunc) (*ExternalWikiService, *Response, error)
+	SetExternalWikiService(pid interface{}, opt *SetExternalWikiServiceOptions, options ...RequestOptionFunc) (*ExternalWikiService, *Response, error)
+	DeleteExternalWikiService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetGithubService(pid interface{}, options ...RequestOptionFunc) (*GithubService, *Response, error)
+	SetGithubService(pid interface{}, opt *SetGithubServiceOptions, options ...RequestOptionFunc) (*GithubService, *Response, error)
+	DeleteGithubService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetHarborService(pid interface{}, options ...RequestOptionFunc) (*HarborService, *Response, error)
+	SetHarborService(pid interface{}, opt *SetHarborServiceOptions, options ...RequestOptionFunc) (*HarborService, *Response, error)
+	DeleteHarborService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetSlackApplication(pid interface{}, options ...RequestOptionFunc) (*SlackApplication, *Response, error)
+	SetSlackApplication(pid interface{}, opt *SetSlackApplicationOptions, options ...RequestOptionFunc) (*SlackApplication, *Response, error)
+	DisableSlackApplication(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	SetGitLabCIService(pid interface{}, opt *SetGitLabCIServiceOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteGitLabCIService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	SetHipChatService(pid interface{}, opt *SetHipChatServiceOptions, options ...RequestOptionFunc) (*Response, error)
+	DeleteHipChatService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetJenkinsCIService(pid interface{}, options ...RequestOptionFunc) (*JenkinsCIService, *Response, error)
+	SetJenkinsCIService(pid interface{}, opt *SetJenkinsCIServiceOptions, options ...RequestOptionFunc) (*JenkinsCIService, *Response, error)
+	DeleteJenkinsCIService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetJiraService(pid interface{}, options ...RequestOptionFunc) (*JiraService, *Response, error)
+	SetJiraService(pid interface{}, opt *SetJiraServiceOptions, options ...RequestOptionFunc) (*JiraService, *Response, error)
+	DeleteJiraService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetMattermostService(pid interface{}, options ...RequestOptionFunc) (*MattermostService, *Response, error)
+	SetMattermostService(pid interface{}, opt *SetMattermostServiceOptions, options ...RequestOptionFunc) (*MattermostService, *Response, error)
+	DeleteMattermostService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetMattermostSlashCommandsService(pid interface{}, options ...RequestOptionFunc) (*MattermostSlashCommandsService, *Response, error)
+	SetMattermostSlashCommandsService(pid interface{}, opt *SetMattermostSlashCommandsServiceOptions, options ...RequestOptionFunc) (*MattermostSlashCommandsService, *Response, error)
+	DeleteMattermostSlashCommandsService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetMicrosoftTeamsService(pid interface{}, options ...RequestOptionFunc) (*MicrosoftTeamsService, *Response, error)
+	SetMicrosoftTeamsService(pid interface{}, opt *SetMicrosoftTeamsServiceOptions, options ...RequestOptionFunc) (*MicrosoftTeamsService, *Response, error)
+	DeleteMicrosoftTeamsService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetPipelinesEmailService(pid interface{}, options ...RequestOptionFunc) (*PipelinesEmailService, *Response, error)
+	SetPipelinesEmailService(pid interface{}, opt *SetPipelinesEmailServiceOptions, options ...RequestOptionFunc) (*PipelinesEmailService, *Response, error)
+	DeletePipelinesEmailService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetPrometheusService(pid interface{}, options ...RequestOptionFunc) (*PrometheusService, *Response, error)
+	SetPrometheusService(pid interface{}, opt *SetPrometheusServiceOptions, options ...RequestOptionFunc) (*PrometheusService, *Response, error)
+	DeletePrometheusService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetRedmineService(pid interface{}, options ...RequestOptionFunc) (*RedmineService, *Response, error)
+	SetRedmineService(pid interface{}, opt *SetRedmineServiceOptions, options ...RequestOptionFunc) (*RedmineService, *Response, error)
+	DeleteRedmineService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetSlackService(pid interface{}, options ...RequestOptionFunc) (*SlackService, *Response, error)
+	SetSlackService(pid interface{}, opt *SetSlackServiceOptions, options ...RequestOptionFunc) (*SlackService, *Response, error)
+	DeleteSlackService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetSlackSlashCommandsService(pid interface{}, options ...RequestOptionFunc) (*SlackSlashCommandsService, *Response, error)
+	SetSlackSlashCommandsService(pid interface{}, opt *SetSlackSlashCommandsServiceOptions, options ...RequestOptionFunc) (*SlackSlashCommandsService, *Response, error)
+	DeleteSlackSlashCommandsService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetTelegramService(pid interface{}, options ...RequestOptionFunc) (*TelegramService, *Response, error)
+	SetTelegramService(pid interface{}, opt *SetTelegramServiceOptions, options ...RequestOptionFunc) (*TelegramService, *Response, error)
+	DeleteTelegramService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+	GetYouTrackService(pid interface{}, options ...RequestOptionFunc) (*YouTrackService, *Response, error)
+	SetYouTrackService(pid interface{}, opt *SetYouTrackServiceOptions, options ...RequestOptionFunc) (*YouTrackService, *Response, error)
+	DeleteYouTrackService(pid interface{}, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ ServicesServiceInterface = (*ServicesService)(nil)
+
+// SettingsServiceInterface defines all the API methods of the SettingsService.
+type SettingsServiceInterface interface {
+	GetSettings(options ...RequestOptionFunc) (*Settings, *Response, error)
+	UpdateSettings(opt *UpdateSettingsOptions, options ...RequestOptionFunc) (*Settings, *Response, error)
+}
+
+var _ SettingsServiceInterface = (*SettingsService)(nil)
+
+// SidekiqServiceInterface defines all the API methods of the SidekiqService.
+type SidekiqServiceInterface interface {
+	GetQueueMetrics(options ...RequestOptionFunc) (*QueueMetrics, *Response, error)
+	GetProcessMetrics(options ...RequestOptionFunc) (*ProcessMetrics, *Response, error)
+	GetJobStats(options ...RequestOptionFunc) (*JobStats, *Response, error)
+	GetCompoundMetrics(options ...RequestOptionFunc) (*CompoundMetrics, *Response, error)
+}
+
+var _ SidekiqServiceInterface = (*SidekiqService)(nil)
+
+// SnippetRepositoryStorageMoveServiceInterface defines all the API methods
+// of the SnippetRepositoryStorageMoveService.
+type SnippetRepositoryStorageMoveServiceInterface interface {
+	RetrieveAllStorageMoves(opts RetrieveAllSnippetStorageMovesOptions, options ...RequestOptionFunc) ([]*SnippetRepositoryStorageMove, *Response, error)
+	RetrieveAllStorageMovesForSnippet(snippet int, opts RetrieveAllSnippetStorageMovesOptions, options ...RequestOptionFunc) ([]*SnippetRepositoryStorageMove, *Response, error)
+	GetStorageMove(repositoryStorage int, options ...RequestOptionFunc) (*SnippetRepositoryStorageMove, *Response, error)
+	GetStorageMoveForSnippet(snippet int, repositoryStorage int, options ...RequestOptionFunc) (*SnippetRepositoryStorageMove, *Response, error)
+	ScheduleStorageMoveForSnippet(snippet int, opts ScheduleStorageMoveForSnippetOptions, options ...RequestOptionFunc) (*SnippetRepositoryStorageMove, *Response, error)
+	ScheduleAllStorageMoves(opts ScheduleAllSnippetStorageMovesOptions, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ SnippetRepositoryStorageMoveServiceInterface = (*SnippetRepositoryStorageMoveService)(nil)
+
+// SnippetsServiceInterface defines all the API methods of the SnippetsService.
+type SnippetsServiceInterface interface {
+	ListSnippets(opt *ListSnippetsOptions, options ...RequestOptionFunc) ([]*Snippet, *Response, error)
+	GetSnippet(snippet int, options ...RequestOptionFunc) (*Snippet, *Response, error)
+	SnippetContent(snippet int, options ...RequestOptionFunc) ([]byte, *Response, error)
+	SnippetFileContent(snippet int, ref, filename string, options ...RequestOptionFunc) ([]byte, *Response, error)
+	CreateSnippet(opt *CreateSnippetOptions, options ...RequestOptionFunc) (*Snippet, *Response, error)
+	UpdateSnippet(snippet int, opt *UpdateSnippetOptions, options ...RequestOptionFunc) (*Snippet, *Response, error)
+	DeleteSnippet(snippet int, options ...RequestOptionFunc) (*Response, error)
+	ExploreSnippets(opt *ExploreSnippetsOptions, options ...RequestOptionFunc) ([]*Snippet, *Response, error)
+	ListAllSnippets(opt *ListAllSnippetsOptions, options ...RequestOptionFunc) ([]*Snippet, *Response, error)
+}
+
+var _ SnippetsServiceInterface = (*SnippetsService)(nil)
+
+// SystemHooksServiceInterface defines all the API methods
+// of the SystemHooksService.
+type SystemHooksServiceInterface interface {
+	ListHooks(options ...RequestOptionFunc) ([]*Hook, *Response, error)
+	GetHook(hook int, options ...RequestOptionFunc) (*Hook, *Response, error)
+	AddHook(opt *AddHookOptions, options ...RequestOptionFunc) (*Hook, *Response, error)
+	TestHook(hook int, options ...RequestOptionFunc) (*HookEvent, *Response, error)
+	DeleteHook(hook int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ SystemHooksServiceInterface = (*SystemHooksService)(nil)
+
+// TagsServiceInterface defines all the API methods of the TagsService.
+type TagsServiceInterface interface {
+	ListTags(pid interface{}, opt *ListTagsOptions, options ...RequestOptionFunc) ([]*Tag, *Response, error)
+	GetTag(pid interface{}, tag string, options ...RequestOptionFunc) (*Tag, *Response, error)
+	CreateTag(pid interface{}, opt *CreateTagOptions, options ...RequestOptionFunc) (*Tag, *Response, error)
+	DeleteTag(pid interface{}, tag string, options ...RequestOptionFunc) (*Response, error)
+	CreateReleaseNote(pid interface{}, tag string, opt *CreateReleaseNoteOptions, options ...RequestOptionFunc) (*ReleaseNote, *Response, error)
+	UpdateReleaseNote(pid interface{}, tag string, opt *UpdateReleaseNoteOptions, options ...RequestOptionFunc) (*ReleaseNote, *Response, error)
+}
+
+var _ TagsServiceInterface = (*TagsService)(nil)
+
+// TodosServiceInterface defines all the API methods of the TodosService.
+type TodosServiceInterface interface {
+	ListTodos(opt *ListTodosOptions, options ...RequestOptionFunc) ([]*Todo, *Response, error)
+	MarkTodoAsDone(id int, options ...RequestOptionFunc) (*Response, error)
+	MarkAllTodosAsDone(options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ TodosServiceInterface = (*TodosService)(nil)
+
+// TopicsServiceInterface defines all the API methods of the TopicsService.
+type TopicsServiceInterface interface {
+	ListTopics(opt *ListTopicsOptions, options ...RequestOptionFunc) ([]*Topic, *Response, error)
+	GetTopic(topic int, options ...RequestOptionFunc) (*Topic, *Response, error)
+	CreateTopic(opt *CreateTopicOptions, options ...RequestOptionFunc) (*Topic, *Response, error)
+	UpdateTopic(topic int, opt *UpdateTopicOptions, options ...RequestOptionFunc) (*Topic, *Response, error)
+	DeleteTopic(topic int, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ TopicsServiceInterface = (*TopicsService)(nil)
+
+// UsersServiceInterface defines all the API methods of the UsersService.
+type UsersServiceInterface interface {
+	ListUserContributionEvents(uid interface{}, opt *ListContributionEventsOptions, options ...RequestOptionFunc) ([]*ContributionEvent, *Response, error)
+	ListUsers(opt *ListUsersOptions, options ...RequestOptionFunc) ([]*User, *Response, error)
+	GetUser(user int, opt GetUsersOptions, options ...RequestOptionFunc) (*User, *Response, error)
+	CreateUser(opt *CreateUserOptions, options ...RequestOptionFunc) (*User, *Response, error)
+	ModifyUser(user int, opt *ModifyUserOptions, options ...RequestOptionFunc) (*User, *Response, error)
+	DeleteUser(user int, options ...RequestOptionFunc) (*Response, error)
+	CurrentUser(options ...RequestOptionFunc) (*User, *Response, error)
+	CurrentUserStatus(options ...RequestOptionFunc) (*UserStatus, *Response, error)
+	GetUserStatus(user int, options ...RequestOptionFunc) (*UserStatus, *Response, error)
+	SetUserStatus(opt *UserStatusOptions, options ...RequestOptionFunc) (*UserStatus, *Response, error)
+	GetUserAssociationsCount(user int, options ...RequestOptionFunc) (*UserAssociationsCount, *Response, error)
+	ListSSHKeys(opt *ListSSHKeysOptions, options ...RequestOptionFunc) ([]*SSHKey, *Response, error)
+	ListSSHKeysForUser(uid interface{}, opt *ListSSHKeysForUserOptions, options ...RequestOptionFunc) ([]*SSHKey, *Response, error)
+	GetSSHKey(key int, options ...RequestOptionFunc) (*SSHKey, *Response, error)
+	GetSSHKeyForUser(user int, key int, options ...RequestOptionFunc) (*SSHKey, *Response, error)
+	AddSSHKey(opt *AddSSHKeyOptions, options ...RequestOptionFunc) (*SSHKey, *Response, error)
+	AddSSHKeyForUser(user int, opt *AddSSHKeyOptions, options ...RequestOptionFunc) (*SSHKey, *Response, error)
+	DeleteSSHKey(key int, options ...RequestOptionFunc) (*Response, error)
+	DeleteSSHKeyForUser(user, key int, options ...RequestOptionFunc) (*Response, error)
+	ListGPGKeys(options ...RequestOptionFunc) ([]*GPGKey, *Response, error)
+	GetGPGKey(key int, options ...RequestOptionFunc) (*GPGKey, *Response, error)
+	AddGPGKey(opt *AddGPGKeyOptions, options ...RequestOptionFunc) (*GPGKey, *Response, error)
+	DeleteGPGKey(key int, options ...RequestOptionFunc) (*Response, error)
+	ListGPGKeysForUser(user int, options ...RequestOptionFunc) ([]*GPGKey, *Response, error)
+	GetGPGKeyForUser(user, key int, options ...RequestOptionFunc) (*GPGKey, *Response, error)
+	AddGPGKeyForUser(user int, opt *AddGPGKeyOptions, options ...RequestOptionFunc) (*GPGKey, *Response, error)
+	DeleteGPGKeyForUser(user, key int, options ...RequestOptionFunc) (*Response, error)
+	ListEmails(options ...RequestOptionFunc) ([]*Email, *Response, error)
+	ListEmailsForUser(user int, opt *ListEmailsForUserOptions, options ...RequestOptionFunc) ([]*Email, *Response, error)
+	GetEmail(email int, options ...RequestOptionFunc) (*Email, *Response, error)
+	AddEmail(opt *AddEmailOptions, options ...RequestOptionFunc) (*Email, *Response, error)
+	AddEmailForUser(user int, opt *AddEmailOptions, options ...RequestOptionFunc) (*Email, *Response, error)
+	DeleteEmail(email int, options ...RequestOptionFunc) (*Response, error)
+	DeleteEmailForUser(user, email int, options ...RequestOptionFunc) (*Response, error)
+	BlockUser(user int, options ...RequestOptionFunc) error
+	UnblockUser(user int, options ...RequestOptionFunc) error
+	BanUser(user int, options ...RequestOptionFunc) error
+	UnbanUser(user int, options ...RequestOptionFunc) error
+	DeactivateUser(user int, options ...RequestOptionFunc) error
+	ActivateUser(user int, options ...RequestOptionFunc) error
+	ApproveUser(user int, options ...RequestOptionFunc) error
+	RejectUser(user int, options ...RequestOptionFunc) error
+	GetAllImpersonationTokens(user int, opt *GetAllImpersonationTokensOptions, options ...RequestOptionFunc) ([]*ImpersonationToken, *Response, error)
+	GetImpersonationToken(user, token int, options ...RequestOptionFunc) (*ImpersonationToken, *Response, error)
+	CreateImpersonationToken(user int, opt *CreateImpersonationTokenOptions, options ...RequestOptionFunc) (*ImpersonationToken, *Response, error)
+	RevokeImpersonationToken(user, token int, options ...RequestOptionFunc) (*Response, error)
+	CreatePersonalAccessToken(user int, opt *CreatePersonalAccessTokenOptions, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	CreatePersonalAccessTokenForCurrentUser(opt *CreatePersonalAccessTokenForCurrentUserOptions, options ...RequestOptionFunc) (*PersonalAccessToken, *Response, error)
+	GetUserActivities(opt *GetUserActivitiesOptions, options ...RequestOptionFunc) ([]*UserActivity, *Response, error)
+	GetUserMemberships(user int, opt *GetUserMembershipOptions, options ...RequestOptionFunc) ([]*UserMembership, *Response, error)
+	DisableTwoFactor(user int, options ...RequestOptionFunc) error
+	CreateUserRunner(opts *CreateUserRunnerOptions, options ...RequestOptionFunc) (*UserRunner, *Response, error)
+	CreateServiceAccountUser(opts *CreateServiceAccountUserOptions, options ...RequestOptionFunc) (*User, *Response, error)
+	ListServiceAccounts(opt *ListServiceAccountsOptions, options ...RequestOptionFunc) ([]*ServiceAccount, *Response, error)
+	UploadAvatar(avatar io.Reader, filename string, options ...RequestOptionFunc) (*User, *Response, error)
+}
+
+var _ UsersServiceInterface = (*UsersService)(nil)
+
+// ValidateServiceInterface defines all the API methods of the ValidateService.
+type ValidateServiceInterface interface {
+	Lint(opts *LintOptions, options ...RequestOptionFunc) (*LintResult, *Response, error)
+	ProjectNamespaceLint(pid interface{}, opt *ProjectNamespaceLintOptions, options ...RequestOptionFunc) (*ProjectLintResult, *Response, error)
+	ProjectLint(pid interface{}, opt *ProjectLintOptions, options ...RequestOptionFunc) (*ProjectLintResult, *Response, error)
+}
+
+var _ ValidateServiceInterface = (*ValidateService)(nil)
+
+// VersionServiceInterface defines all the API methods of the VersionService.
+type VersionServiceInterface interface {
+	GetVersion(options ...RequestOptionFunc) (*Version, *Response, error)
+}
+
+var _ VersionServiceInterface = (*VersionService)(nil)
+
+// WikisServiceInterface defines all the API methods of the WikisService.
+type WikisServiceInterface interface {
+	ListWikis(pid interface{}, opt *ListWikisOptions, options ...RequestOptionFunc) ([]*Wiki, *Response, error)
+	GetWikiPage(pid interface{}, slug string, opt *GetWikiPageOptions, options ...RequestOptionFunc) (*Wiki, *Response, error)
+	CreateWikiPage(pid interface{}, opt *CreateWikiPageOptions, options ...RequestOptionFunc) (*Wiki, *Response, error)
+	EditWikiPage(pid interface{}, slug string, opt *EditWikiPageOptions, options ...RequestOptionFunc) (*Wiki, *Response, error)
+	DeleteWikiPage(pid interface{}, slug string, options ...RequestOptionFunc) (*Response, error)
+}
+
+var _ WikisServiceInterface = (*WikisService)(nil)
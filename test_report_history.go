@@ -0,0 +1,368 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// defaultTestReportHistoryLimit is the default number of pipelines
+// GetTestReportHistory collects test reports from.
+const defaultTestReportHistoryLimit = 20
+
+// parallelSuffix matches the suffix GitLab adds to the names of parallel
+// jobs, which are also used as test suite names.
+var parallelSuffix = regexp.MustCompile(`\s+\d+/\d+$`)
+
+// GetTestReportHistoryOptions represents the available
+// GetTestReportHistory() options.
+type GetTestReportHistoryOptions struct {
+	// Ref is the branch or tag to collect the test reports of. Required.
+	Ref *string
+
+	// Limit is the number of most recent finished pipelines to collect the
+	// test reports of. Defaults to 20.
+	Limit int
+}
+
+// TestReportHistory represents the aggregated test reports of a number of
+// pipelines.
+type TestReportHistory struct {
+	Ref string `json:"ref"`
+
+	// Pipelines contains the pipelines that have a test report, most
+	// recent first.
+	Pipelines []*PipelineInfo `json:"pipelines"`
+
+	// Tests contains the history of every test, sorted by suite, class name
+	// and name.
+	Tests []*TestHistory `json:"tests"`
+}
+
+// TestHistory represents the results of a single test across pipelines.
+type TestHistory struct {
+	Suite     string `json:"suite"`
+	Classname string `json:"classname"`
+	Name      string `json:"name"`
+	File      string `json:"file,omitempty"`
+
+	SuccessCount int `json:"success_count"`
+	FailedCount  int `json:"failed_count"`
+	SkippedCount int `json:"skipped_count"`
+	ErrorCount   int `json:"error_count"`
+
+	// AverageTime is the average execution time in seconds of the runs that
+	// were not skipped.
+	AverageTime float64 `json:"average_time"`
+
+	// Flaky reports whether the test both passed and failed on the same
+	// commit. FlakySHAs contains those commits.
+	Flaky     bool     `json:"flaky"`
+	FlakySHAs []string `json:"flaky_shas,omitempty"`
+
+	// Runs contains the results of the test, most recent first.
+	Runs []*TestRun `json:"runs"`
+}
+
+// TestRun represents the result of a test in a single pipeline.
+type TestRun struct {
+	PipelineID    int     `json:"pipeline_id"`
+	SHA           string  `json:"sha"`
+	Status        string  `json:"status"`
+	ExecutionTime float64 `json:"execution_time"`
+	StackTrace    string  `json:"stack_trace,omitempty"`
+}
+
+// FailureRate returns the fraction of runs that were not skipped and failed
+// or errored.
+func (t *TestHistory) FailureRate() float64 {
+	runs := t.SuccessCount + t.FailedCount + t.ErrorCount
+	if runs == 0 {
+		return 0
+	}
+	return float64(t.FailedCount+t.ErrorCount) / float64(runs)
+}
+
+// GetTestReportHistory collects the test reports of the most recent finished
+// pipelines for a ref, and aggregates the results per test. Tests are
+// identified by their suite, class name and name, where the suffix of
+// parallel jobs is removed from the suite name.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/api/pipelines.html#get-a-pipelines-test-report
+func (s *PipelinesService) GetTestReportHistory(pid interface{}, opt *GetTestReportHistoryOptions, options ...RequestOptionFunc) (*TestReportHistory, *Response, error) {
+	if opt == nil || opt.Ref == nil || *opt.Ref == "" {
+		return nil, nil, errors.New("ref is required")
+	}
+
+	limit := opt.Limit
+	if limit <= 0 {
+		limit = defaultTestReportHistoryLimit
+	}
+
+	lopt := &ListProjectPipelinesOptions{
+		ListOptions: ListOptions{PerPage: limit},
+		Scope:       Ptr("finished"),
+		Ref:         opt.Ref,
+		OrderBy:     Ptr("id"),
+		Sort:        Ptr("desc"),
+	}
+	if limit > 100 {
+		lopt.PerPage = 100
+	}
+
+	var lastResp *Response
+	pipelines, err := All(context.Background(), func(pageOptions ...RequestOptionFunc) ([]*PipelineInfo, *Response, error) {
+		pipelines, resp, err := s.ListProjectPipelines(pid, lopt, append(pageOptions, options...)...)
+		lastResp = resp
+		return pipelines, resp, err
+	}, WithMaxItems(limit))
+	if err != nil {
+		return nil, lastResp, err
+	}
+
+	h := &TestReportHistory{Ref: *opt.Ref}
+	tests := make(map[[3]string]*TestHistory)
+
+	for _, p := range pipelines {
+		report, resp, err := s.GetPipelineTestReport(pid, p.ID, options...)
+		if err != nil {
+			return nil, resp, err
+		}
+		lastResp = resp
+
+		if report.TotalCount == 0 {
+			continue
+		}
+		h.Pipelines = append(h.Pipelines, p)
+
+		for _, suite := range report.TestSuites {
+			suiteName := parallelSuffix.ReplaceAllString(suite.Name, "")
+
+			for _, tc := range suite.TestCases {
+				key := [3]string{suiteName, tc.Classname, tc.Name}
+				t, ok := tests[key]
+				if !ok {
+					t = &TestHistory{Suite: suiteName, Classname: tc.Classname, Name: tc.Name, File: tc.File}
+					tests[key] = t
+					h.Tests = append(h.Tests, t)
+				}
+
+				t.Runs = append(t.Runs, &TestRun{
+					PipelineID:    p.ID,
+					SHA:           p.SHA,
+					Status:        tc.Status,
+					ExecutionTime: tc.ExecutionTime,
+					StackTrace:    tc.StackTrace,
+				})
+			}
+		}
+	}
+
+	for _, t := range h.Tests {
+		t.aggregate()
+	}
+	sort.Slice(h.Tests, func(i, j int) bool {
+		a, b := h.Tests[i], h.Tests[j]
+		if a.Suite != b.Suite {
+			return a.Suite < b.Suite
+		}
+		if a.Classname != b.Classname {
+			return a.Classname < b.Classname
+		}
+		return a.Name < b.Name
+	})
+
+	return h, lastResp, nil
+}
+
+// aggregate computes the counts, average time and flakiness from the runs.
+func (t *TestHistory) aggregate() {
+	var total float64
+	passed := make(map[string]bool)
+	failed := make(map[string]bool)
+
+	for _, run := range t.Runs {
+		switch run.Status {
+		case "success":
+			t.SuccessCount++
+			passed[run.SHA] = true
+		case "failed":
+			t.FailedCount++
+			failed[run.SHA] = true
+		case "error":
+			t.ErrorCount++
+			failed[run.SHA] = true
+		case "skipped":
+			t.SkippedCount++
+			continue
+		}
+		total += run.ExecutionTime
+	}
+
+	if runs := len(t.Runs) - t.SkippedCount; runs > 0 {
+		t.AverageTime = total / float64(runs)
+	}
+
+	for _, run := range t.Runs {
+		if passed[run.SHA] && failed[run.SHA] {
+			t.Flaky = true
+			t.FlakySHAs = append(t.FlakySHAs, run.SHA)
+			delete(passed, run.SHA)
+		}
+	}
+}
+
+// FlakyTests returns the tests that both passed and failed on the same
+// commit.
+func (h *TestReportHistory) FlakyTests() []*TestHistory {
+	var tests []*TestHistory
+	for _, t := range h.Tests {
+		if t.Flaky {
+			tests = append(tests, t)
+		}
+	}
+	return tests
+}
+
+// WriteJSON writes the history as indented JSON to w.
+func (h *TestReportHistory) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(h)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Cases    []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	Classname  string           `xml:"classname,attr"`
+	File       string           `xml:"file,attr,omitempty"`
+	Time       string           `xml:"time,attr"`
+	Properties []*junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitResult     `xml:"failure,omitempty"`
+	Error      *junitResult     `xml:"error,omitempty"`
+	Skipped    *junitResult     `xml:"skipped,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the most recent result of every test as a JUnit XML
+// report to w. Every test case has properties with its failure rate and
+// whether it is flaky.
+func (h *TestReportHistory) WriteJUnit(w io.Writer) error {
+	report := &junitTestSuites{Name: h.Ref}
+	suites := make(map[string]*junitTestSuite)
+	times := make(map[string]float64)
+
+	for _, t := range h.Tests {
+		if len(t.Runs) == 0 {
+			continue
+		}
+		latest := t.Runs[0]
+
+		suite, ok := suites[t.Suite]
+		if !ok {
+			suite = &junitTestSuite{Name: t.Suite}
+			suites[t.Suite] = suite
+			report.Suites = append(report.Suites, suite)
+		}
+
+		tc := &junitTestCase{
+			Name:      t.Name,
+			Classname: t.Classname,
+			File:      t.File,
+			Time:      formatJUnitTime(latest.ExecutionTime),
+			Properties: []*junitProperty{
+				{Name: "flaky", Value: strconv.FormatBool(t.Flaky)},
+				{Name: "failure_rate", Value: strconv.FormatFloat(t.FailureRate(), 'f', 4, 64)},
+			},
+		}
+		switch latest.Status {
+		case "failed":
+			tc.Failure = &junitResult{Message: fmt.Sprintf("failed in pipeline %d", latest.PipelineID), Text: latest.StackTrace}
+			suite.Failures++
+		case "error":
+			tc.Error = &junitResult{Message: fmt.Sprintf("errored in pipeline %d", latest.PipelineID), Text: latest.StackTrace}
+			suite.Errors++
+		case "skipped":
+			tc.Skipped = &junitResult{}
+			suite.Skipped++
+		}
+
+		suite.Tests++
+		suite.Cases = append(suite.Cases, tc)
+		times[t.Suite] += latest.ExecutionTime
+	}
+
+	for _, suite := range report.Suites {
+		suite.Time = formatJUnitTime(times[suite.Name])
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func formatJUnitTime(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
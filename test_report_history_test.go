@@ -0,0 +1,134 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func handleTestReportHistory(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("/api/v4/projects/1/pipelines", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testParams(t, r, "order_by=id&per_page=3&ref=main&scope=finished&sort=desc")
+		fmt.Fprint(w, `[{"id":3,"sha":"bbb"},{"id":2,"sha":"aaa"},{"id":1,"sha":"aaa"}]`)
+	})
+
+	reports := map[string]string{
+		"3": `{"total_count":2,"test_suites":[{"name":"rspec 2/2","test_cases":[
+			{"status":"success","name":"logs in","classname":"Login","execution_time":2},
+			{"status":"skipped","name":"logs out","classname":"Login"}
+		]}]}`,
+		"2": `{"total_count":2,"test_suites":[{"name":"rspec 1/2","test_cases":[
+			{"status":"failed","name":"logs in","classname":"Login","execution_time":4,"stack_trace":"timeout"},
+			{"status":"success","name":"logs out","classname":"Login","execution_time":1}
+		]}]}`,
+		"1": `{"total_count":2,"test_suites":[{"name":"rspec 1/2","test_cases":[
+			{"status":"success","name":"logs in","classname":"Login","execution_time":3},
+			{"status":"success","name":"logs out","classname":"Login","execution_time":1}
+		]}]}`,
+	}
+	for id, report := range reports {
+		report := report
+		mux.HandleFunc("/api/v4/projects/1/pipelines/"+id+"/test_report", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodGet)
+			fmt.Fprint(w, report)
+		})
+	}
+}
+
+func TestGetTestReportHistory(t *testing.T) {
+	mux, client := setup(t)
+	handleTestReportHistory(t, mux)
+
+	h, _, err := client.Pipelines.GetTestReportHistory(1, &GetTestReportHistoryOptions{
+		Ref:   Ptr("main"),
+		Limit: 3,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, h.Pipelines, 3)
+	require.Len(t, h.Tests, 2)
+
+	login := h.Tests[0]
+	assert.Equal(t, "rspec", login.Suite)
+	assert.Equal(t, "logs in", login.Name)
+	assert.Equal(t, 2, login.SuccessCount)
+	assert.Equal(t, 1, login.FailedCount)
+	assert.Equal(t, 3.0, login.AverageTime)
+	assert.InDelta(t, 1.0/3, login.FailureRate(), 0.001)
+	assert.True(t, login.Flaky)
+	assert.Equal(t, []string{"aaa"}, login.FlakySHAs)
+	require.Len(t, login.Runs, 3)
+	assert.Equal(t, 3, login.Runs[0].PipelineID)
+
+	logout := h.Tests[1]
+	assert.Equal(t, 1, logout.SkippedCount)
+	assert.Equal(t, 1.0, logout.AverageTime)
+	assert.False(t, logout.Flaky)
+
+	assert.Equal(t, []*TestHistory{login}, h.FlakyTests())
+
+	_, _, err = client.Pipelines.GetTestReportHistory(1, nil)
+	assert.Error(t, err)
+}
+
+func TestTestReportHistoryExport(t *testing.T) {
+	mux, client := setup(t)
+	handleTestReportHistory(t, mux)
+
+	h, _, err := client.Pipelines.GetTestReportHistory(1, &GetTestReportHistoryOptions{
+		Ref:   Ptr("main"),
+		Limit: 3,
+	})
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, h.WriteJSON(&b))
+
+	var decoded TestReportHistory
+	require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	assert.Equal(t, h, &decoded)
+
+	b.Reset()
+	require.NoError(t, h.WriteJUnit(&b))
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(b.Bytes(), &report))
+	assert.Equal(t, "main", report.Name)
+	assert.Equal(t, 2, report.Tests)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 0, report.Failures)
+	require.Len(t, report.Suites, 1)
+	require.Len(t, report.Suites[0].Cases, 2)
+
+	tc := report.Suites[0].Cases[0]
+	assert.Equal(t, "logs in", tc.Name)
+	assert.Equal(t, "2.000", tc.Time)
+	assert.Equal(t, []*junitProperty{
+		{Name: "flaky", Value: "true"},
+		{Name: "failure_rate", Value: "0.3333"},
+	}, tc.Properties)
+	assert.NotNil(t, report.Suites[0].Cases[1].Skipped)
+}
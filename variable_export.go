@@ -0,0 +1,382 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dotenvAnnotation prefixes the comment that holds the attributes of the
+// variable on the next line of a dotenv file.
+const dotenvAnnotation = "# gitlab:"
+
+// VariableFormatValue represents a format variables can be exported to and
+// imported from.
+type VariableFormatValue string
+
+// The available variable formats.
+const (
+	VariableFormatJSON   VariableFormatValue = "json"
+	VariableFormatYAML   VariableFormatValue = "yaml"
+	VariableFormatDotenv VariableFormatValue = "dotenv"
+)
+
+// VariableConflictPolicyValue represents how existing variables with
+// different attributes are handled when importing variables.
+type VariableConflictPolicyValue string
+
+// The available variable conflict policies.
+const (
+	VariableConflictSkip      VariableConflictPolicyValue = "skip"
+	VariableConflictOverwrite VariableConflictPolicyValue = "overwrite"
+	VariableConflictFail      VariableConflictPolicyValue = "fail"
+)
+
+// VariableDocument represents the exported variables of a project or group.
+//
+// The values of hidden variables can't be read, so they are exported with an
+// empty value. Importing a document fails as long as any hidden variable has
+// no value, so the values have to be filled in before importing it again.
+type VariableDocument struct {
+	Variables []*VariableSpec `json:"variables" yaml:"variables"`
+}
+
+// dotenvAttributes represents the attributes of a variable that are stored
+// in the annotation of a dotenv file.
+type dotenvAttributes struct {
+	VariableType     VariableTypeValue `json:"variable_type,omitempty"`
+	Protected        bool              `json:"protected,omitempty"`
+	Masked           bool              `json:"masked,omitempty"`
+	Hidden           bool              `json:"hidden,omitempty"`
+	Raw              bool              `json:"raw,omitempty"`
+	EnvironmentScope string            `json:"environment_scope,omitempty"`
+	Description      string            `json:"description,omitempty"`
+}
+
+// Write writes the document to w in the given format.
+//
+// The dotenv format writes every variable as a double quoted KEY="value"
+// line. Attributes other than the default env_var type and "*" environment
+// scope are written as a JSON annotation on the line above, for example:
+//
+//	# gitlab: {"variable_type":"file","environment_scope":"production"}
+//	KUBECONFIG="apiVersion: v1\n"
+func (d *VariableDocument) Write(w io.Writer, format VariableFormatValue) error {
+	switch format {
+	case VariableFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case VariableFormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(d); err != nil {
+			return err
+		}
+		return enc.Close()
+	case VariableFormatDotenv:
+		return d.writeDotenv(w)
+	}
+	return fmt.Errorf("unknown variable format %q", format)
+}
+
+func (d *VariableDocument) writeDotenv(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, v := range d.Variables {
+		attrs := dotenvAttributes{
+			Protected:   v.Protected,
+			Masked:      v.Masked,
+			Hidden:      v.Hidden,
+			Raw:         v.Raw,
+			Description: v.Description,
+		}
+		if v.variableType() != EnvVariableType {
+			attrs.VariableType = v.VariableType
+		}
+		if v.scope() != defaultEnvironmentScope {
+			attrs.EnvironmentScope = v.EnvironmentScope
+		}
+		if attrs != (dotenvAttributes{}) {
+			annotation, err := json.Marshal(attrs)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(bw, "%s %s\n", dotenvAnnotation, annotation)
+		}
+		fmt.Fprintf(bw, "%s=%s\n", v.Key, quoteDotenv(v.Value))
+	}
+	return bw.Flush()
+}
+
+var dotenvEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func quoteDotenv(s string) string {
+	return `"` + dotenvEscaper.Replace(s) + `"`
+}
+
+// ReadVariableDocument reads a document in the given format from r. Dotenv
+// files may contain comments, blank lines, "export" prefixes and unquoted,
+// single quoted or double quoted values, and the annotations written by
+// VariableDocument.Write.
+func ReadVariableDocument(r io.Reader, format VariableFormatValue) (*VariableDocument, error) {
+	d := new(VariableDocument)
+	switch format {
+	case VariableFormatJSON:
+		if err := json.NewDecoder(r).Decode(d); err != nil {
+			return nil, err
+		}
+	case VariableFormatYAML:
+		if err := yaml.NewDecoder(r).Decode(d); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	case VariableFormatDotenv:
+		if err := d.readDotenv(r); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown variable format %q", format)
+	}
+	return d, nil
+}
+
+func (d *VariableDocument) readDotenv(r io.Reader) error {
+	var attrs *dotenvAttributes
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, dotenvAnnotation):
+			attrs = new(dotenvAttributes)
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, dotenvAnnotation)), attrs); err != nil {
+				return fmt.Errorf("line %d: invalid annotation: %v", n, err)
+			}
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("line %d: expected KEY=value", n)
+		}
+		value, err := unquoteDotenv(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("line %d: %v", n, err)
+		}
+
+		v := &VariableSpec{Key: key, Value: value}
+		if attrs != nil {
+			v.VariableType = attrs.VariableType
+			v.Protected = attrs.Protected
+			v.Masked = attrs.Masked
+			v.Hidden = attrs.Hidden
+			v.Raw = attrs.Raw
+			v.EnvironmentScope = attrs.EnvironmentScope
+			v.Description = attrs.Description
+			attrs = nil
+		}
+		d.Variables = append(d.Variables, v)
+	}
+	return scanner.Err()
+}
+
+// unquoteDotenv returns the value of a dotenv assignment. Double quoted
+// values are unescaped, single quoted values are taken literally and
+// trailing comments are removed from unquoted values.
+func unquoteDotenv(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `'`):
+		end := strings.Index(s[1:], `'`)
+		if end < 0 {
+			return "", errors.New("unterminated single quoted value")
+		}
+		return s[1 : end+1], nil
+
+	case strings.HasPrefix(s, `"`):
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			switch c := s[i]; c {
+			case '"':
+				return b.String(), nil
+			case '\\':
+				if i+1 == len(s) {
+					continue
+				}
+				i++
+				switch s[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				case '"', '\\':
+					b.WriteByte(s[i])
+				default:
+					b.WriteByte('\\')
+					b.WriteByte(s[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", errors.New("unterminated double quoted value")
+	}
+
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// ImportVariablesOptions represents the available options for importing
+// variables.
+type ImportVariablesOptions struct {
+	// OnConflict determines what happens to existing variables with the same
+	// key and environment scope but different attributes. Defaults to
+	// VariableConflictFail, in which case nothing is imported.
+	OnConflict *VariableConflictPolicyValue
+
+	// DryRun only computes the plan, without applying it.
+	DryRun *bool
+}
+
+// ExportVariables returns all variables of a project, including their type
+// and environment scope, ordered by key and environment scope.
+//
+// The value of hidden variables can't be read, so they are exported with an
+// empty value, see VariableDocument.
+//
+// Example usage:
+//
+//	doc, _, err := client.ProjectVariables.ExportVariables("group/app")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = doc.Write(os.Stdout, gitlab.VariableFormatYAML)
+func (s *ProjectVariablesService) ExportVariables(pid interface{}, options ...RequestOptionFunc) (*VariableDocument, *Response, error) {
+	return exportVariables(&projectVariableStore{s: s, pid: pid}, options)
+}
+
+// ExportVariables returns all variables of a group, see
+// ProjectVariablesService.ExportVariables.
+func (s *GroupVariablesService) ExportVariables(gid interface{}, options ...RequestOptionFunc) (*VariableDocument, *Response, error) {
+	return exportVariables(&groupVariableStore{s: s, gid: gid}, options)
+}
+
+// ImportVariables creates the variables of the document in a project.
+// Variables that already exist with the same attributes are left alone, and
+// other existing variables are handled according to the conflict policy.
+// Variables that are not part of the document are never deleted.
+//
+// Every hidden variable in the document needs a value, otherwise nothing is
+// imported. The values of existing hidden variables can't be read, so they
+// never conflict, but they are always updated when the conflict policy is
+// VariableConflictOverwrite.
+//
+// Example usage:
+//
+//	doc, err := gitlab.ReadVariableDocument(f, gitlab.VariableFormatDotenv)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	plan, _, err := client.ProjectVariables.ImportVariables("group/app", doc, &gitlab.ImportVariablesOptions{
+//	    OnConflict: gitlab.Ptr(gitlab.VariableConflictSkip),
+//	})
+func (s *ProjectVariablesService) ImportVariables(pid interface{}, doc *VariableDocument, opt *ImportVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error) {
+	return importVariables(&projectVariableStore{s: s, pid: pid}, doc, opt, options)
+}
+
+// ImportVariables creates the variables of the document in a group, see
+// ProjectVariablesService.ImportVariables.
+func (s *GroupVariablesService) ImportVariables(gid interface{}, doc *VariableDocument, opt *ImportVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error) {
+	return importVariables(&groupVariableStore{s: s, gid: gid}, doc, opt, options)
+}
+
+func exportVariables(store variableStore, options []RequestOptionFunc) (*VariableDocument, *Response, error) {
+	variables, resp, err := store.list(options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sort.SliceStable(variables, func(i, j int) bool {
+		if variables[i].Key != variables[j].Key {
+			return variables[i].Key < variables[j].Key
+		}
+		return variables[i].scope() < variables[j].scope()
+	})
+
+	return &VariableDocument{Variables: variables}, resp, nil
+}
+
+func importVariables(store variableStore, doc *VariableDocument, opt *ImportVariablesOptions, options []RequestOptionFunc) (*VariablePlan, *Response, error) {
+	if doc == nil {
+		return nil, nil, errors.New("document is required")
+	}
+	if opt == nil {
+		opt = &ImportVariablesOptions{}
+	}
+
+	onConflict := VariableConflictFail
+	if opt.OnConflict != nil {
+		onConflict = *opt.OnConflict
+	}
+	switch onConflict {
+	case VariableConflictSkip, VariableConflictOverwrite, VariableConflictFail:
+	default:
+		return nil, nil, fmt.Errorf("unknown conflict policy %q", onConflict)
+	}
+
+	// Hidden variables are exported without their value, which must not be
+	// mistaken for an empty value.
+	for _, v := range doc.Variables {
+		if v.Hidden && v.Value == "" {
+			return nil, nil, fmt.Errorf("hidden variable %s has no value", variableID{key: v.Key, scope: v.scope()})
+		}
+	}
+
+	current, resp, err := store.list(options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	plan, err := planVariables(store, current, doc.Variables, &planVariablesOptions{
+		onConflict:   onConflict,
+		hiddenValues: onConflict == VariableConflictOverwrite,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	if opt.DryRun != nil && *opt.DryRun {
+		return plan, resp, nil
+	}
+
+	return applyVariablePlan(store, plan, resp, options)
+}
@@ -0,0 +1,213 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportVariables(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/groups/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+			{"key":"TOKEN","value":"s3cr3t","variable_type":"env_var","masked":true,"protected":true,"environment_scope":"production"},
+			{"key":"KUBECONFIG","value":"apiVersion: v1\nkind: \"Config\"\n","variable_type":"file","environment_scope":"*"},
+			{"key":"TOKEN","value":"dev","variable_type":"env_var","environment_scope":"*","description":"Dev token"}
+		]`)
+	})
+
+	doc, _, err := client.GroupVariables.ExportVariables(1)
+	require.NoError(t, err)
+
+	want := &VariableDocument{Variables: []*VariableSpec{
+		{Key: "KUBECONFIG", Value: "apiVersion: v1\nkind: \"Config\"\n", VariableType: FileVariableType, EnvironmentScope: "*"},
+		{Key: "TOKEN", Value: "dev", VariableType: EnvVariableType, EnvironmentScope: "*", Description: "Dev token"},
+		{Key: "TOKEN", Value: "s3cr3t", VariableType: EnvVariableType, Masked: true, Protected: true, EnvironmentScope: "production"},
+	}}
+	assert.Equal(t, want, doc)
+
+	var b bytes.Buffer
+	require.NoError(t, doc.Write(&b, VariableFormatDotenv))
+	assert.Equal(t, `# gitlab: {"variable_type":"file"}
+KUBECONFIG="apiVersion: v1\nkind: \"Config\"\n"
+# gitlab: {"description":"Dev token"}
+TOKEN="dev"
+# gitlab: {"protected":true,"masked":true,"environment_scope":"production"}
+TOKEN="s3cr3t"
+`, b.String())
+
+	for _, format := range []VariableFormatValue{VariableFormatJSON, VariableFormatYAML, VariableFormatDotenv} {
+		b.Reset()
+		require.NoError(t, doc.Write(&b, format))
+
+		got, err := ReadVariableDocument(&b, format)
+		require.NoError(t, err, format)
+		require.Len(t, got.Variables, 3, format)
+		for i, v := range got.Variables {
+			assert.Equal(t, doc.Variables[i].Key, v.Key, format)
+			assert.Equal(t, doc.Variables[i].Value, v.Value, format)
+			assert.Equal(t, doc.Variables[i].variableType(), v.variableType(), format)
+			assert.Equal(t, doc.Variables[i].scope(), v.scope(), format)
+			assert.Equal(t, doc.Variables[i].Masked, v.Masked, format)
+			assert.Equal(t, doc.Variables[i].Description, v.Description, format)
+		}
+	}
+
+	assert.EqualError(t, doc.Write(&b, "toml"), `unknown variable format "toml"`)
+}
+
+func TestReadVariableDocumentDotenv(t *testing.T) {
+	doc, err := ReadVariableDocument(strings.NewReader(`
+# Database settings
+export DB_HOST=localhost # local only
+DB_PASSWORD='p@ss"word'
+# gitlab: {"environment_scope":"staging","raw":true}
+# Raw, because of the dollar sign.
+GREETING="Hello\t$USER\n"
+`), VariableFormatDotenv)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*VariableSpec{
+		{Key: "DB_HOST", Value: "localhost"},
+		{Key: "DB_PASSWORD", Value: `p@ss"word`},
+		{Key: "GREETING", Value: "Hello\t$USER\n", Raw: true, EnvironmentScope: "staging"},
+	}, doc.Variables)
+
+	_, err = ReadVariableDocument(strings.NewReader("A=1\nB\n"), VariableFormatDotenv)
+	assert.EqualError(t, err, "line 2: expected KEY=value")
+
+	_, err = ReadVariableDocument(strings.NewReader(`A="unterminated`), VariableFormatDotenv)
+	assert.EqualError(t, err, "line 1: unterminated double quoted value")
+}
+
+func TestImportVariables(t *testing.T) {
+	mux, client := setup(t)
+
+	var created []string
+	mux.HandleFunc("/api/v4/projects/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[
+				{"key":"SAME","value":"1","variable_type":"env_var","environment_scope":"*"},
+				{"key":"OTHER","value":"old","variable_type":"env_var","environment_scope":"*"}
+			]`)
+		case http.MethodPost:
+			created = append(created, r.Method)
+			fmt.Fprint(w, `{}`)
+		}
+	})
+	var updated int
+	mux.HandleFunc("/api/v4/projects/1/variables/OTHER", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		updated++
+		fmt.Fprint(w, `{}`)
+	})
+
+	doc := &VariableDocument{Variables: []*VariableSpec{
+		{Key: "SAME", Value: "1"},
+		{Key: "OTHER", Value: "new"},
+		{Key: "NEW", Value: "1", EnvironmentScope: "production"},
+	}}
+
+	_, _, err := client.ProjectVariables.ImportVariables(1, doc, nil)
+	assert.EqualError(t, err, "variables already exist with different attributes: OTHER (*)")
+	assert.Empty(t, created)
+
+	plan, _, err := client.ProjectVariables.ImportVariables(1, doc, &ImportVariablesOptions{
+		OnConflict: Ptr(VariableConflictSkip),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "create NEW (production)\n", plan.String())
+	assert.Len(t, created, 1)
+	assert.Equal(t, 0, updated)
+
+	plan, _, err = client.ProjectVariables.ImportVariables(1, doc, &ImportVariablesOptions{
+		OnConflict: Ptr(VariableConflictOverwrite),
+		DryRun:     Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "update OTHER (*): value\ncreate NEW (production)\n", plan.String())
+	assert.Len(t, created, 1)
+
+	_, _, err = client.ProjectVariables.ImportVariables(1, doc, &ImportVariablesOptions{
+		OnConflict: Ptr(VariableConflictOverwrite),
+	})
+	require.NoError(t, err)
+	assert.Len(t, created, 2)
+	assert.Equal(t, 1, updated)
+
+	_, _, err = client.ProjectVariables.ImportVariables(1, &VariableDocument{Variables: []*VariableSpec{
+		{Key: "SECRET", Hidden: true},
+	}}, nil)
+	assert.EqualError(t, err, "hidden variable SECRET (*) has no value")
+}
+
+func TestImportExportedVariablesWithHidden(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+			{"key":"SECRET","value":null,"variable_type":"env_var","masked":true,"hidden":true,"environment_scope":"*"},
+			{"key":"URL","value":"https://example.com","variable_type":"env_var","environment_scope":"*"}
+		]`)
+	})
+	updated := 0
+	mux.HandleFunc("/api/v4/projects/1/variables/SECRET", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"value":"s3cret","description":"","filter":{"environment_scope":"*"},"masked":true,"protected":false,"raw":false,"variable_type":"env_var"}`)
+		updated++
+		fmt.Fprint(w, `{}`)
+	})
+
+	doc, _, err := client.ProjectVariables.ExportVariables(1)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	require.NoError(t, doc.Write(&b, VariableFormatDotenv))
+	doc, err = ReadVariableDocument(&b, VariableFormatDotenv)
+	require.NoError(t, err)
+
+	// The value of the hidden variable is missing from the export.
+	_, _, err = client.ProjectVariables.ImportVariables(1, doc, &ImportVariablesOptions{
+		OnConflict: Ptr(VariableConflictOverwrite),
+	})
+	assert.EqualError(t, err, "hidden variable SECRET (*) has no value")
+
+	doc.Variables[0].Value = "s3cret"
+
+	// Existing hidden variables never conflict, as their value is unknown.
+	plan, _, err := client.ProjectVariables.ImportVariables(1, doc, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+
+	plan, _, err = client.ProjectVariables.ImportVariables(1, doc, &ImportVariablesOptions{
+		OnConflict: Ptr(VariableConflictOverwrite),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "update SECRET (*): value\n", plan.String())
+	assert.Equal(t, 1, updated)
+}
@@ -0,0 +1,641 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// defaultEnvironmentScope is the environment scope of variables that apply
+// to all environments.
+const defaultEnvironmentScope = "*"
+
+// maskedValue replaces the value of masked variables when printing them.
+const maskedValue = "[MASKED]"
+
+// VariableSpec represents a CI/CD variable independent of the project,
+// group or instance it is defined on.
+type VariableSpec struct {
+	Key          string            `json:"key" yaml:"key"`
+	Value        string            `json:"value" yaml:"value"`
+	VariableType VariableTypeValue `json:"variable_type,omitempty" yaml:"variable_type,omitempty"`
+	Protected    bool              `json:"protected,omitempty" yaml:"protected,omitempty"`
+	Masked       bool              `json:"masked,omitempty" yaml:"masked,omitempty"`
+
+	// Hidden variables are created masked and hidden. The value of a hidden
+	// variable can't be read back, so it is only compared when asked for,
+	// and GitLab can't change whether a variable is hidden, so the variable
+	// is deleted and created again instead. Hidden is not supported for
+	// instance variables.
+	Hidden bool `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+
+	Raw bool `json:"raw,omitempty" yaml:"raw,omitempty"`
+
+	// EnvironmentScope defaults to "*". It is not supported for instance
+	// variables.
+	EnvironmentScope string `json:"environment_scope,omitempty" yaml:"environment_scope,omitempty"`
+
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// String returns a representation of the variable in which the value of
+// masked and hidden variables is redacted.
+func (v VariableSpec) String() string {
+	if v.masked() {
+		v.Value = maskedValue
+	}
+	return Stringify(v)
+}
+
+// scope returns the environment scope of the variable, or "*" if it has
+// none.
+func (v *VariableSpec) scope() string {
+	if v.EnvironmentScope == "" {
+		return defaultEnvironmentScope
+	}
+	return v.EnvironmentScope
+}
+
+// variableType returns the type of the variable, or env_var if it has none.
+func (v *VariableSpec) variableType() VariableTypeValue {
+	if v.VariableType == "" {
+		return EnvVariableType
+	}
+	return v.VariableType
+}
+
+// masked reports whether the variable is masked, which hidden variables
+// always are.
+func (v *VariableSpec) masked() bool {
+	return v.Masked || v.Hidden
+}
+
+// VariableActionValue represents the action needed to sync a variable.
+type VariableActionValue string
+
+// The available variable actions.
+const (
+	VariableCreate VariableActionValue = "create"
+	VariableUpdate VariableActionValue = "update"
+	VariableDelete VariableActionValue = "delete"
+)
+
+// VariableChange represents a single change needed to sync variables.
+type VariableChange struct {
+	Action           VariableActionValue
+	Key              string
+	EnvironmentScope string
+
+	// Fields contains the names of the attributes that differ, for updates.
+	// It is "hidden" for the delete and create that replace a variable
+	// which is made hidden or no longer hidden.
+	Fields []string
+
+	// Current is the existing variable, which is nil for creates.
+	Current *VariableSpec
+
+	// Desired is the wanted variable, which is nil for deletes.
+	Desired *VariableSpec
+}
+
+// String returns a description of the change. It never contains variable
+// values.
+func (c *VariableChange) String() string {
+	s := fmt.Sprintf("%s %s", c.Action, c.Key)
+	if c.EnvironmentScope != "" {
+		s += fmt.Sprintf(" (%s)", c.EnvironmentScope)
+	}
+	if len(c.Fields) > 0 {
+		s += ": " + strings.Join(c.Fields, ", ")
+	}
+	return s
+}
+
+// VariablePlan represents the changes needed to sync variables.
+type VariablePlan struct {
+	// Changes contains the deletes, updates and creates, in the order they
+	// are applied. It is empty if the variables are already in sync.
+	Changes []*VariableChange
+}
+
+// String returns a description of every change on a separate line.
+func (p *VariablePlan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// SyncVariablesOptions represents the available options for syncing
+// variables.
+type SyncVariablesOptions struct {
+	// Prune deletes the existing variables that are not part of the desired
+	// variables. By default they are left alone.
+	Prune *bool
+
+	// UpdateHiddenValues updates the value of existing hidden variables.
+	// Their values can't be read back, so they can't be compared and are
+	// only updated when this is set.
+	UpdateHiddenValues *bool
+
+	// DryRun only computes the plan, without applying it.
+	DryRun *bool
+}
+
+// variableStore adapts the variables API of a project, group or instance.
+type variableStore interface {
+	list(options []RequestOptionFunc) ([]*VariableSpec, *Response, error)
+	create(v *VariableSpec, options []RequestOptionFunc) (*Response, error)
+	update(current, desired *VariableSpec, options []RequestOptionFunc) (*Response, error)
+	remove(v *VariableSpec, options []RequestOptionFunc) (*Response, error)
+
+	// scoped reports whether variables have an environment scope.
+	scoped() bool
+}
+
+// SyncVariables makes the variables of a project match the desired
+// variables. Variables are identified by their key and environment scope,
+// and are only created, updated or deleted when needed, so syncing the same
+// variables again doesn't change anything.
+//
+// The returned plan never contains values of masked variables when printed.
+//
+// Example usage:
+//
+//	plan, _, err := client.ProjectVariables.SyncVariables("group/app", []*gitlab.VariableSpec{
+//	    {Key: "API_URL", Value: "https://api.example.com"},
+//	    {Key: "API_TOKEN", Value: token, Masked: true, Protected: true, EnvironmentScope: "production"},
+//	}, &gitlab.SyncVariablesOptions{DryRun: gitlab.Ptr(true)})
+//	fmt.Print(plan)
+func (s *ProjectVariablesService) SyncVariables(pid interface{}, desired []*VariableSpec, opt *SyncVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error) {
+	return syncVariables(&projectVariableStore{s: s, pid: pid}, desired, opt, options)
+}
+
+// SyncVariables makes the variables of a group match the desired variables,
+// see ProjectVariablesService.SyncVariables.
+//
+// The group variables API can't address a variable by environment scope, so
+// a variable can't be updated or deleted if the group has more than one
+// variable with the same key.
+func (s *GroupVariablesService) SyncVariables(gid interface{}, desired []*VariableSpec, opt *SyncVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error) {
+	return syncVariables(&groupVariableStore{s: s, gid: gid}, desired, opt, options)
+}
+
+// SyncVariables makes the instance level CI variables match the desired
+// variables, see ProjectVariablesService.SyncVariables. Instance variables
+// are identified by their key only.
+func (s *InstanceVariablesService) SyncVariables(desired []*VariableSpec, opt *SyncVariablesOptions, options ...RequestOptionFunc) (*VariablePlan, *Response, error) {
+	return syncVariables(&instanceVariableStore{s: s}, desired, opt, options)
+}
+
+func syncVariables(store variableStore, desired []*VariableSpec, opt *SyncVariablesOptions, options []RequestOptionFunc) (*VariablePlan, *Response, error) {
+	if opt == nil {
+		opt = &SyncVariablesOptions{}
+	}
+
+	current, resp, err := store.list(options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	plan, err := planVariables(store, current, desired, &planVariablesOptions{
+		onConflict:   VariableConflictOverwrite,
+		prune:        opt.Prune != nil && *opt.Prune,
+		hiddenValues: opt.UpdateHiddenValues != nil && *opt.UpdateHiddenValues,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	if opt.DryRun != nil && *opt.DryRun {
+		return plan, resp, nil
+	}
+
+	return applyVariablePlan(store, plan, resp, options)
+}
+
+// applyVariablePlan applies the changes of the plan in order. Variables that
+// were already deleted are ignored.
+func applyVariablePlan(store variableStore, plan *VariablePlan, resp *Response, options []RequestOptionFunc) (*VariablePlan, *Response, error) {
+	var err error
+	for _, c := range plan.Changes {
+		switch c.Action {
+		case VariableDelete:
+			resp, err = store.remove(c.Current, options)
+			if errors.Is(err, ErrNotFound) {
+				err = nil
+			}
+		case VariableUpdate:
+			resp, err = store.update(c.Current, c.Desired, options)
+		case VariableCreate:
+			resp, err = store.create(c.Desired, options)
+		}
+		if err != nil {
+			return plan, resp, fmt.Errorf("%s: %v", c, err)
+		}
+	}
+
+	return plan, resp, nil
+}
+
+// variableID identifies a variable by its key and environment scope.
+type variableID struct {
+	key   string
+	scope string
+}
+
+func (id variableID) String() string {
+	if id.scope == "" {
+		return id.key
+	}
+	return fmt.Sprintf("%s (%s)", id.key, id.scope)
+}
+
+// planVariablesOptions represents the options for planning variable changes.
+type planVariablesOptions struct {
+	// onConflict determines how existing variables with different
+	// attributes are handled.
+	onConflict VariableConflictPolicyValue
+
+	// prune deletes the variables that are not desired.
+	prune bool
+
+	// hiddenValues compares the values of hidden variables, which always
+	// differ as they can't be read back.
+	hiddenValues bool
+}
+
+// planVariables computes the changes needed to turn the current variables
+// into the desired variables.
+func planVariables(store variableStore, current, desired []*VariableSpec, opt *planVariablesOptions) (*VariablePlan, error) {
+	id := func(v *VariableSpec) variableID {
+		if !store.scoped() {
+			return variableID{key: v.Key}
+		}
+		return variableID{key: v.Key, scope: v.scope()}
+	}
+
+	existing := make(map[variableID]*VariableSpec)
+	for _, v := range current {
+		existing[id(v)] = v
+	}
+
+	plan := new(VariablePlan)
+	var creates, updates, deletes []*VariableChange
+	var conflicts []string
+	wanted := make(map[variableID]bool)
+
+	for _, v := range desired {
+		if v.Key == "" {
+			return nil, errors.New("variable key is required")
+		}
+		if !store.scoped() && v.scope() != defaultEnvironmentScope {
+			return nil, fmt.Errorf("variable %s: environment scopes are not supported", v.Key)
+		}
+		if !store.scoped() && v.Hidden {
+			return nil, fmt.Errorf("variable %s: hidden variables are not supported", v.Key)
+		}
+
+		vid := id(v)
+		if wanted[vid] {
+			return nil, fmt.Errorf("variable %s is defined more than once", vid)
+		}
+		wanted[vid] = true
+
+		cur, ok := existing[vid]
+		if !ok {
+			if v.Hidden && v.Value == "" {
+				return nil, fmt.Errorf("hidden variable %s has no value", vid)
+			}
+			creates = append(creates, &VariableChange{Action: VariableCreate, Key: v.Key, EnvironmentScope: vid.scope, Desired: v})
+			continue
+		}
+
+		fields := diffVariables(cur, v, opt.hiddenValues)
+		if len(fields) == 0 {
+			continue
+		}
+		switch opt.onConflict {
+		case VariableConflictSkip:
+			continue
+		case VariableConflictFail:
+			conflicts = append(conflicts, vid.String())
+			continue
+		}
+		// Updating a variable requires its value, which is unknown for
+		// hidden variables unless it is given.
+		if (cur.Hidden || v.Hidden) && v.Value == "" {
+			return nil, fmt.Errorf("hidden variable %s has no value", vid)
+		}
+
+		if cur.Hidden != v.Hidden {
+			fields = []string{"hidden"}
+			deletes = append(deletes, &VariableChange{Action: VariableDelete, Key: v.Key, EnvironmentScope: vid.scope, Fields: fields, Current: cur})
+			creates = append(creates, &VariableChange{Action: VariableCreate, Key: v.Key, EnvironmentScope: vid.scope, Fields: fields, Desired: v})
+			continue
+		}
+
+		updates = append(updates, &VariableChange{
+			Action:           VariableUpdate,
+			Key:              v.Key,
+			EnvironmentScope: vid.scope,
+			Fields:           fields,
+			Current:          cur,
+			Desired:          v,
+		})
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, fmt.Errorf("variables already exist with different attributes: %s", strings.Join(conflicts, ", "))
+	}
+
+	if opt.prune {
+		for vid, cur := range existing {
+			if wanted[vid] {
+				continue
+			}
+			deletes = append(deletes, &VariableChange{Action: VariableDelete, Key: cur.Key, EnvironmentScope: vid.scope, Current: cur})
+		}
+	}
+
+	// Deletes go first, so the key of a deleted variable is free again when a
+	// variable with the same key is created.
+	for _, changes := range [][]*VariableChange{deletes, updates, creates} {
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].Key != changes[j].Key {
+				return changes[i].Key < changes[j].Key
+			}
+			return changes[i].EnvironmentScope < changes[j].EnvironmentScope
+		})
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	return plan, nil
+}
+
+// diffVariables returns the names of the attributes that differ between the
+// current and desired variable. The value of a hidden variable is only
+// compared if hiddenValues is set.
+func diffVariables(current, desired *VariableSpec, hiddenValues bool) []string {
+	var fields []string
+	if current.Hidden != desired.Hidden {
+		fields = append(fields, "hidden")
+	}
+	if current.Hidden && hiddenValues || !current.Hidden && current.Value != desired.Value {
+		fields = append(fields, "value")
+	}
+	if current.variableType() != desired.variableType() {
+		fields = append(fields, "variable_type")
+	}
+	if current.Protected != desired.Protected {
+		fields = append(fields, "protected")
+	}
+	if current.masked() != desired.masked() {
+		fields = append(fields, "masked")
+	}
+	if current.Raw != desired.Raw {
+		fields = append(fields, "raw")
+	}
+	if current.Description != desired.Description {
+		fields = append(fields, "description")
+	}
+	return fields
+}
+
+type projectVariableStore struct {
+	s   *ProjectVariablesService
+	pid interface{}
+}
+
+func (p *projectVariableStore) list(options []RequestOptionFunc) ([]*VariableSpec, *Response, error) {
+	var lastResp *Response
+	variables, err := All(context.Background(), func(pageOptions ...RequestOptionFunc) ([]*ProjectVariable, *Response, error) {
+		variables, resp, err := p.s.ListVariables(p.pid, &ListProjectVariablesOptions{PerPage: 100}, append(pageOptions, options...)...)
+		lastResp = resp
+		return variables, resp, err
+	})
+	if err != nil {
+		return nil, lastResp, err
+	}
+
+	specs := make([]*VariableSpec, 0, len(variables))
+	for _, v := range variables {
+		specs = append(specs, &VariableSpec{
+			Key:              v.Key,
+			Value:            v.Value,
+			VariableType:     v.VariableType,
+			Protected:        v.Protected,
+			Masked:           v.Masked,
+			Hidden:           v.Hidden,
+			Raw:              v.Raw,
+			EnvironmentScope: v.EnvironmentScope,
+			Description:      v.Description,
+		})
+	}
+	return specs, lastResp, nil
+}
+
+func (p *projectVariableStore) create(v *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	opt := &CreateProjectVariableOptions{
+		Key:              Ptr(v.Key),
+		Value:            Ptr(v.Value),
+		Description:      Ptr(v.Description),
+		EnvironmentScope: Ptr(v.scope()),
+		Masked:           Ptr(v.Masked),
+		Protected:        Ptr(v.Protected),
+		Raw:              Ptr(v.Raw),
+		VariableType:     Ptr(v.variableType()),
+	}
+	if v.Hidden {
+		opt.Masked = nil
+		opt.MaskedAndHidden = Ptr(true)
+	}
+	_, resp, err := p.s.CreateVariable(p.pid, opt, options...)
+	return resp, err
+}
+
+func (p *projectVariableStore) update(current, desired *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	_, resp, err := p.s.UpdateVariable(p.pid, current.Key, &UpdateProjectVariableOptions{
+		Value:        Ptr(desired.Value),
+		Description:  Ptr(desired.Description),
+		Filter:       &VariableFilter{EnvironmentScope: current.scope()},
+		Masked:       Ptr(desired.masked()),
+		Protected:    Ptr(desired.Protected),
+		Raw:          Ptr(desired.Raw),
+		VariableType: Ptr(desired.variableType()),
+	}, options...)
+	return resp, err
+}
+
+func (p *projectVariableStore) remove(v *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	return p.s.RemoveVariable(p.pid, v.Key, &RemoveProjectVariableOptions{
+		Filter: &VariableFilter{EnvironmentScope: v.scope()},
+	}, options...)
+}
+
+func (p *projectVariableStore) scoped() bool { return true }
+
+type groupVariableStore struct {
+	s   *GroupVariablesService
+	gid interface{}
+}
+
+func (g *groupVariableStore) list(options []RequestOptionFunc) ([]*VariableSpec, *Response, error) {
+	var lastResp *Response
+	variables, err := All(context.Background(), func(pageOptions ...RequestOptionFunc) ([]*GroupVariable, *Response, error) {
+		variables, resp, err := g.s.ListVariables(g.gid, &ListGroupVariablesOptions{PerPage: 100}, append(pageOptions, options...)...)
+		lastResp = resp
+		return variables, resp, err
+	})
+	if err != nil {
+		return nil, lastResp, err
+	}
+
+	specs := make([]*VariableSpec, 0, len(variables))
+	for _, v := range variables {
+		specs = append(specs, &VariableSpec{
+			Key:              v.Key,
+			Value:            v.Value,
+			VariableType:     v.VariableType,
+			Protected:        v.Protected,
+			Masked:           v.Masked,
+			Hidden:           v.Hidden,
+			Raw:              v.Raw,
+			EnvironmentScope: v.EnvironmentScope,
+			Description:      v.Description,
+		})
+	}
+	return specs, lastResp, nil
+}
+
+func (g *groupVariableStore) create(v *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	opt := &CreateGroupVariableOptions{
+		Key:              Ptr(v.Key),
+		Value:            Ptr(v.Value),
+		Description:      Ptr(v.Description),
+		EnvironmentScope: Ptr(v.scope()),
+		Masked:           Ptr(v.Masked),
+		Protected:        Ptr(v.Protected),
+		Raw:              Ptr(v.Raw),
+		VariableType:     Ptr(v.variableType()),
+	}
+	if v.Hidden {
+		opt.Masked = nil
+		opt.MaskedAndHidden = Ptr(true)
+	}
+	_, resp, err := g.s.CreateVariable(g.gid, opt, options...)
+	return resp, err
+}
+
+func (g *groupVariableStore) update(current, desired *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	_, resp, err := g.s.UpdateVariable(g.gid, current.Key, &UpdateGroupVariableOptions{
+		Value:        Ptr(desired.Value),
+		Description:  Ptr(desired.Description),
+		Filter:       &VariableFilter{EnvironmentScope: current.scope()},
+		Masked:       Ptr(desired.masked()),
+		Protected:    Ptr(desired.Protected),
+		Raw:          Ptr(desired.Raw),
+		VariableType: Ptr(desired.variableType()),
+	}, options...)
+	return resp, err
+}
+
+func (g *groupVariableStore) remove(v *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	return g.s.RemoveVariable(g.gid, v.Key, append(options, withVariableFilter(v.scope()))...)
+}
+
+// withVariableFilter only removes the group variable with the given
+// environment scope, as RemoveVariable has no options to set the filter.
+func withVariableFilter(scope string) RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		q := req.URL.Query()
+		q.Set("filter[environment_scope]", scope)
+		req.URL.RawQuery = q.Encode()
+		return nil
+	}
+}
+
+func (g *groupVariableStore) scoped() bool { return true }
+
+type instanceVariableStore struct {
+	s *InstanceVariablesService
+}
+
+func (i *instanceVariableStore) list(options []RequestOptionFunc) ([]*VariableSpec, *Response, error) {
+	var lastResp *Response
+	variables, err := All(context.Background(), func(pageOptions ...RequestOptionFunc) ([]*InstanceVariable, *Response, error) {
+		variables, resp, err := i.s.ListVariables(&ListInstanceVariablesOptions{PerPage: 100}, append(pageOptions, options...)...)
+		lastResp = resp
+		return variables, resp, err
+	})
+	if err != nil {
+		return nil, lastResp, err
+	}
+
+	specs := make([]*VariableSpec, 0, len(variables))
+	for _, v := range variables {
+		specs = append(specs, &VariableSpec{
+			Key:          v.Key,
+			Value:        v.Value,
+			VariableType: v.VariableType,
+			Protected:    v.Protected,
+			Masked:       v.Masked,
+			Raw:          v.Raw,
+			Description:  v.Description,
+		})
+	}
+	return specs, lastResp, nil
+}
+
+func (i *instanceVariableStore) create(v *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	_, resp, err := i.s.CreateVariable(&CreateInstanceVariableOptions{
+		Key:          Ptr(v.Key),
+		Value:        Ptr(v.Value),
+		Description:  Ptr(v.Description),
+		Masked:       Ptr(v.Masked),
+		Protected:    Ptr(v.Protected),
+		Raw:          Ptr(v.Raw),
+		VariableType: Ptr(v.variableType()),
+	}, options...)
+	return resp, err
+}
+
+func (i *instanceVariableStore) update(current, desired *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	_, resp, err := i.s.UpdateVariable(current.Key, &UpdateInstanceVariableOptions{
+		Value:        Ptr(desired.Value),
+		Description:  Ptr(desired.Description),
+		Masked:       Ptr(desired.masked()),
+		Protected:    Ptr(desired.Protected),
+		Raw:          Ptr(desired.Raw),
+		VariableType: Ptr(desired.variableType()),
+	}, options...)
+	return resp, err
+}
+
+func (i *instanceVariableStore) remove(v *VariableSpec, options []RequestOptionFunc) (*Response, error) {
+	return i.s.RemoveVariable(v.Key, options...)
+}
+
+func (i *instanceVariableStore) scoped() bool { return false }
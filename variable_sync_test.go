@@ -0,0 +1,244 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectVariablesSyncVariables(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[
+				{"key":"KEEP","value":"same","variable_type":"env_var","environment_scope":"*"},
+				{"key":"TOKEN","value":"old","variable_type":"env_var","masked":true,"environment_scope":"production"},
+				{"key":"TOKEN","value":"staging","variable_type":"env_var","masked":true,"environment_scope":"staging"},
+				{"key":"STALE","value":"x","variable_type":"env_var","environment_scope":"*"}
+			]`)
+		case http.MethodPost:
+			testBody(t, r, `{"key":"CONFIG","value":"a: b","description":"","environment_scope":"*","masked":false,"protected":false,"raw":true,"variable_type":"file"}`)
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Fatalf("unexpected %s request", r.Method)
+		}
+	})
+	mux.HandleFunc("/api/v4/projects/1/variables/TOKEN", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"value":"new","description":"","filter":{"environment_scope":"production"},"masked":true,"protected":true,"raw":false,"variable_type":"env_var"}`)
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/variables/STALE", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		testParams(t, r, "filter%5Benvironment_scope%5D=%2A")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	desired := []*VariableSpec{
+		{Key: "KEEP", Value: "same"},
+		{Key: "TOKEN", Value: "new", Masked: true, Protected: true, EnvironmentScope: "production"},
+		{Key: "TOKEN", Value: "staging", Masked: true, EnvironmentScope: "staging"},
+		{Key: "CONFIG", Value: "a: b", VariableType: FileVariableType, Raw: true},
+	}
+
+	plan, _, err := client.ProjectVariables.SyncVariables(1, desired, &SyncVariablesOptions{
+		Prune: Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "delete STALE (*)\nupdate TOKEN (production): value, protected\ncreate CONFIG (*)\n", plan.String())
+	assert.NotContains(t, plan.Changes[1].Desired.String(), "new")
+}
+
+func TestProjectVariablesSyncVariablesDryRun(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"key":"A","value":"1","variable_type":"env_var","environment_scope":"*"}]`)
+	})
+
+	plan, _, err := client.ProjectVariables.SyncVariables(1, []*VariableSpec{
+		{Key: "A", Value: "2"},
+		{Key: "B", Value: "1"},
+	}, &SyncVariablesOptions{DryRun: Ptr(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "update A (*): value\ncreate B (*)\n", plan.String())
+
+	plan, _, err = client.ProjectVariables.SyncVariables(1, []*VariableSpec{
+		{Key: "A", Value: "1", EnvironmentScope: "*"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+
+	_, _, err = client.ProjectVariables.SyncVariables(1, []*VariableSpec{
+		{Key: "A", Value: "1"},
+		{Key: "A", Value: "2", EnvironmentScope: "*"},
+	}, nil)
+	assert.EqualError(t, err, "variable A (*) is defined more than once")
+}
+
+func TestGroupVariablesSyncVariables(t *testing.T) {
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/groups/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[
+			{"key":"A","value":"1","variable_type":"env_var","environment_scope":"*"},
+			{"key":"B","value":"1","variable_type":"env_var","environment_scope":"production"},
+			{"key":"B","value":"2","variable_type":"env_var","environment_scope":"staging"},
+			{"key":"B","value":"3","variable_type":"env_var","environment_scope":"review/*"}
+		]`)
+	})
+	mux.HandleFunc("/api/v4/groups/1/variables/A", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"value":"2","description":"","filter":{"environment_scope":"*"},"masked":false,"protected":false,"raw":false,"variable_type":"env_var"}`)
+		fmt.Fprint(w, `{}`)
+	})
+
+	plan, _, err := client.GroupVariables.SyncVariables(1, []*VariableSpec{
+		{Key: "A", Value: "2"},
+		{Key: "B", Value: "1", EnvironmentScope: "production"},
+		{Key: "B", Value: "2", EnvironmentScope: "staging"},
+		{Key: "B", Value: "3", EnvironmentScope: "review/*"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "update A (*): value\n", plan.String())
+
+	// Variables with a key that has several environment scopes are updated
+	// and deleted per scope.
+	var updated, deleted []string
+	mux.HandleFunc("/api/v4/groups/1/variables/B", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			testBody(t, r, `{"value":"4","description":"","filter":{"environment_scope":"production"},"masked":false,"protected":false,"raw":false,"variable_type":"env_var"}`)
+			updated = append(updated, "production")
+		case http.MethodDelete:
+			deleted = append(deleted, r.URL.Query().Get("filter[environment_scope]"))
+		default:
+			t.Fatalf("unexpected %s request", r.Method)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	plan, _, err = client.GroupVariables.SyncVariables(1, []*VariableSpec{
+		{Key: "A", Value: "1"},
+		{Key: "B", Value: "4", EnvironmentScope: "production"},
+		{Key: "B", Value: "2", EnvironmentScope: "staging"},
+	}, &SyncVariablesOptions{Prune: Ptr(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "delete B (review/*)\nupdate B (production): value\n", plan.String())
+	assert.Equal(t, []string{"production"}, updated)
+	assert.Equal(t, []string{"review/*"}, deleted)
+}
+
+func TestInstanceVariablesSyncVariables(t *testing.T) {
+	mux, client := setup(t)
+
+	var created []string
+	mux.HandleFunc("/api/v4/admin/ci/variables", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[{"key":"A","value":"1","variable_type":"env_var","masked":true}]`)
+		case http.MethodPost:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			created = append(created, string(body))
+			fmt.Fprint(w, `{}`)
+		}
+	})
+
+	plan, _, err := client.InstanceVariables.SyncVariables([]*VariableSpec{
+		{Key: "A", Value: "1", Masked: true},
+		{Key: "B", Value: "secret", Masked: true},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "create B\n", plan.String())
+	require.Len(t, created, 1)
+	assert.Contains(t, created[0], `"key":"B"`)
+
+	_, _, err = client.InstanceVariables.SyncVariables([]*VariableSpec{
+		{Key: "A", Value: "1", EnvironmentScope: "production"},
+	}, nil)
+	assert.EqualError(t, err, "variable A: environment scopes are not supported")
+}
+
+func TestVariableSpecString(t *testing.T) {
+	v := VariableSpec{Key: "TOKEN", Value: "secret", Masked: true}
+	assert.NotContains(t, v.String(), "secret")
+	assert.Contains(t, v.String(), maskedValue)
+
+	v = VariableSpec{Key: "URL", Value: "https://example.com"}
+	assert.Contains(t, v.String(), "https://example.com")
+}
+
+func TestProjectVariablesSyncVariablesHidden(t *testing.T) {
+	mux, client := setup(t)
+
+	var requests []string
+	mux.HandleFunc("/api/v4/projects/1/variables", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `[
+				{"key":"SECRET","value":null,"variable_type":"env_var","masked":true,"hidden":true,"environment_scope":"*"},
+				{"key":"TOKEN","value":"abc","variable_type":"env_var","masked":true,"environment_scope":"*"}
+			]`)
+		case http.MethodPost:
+			testBody(t, r, `{"key":"TOKEN","value":"abc","description":"","environment_scope":"*","masked_and_hidden":true,"protected":false,"raw":false,"variable_type":"env_var"}`)
+			requests = append(requests, "create TOKEN")
+			fmt.Fprint(w, `{}`)
+		}
+	})
+	mux.HandleFunc("/api/v4/projects/1/variables/TOKEN", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodDelete)
+		requests = append(requests, "delete TOKEN")
+	})
+	mux.HandleFunc("/api/v4/projects/1/variables/SECRET", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"value":"s3cr3t","description":"","filter":{"environment_scope":"*"},"masked":true,"protected":false,"raw":false,"variable_type":"env_var"}`)
+		requests = append(requests, "update SECRET")
+		fmt.Fprint(w, `{}`)
+	})
+
+	desired := []*VariableSpec{
+		{Key: "SECRET", Value: "s3cr3t", Hidden: true},
+		{Key: "TOKEN", Value: "abc", Masked: true},
+	}
+
+	plan, _, err := client.ProjectVariables.SyncVariables(1, desired, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plan.Changes)
+
+	plan, _, err = client.ProjectVariables.SyncVariables(1, desired, &SyncVariablesOptions{
+		UpdateHiddenValues: Ptr(true),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "update SECRET (*): value\n", plan.String())
+
+	desired[1].Hidden = true
+	plan, _, err = client.ProjectVariables.SyncVariables(1, desired, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "delete TOKEN (*): hidden\ncreate TOKEN (*): hidden\n", plan.String())
+	assert.Equal(t, []string{"update SECRET", "delete TOKEN", "create TOKEN"}, requests)
+}
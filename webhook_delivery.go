@@ -0,0 +1,211 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	eventUUIDHeader      = "X-Gitlab-Event-UUID"
+	webhookUUIDHeader    = "X-Gitlab-Webhook-UUID"
+	instanceHeader       = "X-Gitlab-Instance"
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// WebhookDelivery represents the metadata GitLab sends with every web- or
+// system hook delivery.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/ee/user/project/integrations/webhooks.html#delivery-headers
+type WebhookDelivery struct {
+	EventType      EventType `json:"event_type"`
+	EventUUID      string    `json:"event_uuid"`
+	WebhookUUID    string    `json:"webhook_uuid"`
+	InstanceURL    string    `json:"instance_url"`
+	IdempotencyKey string    `json:"idempotency_key"`
+}
+
+// Key returns the key that identifies the delivery. Retries of a delivery
+// have the same key, which makes it suitable to deduplicate deliveries.
+//
+// The Idempotency-Key header is used when available. Older GitLab versions
+// don't send it, in which case the key is derived from the webhook and event
+// UUIDs. An empty key is returned if GitLab sent neither.
+func (d *WebhookDelivery) Key() string {
+	if d.IdempotencyKey != "" {
+		return d.IdempotencyKey
+	}
+	if d.EventUUID == "" {
+		return ""
+	}
+	return d.WebhookUUID + ":" + d.EventUUID
+}
+
+// HookDelivery returns the delivery metadata for the given request.
+func HookDelivery(r *http.Request) *WebhookDelivery {
+	return &WebhookDelivery{
+		EventType:      HookEventType(r),
+		EventUUID:      r.Header.Get(eventUUIDHeader),
+		WebhookUUID:    r.Header.Get(webhookUUIDHeader),
+		InstanceURL:    r.Header.Get(instanceHeader),
+		IdempotencyKey: r.Header.Get(idempotencyKeyHeader),
+	}
+}
+
+// ParseHookRequest reads and parses the web- or system hook sent with the
+// given request, and returns the parsed event together with the delivery
+// metadata.
+//
+// Example usage:
+//
+//	func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+//	    event, delivery, err := gitlab.ParseHookRequest(r)
+//	    if err != nil { ... }
+//	    claimed, err := s.store.Claim(r.Context(), delivery.Key())
+//	    if err != nil { ... }
+//	    if !claimed {
+//	        // This delivery was already processed.
+//	        return
+//	    }
+//	    ...
+//	}
+func ParseHookRequest(r *http.Request) (event interface{}, delivery *WebhookDelivery, err error) {
+	delivery = HookDelivery(r)
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, delivery, fmt.Errorf("error reading payload: %v", err)
+	}
+
+	event, err = ParseHook(delivery.EventType, payload)
+	if err != nil {
+		return nil, delivery, err
+	}
+
+	return event, delivery, nil
+}
+
+type webhookDeliveryContextKey struct{}
+
+// WithWebhookDelivery returns a copy of ctx carrying the given delivery.
+func WithWebhookDelivery(ctx context.Context, delivery *WebhookDelivery) context.Context {
+	return context.WithValue(ctx, webhookDeliveryContextKey{}, delivery)
+}
+
+// WebhookDeliveryFromContext returns the delivery carried by ctx, if any.
+// The WebhookHandler passes the delivery to its callbacks this way.
+func WebhookDeliveryFromContext(ctx context.Context) (*WebhookDelivery, bool) {
+	delivery, ok := ctx.Value(webhookDeliveryContextKey{}).(*WebhookDelivery)
+	return delivery, ok
+}
+
+// WebhookDeliveryStore describes the interface that all (custom) stores used
+// to deduplicate webhook deliveries must implement. Implementations must be
+// safe for concurrent use.
+type WebhookDeliveryStore interface {
+	// Claim records the delivery with the given key. It returns false if the
+	// delivery was already claimed before.
+	Claim(ctx context.Context, key string) (bool, error)
+
+	// Release removes the claim of the delivery with the given key, so a
+	// delivery that failed to be processed can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// MemoryWebhookDeliveryStore is an in-memory WebhookDeliveryStore that
+// forgets deliveries after a configurable TTL.
+type MemoryWebhookDeliveryStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	claims  map[string]time.Time
+	cleaned time.Time
+}
+
+// NewMemoryWebhookDeliveryStore returns a new in-memory delivery store that
+// remembers deliveries for the given TTL. The TTL should exceed the period
+// in which GitLab retries failed deliveries.
+func NewMemoryWebhookDeliveryStore(ttl time.Duration) *MemoryWebhookDeliveryStore {
+	return &MemoryWebhookDeliveryStore{
+		ttl:    ttl,
+		now:    time.Now,
+		claims: make(map[string]time.Time),
+	}
+}
+
+// Claim records the delivery with the given key. It returns false if the
+// delivery was already claimed within the TTL.
+func (s *MemoryWebhookDeliveryStore) Claim(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.removeExpired(now)
+
+	if expires, ok := s.claims[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	s.claims[key] = now.Add(s.ttl)
+
+	return true, nil
+}
+
+// Release removes the claim of the delivery with the given key.
+func (s *MemoryWebhookDeliveryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.claims, key)
+
+	return nil
+}
+
+// Len returns the number of deliveries in the store.
+func (s *MemoryWebhookDeliveryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now, n := s.now(), 0
+	for _, expires := range s.claims {
+		if now.Before(expires) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// removeExpired removes all expired claims, at most once per TTL so claims
+// are cheap. Must be called with the lock held.
+func (s *MemoryWebhookDeliveryStore) removeExpired(now time.Time) {
+	if now.Sub(s.cleaned) < s.ttl {
+		return
+	}
+	for key, expires := range s.claims {
+		if !now.Before(expires) {
+			delete(s.claims, key)
+		}
+	}
+	s.cleaned = now
+}
@@ -0,0 +1,131 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHookRequest(t *testing.T) {
+	req := newWebhookRequest(t, EventTypePush, "", "testdata/webhooks/push.json")
+	req.Header.Set(eventUUIDHeader, "13792a34-cac6-4fda-95a8-c58e00a3954e")
+	req.Header.Set(webhookUUIDHeader, "e7ba6fa4-4d7d-4c8b-b5fe-bd4c6a8fbf5a")
+	req.Header.Set(instanceHeader, "https://gitlab.example.com")
+	req.Header.Set(idempotencyKeyHeader, "f5e5f430-f57b-4e6e-9fac-d9128cd7232f")
+
+	event, delivery, err := ParseHookRequest(req)
+	require.NoError(t, err)
+	assert.IsType(t, &PushEvent{}, event)
+
+	want := &WebhookDelivery{
+		EventType:      EventTypePush,
+		EventUUID:      "13792a34-cac6-4fda-95a8-c58e00a3954e",
+		WebhookUUID:    "e7ba6fa4-4d7d-4c8b-b5fe-bd4c6a8fbf5a",
+		InstanceURL:    "https://gitlab.example.com",
+		IdempotencyKey: "f5e5f430-f57b-4e6e-9fac-d9128cd7232f",
+	}
+	assert.Equal(t, want, delivery)
+	assert.Equal(t, "f5e5f430-f57b-4e6e-9fac-d9128cd7232f", delivery.Key())
+
+	delivery.IdempotencyKey = ""
+	assert.Equal(t, "e7ba6fa4-4d7d-4c8b-b5fe-bd4c6a8fbf5a:13792a34-cac6-4fda-95a8-c58e00a3954e", delivery.Key())
+
+	delivery.EventUUID = ""
+	assert.Empty(t, delivery.Key())
+}
+
+func TestMemoryWebhookDeliveryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := NewMemoryWebhookDeliveryStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	claimed, err := store.Claim(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = store.Claim(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	require.NoError(t, store.Release(ctx, "a"))
+	claimed, err = store.Claim(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	now = now.Add(30 * time.Minute)
+	claimed, err = store.Claim(ctx, "b")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, 2, store.Len())
+
+	now = now.Add(45 * time.Minute)
+	assert.Equal(t, 1, store.Len())
+
+	claimed, err = store.Claim(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestWebhookHandlerDeduplicatesDeliveries(t *testing.T) {
+	h := NewWebhookHandler("", WithWebhookDeliveryStore(NewMemoryWebhookDeliveryStore(time.Hour)))
+
+	var calls int
+	var fail bool
+	h.OnPush(func(ctx context.Context, event *PushEvent) error {
+		calls++
+
+		delivery, ok := WebhookDeliveryFromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "key", delivery.Key())
+
+		if fail {
+			return errors.New("failed to handle push")
+		}
+		return nil
+	})
+
+	deliver := func() int {
+		req := newWebhookRequest(t, EventTypePush, "", "testdata/webhooks/push.json")
+		req.Header.Set(idempotencyKeyHeader, "key")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		return w.Code
+	}
+
+	fail = true
+	assert.Equal(t, http.StatusInternalServerError, deliver())
+	assert.Equal(t, 1, calls)
+
+	fail = false
+	assert.Equal(t, http.StatusNoContent, deliver())
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, http.StatusNoContent, deliver())
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,447 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// DefaultWebhookMaxBodySize is the default maximum size of a webhook payload
+// accepted by a WebhookHandler.
+const DefaultWebhookMaxBodySize = 25 << 20
+
+// WebhookHandlerOptionFunc can be used to customize a new WebhookHandler.
+type WebhookHandlerOptionFunc func(*WebhookHandler)
+
+// WithWebhookMaxBodySize sets the maximum size of the payloads accepted by the
+// handler. Larger payloads are rejected with 413 Request Entity Too Large.
+func WithWebhookMaxBodySize(size int64) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.maxBodySize = size
+	}
+}
+
+// WithWebhookEvents limits the events handled by the handler to the given
+// event types. Other events are acknowledged, but otherwise ignored.
+func WithWebhookEvents(eventTypes ...EventType) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.eventTypes = make(map[EventType]bool, len(eventTypes))
+		for _, eventType := range eventTypes {
+			h.eventTypes[eventType] = true
+		}
+	}
+}
+
+// WithWebhookErrorHandler sets a function that is called with every error
+// that causes the handler to reject a request, for example to log them.
+func WithWebhookErrorHandler(fn func(r *http.Request, err error)) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.errorHandler = fn
+	}
+}
+
+// WithWebhookDeliveryStore sets the store used to deduplicate deliveries. When
+// set, retries of a delivery that was already processed successfully are
+// acknowledged without calling the callbacks again.
+func WithWebhookDeliveryStore(store WebhookDeliveryStore) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.store = store
+	}
+}
+
+// WebhookHandler is an http.Handler that receives GitLab web- and system
+// hooks, and dispatches the parsed events to the registered callbacks.
+//
+// The status codes returned by the handler are chosen so GitLab doesn't
+// disable the hook for events the handler isn't interested in: events that
+// are filtered out or have no registered callback, including events of a
+// type this package doesn't know about (yet), are acknowledged with 204 No
+// Content. Only invalid requests are answered with a 4xx status code, while
+// errors returned by a callback result in a 500 Internal Server Error.
+//
+// The delivery metadata of the request can be retrieved in the callbacks
+// using WebhookDeliveryFromContext.
+//
+// Example usage:
+//
+//	h := gitlab.NewWebhookHandler("secret")
+//	h.OnMergeRequest(func(ctx context.Context, event *gitlab.MergeEvent) error {
+//	    ...
+//	})
+//	http.Handle("/webhook", h)
+type WebhookHandler struct {
+	secret       []byte
+	maxBodySize  int64
+	eventTypes   map[EventType]bool
+	errorHandler func(r *http.Request, err error)
+	store        WebhookDeliveryStore
+
+	mu        sync.RWMutex
+	callbacks map[reflect.Type]func(context.Context, interface{}) error
+	fallback  func(context.Context, EventType, interface{}) error
+}
+
+// NewWebhookHandler returns a new WebhookHandler. If secret is not empty, the
+// handler only accepts requests with a matching X-Gitlab-Token header.
+func NewWebhookHandler(secret string, options ...WebhookHandlerOptionFunc) *WebhookHandler {
+	h := &WebhookHandler{
+		secret:      []byte(secret),
+		maxBodySize: DefaultWebhookMaxBodySize,
+		callbacks:   make(map[reflect.Type]func(context.Context, interface{}) error),
+	}
+
+	for _, fn := range options {
+		if fn != nil {
+			fn(h)
+		}
+	}
+
+	return h
+}
+
+// errWebhookStatus is an error that results in a specific status code.
+type errWebhookStatus struct {
+	status int
+	err    error
+}
+
+func (e *errWebhookStatus) Error() string { return e.err.Error() }
+func (e *errWebhookStatus) Unwrap() error { return e.err }
+
+func webhookStatusError(status int, format string, args ...interface{}) error {
+	return &errWebhookStatus{status: status, err: fmt.Errorf(format, args...)}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, http.StatusNoContent, h.serve(w, r))
+}
+
+// respond writes the response for a request that resulted in the given
+// error, or the given status code if err is nil.
+func (h *WebhookHandler) respond(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if err != nil {
+		status = http.StatusInternalServerError
+
+		var statusErr *errWebhookStatus
+		if errors.As(err, &statusErr) {
+			status = statusErr.status
+		}
+		if status == http.StatusMethodNotAllowed {
+			w.Header().Set("Allow", http.MethodPost)
+		}
+		if h.errorHandler != nil {
+			h.errorHandler(r, err)
+		}
+	}
+
+	if status < http.StatusBadRequest {
+		w.WriteHeader(status)
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// serve handles a single request.
+func (h *WebhookHandler) serve(w http.ResponseWriter, r *http.Request) error {
+	_, event, err := h.read(w, r)
+	if err != nil || event == nil {
+		return err
+	}
+	return h.process(r.Context(), HookDelivery(r), event)
+}
+
+// read validates the request and returns its payload and the parsed event.
+// A nil event is returned for events that should be ignored.
+func (h *WebhookHandler) read(w http.ResponseWriter, r *http.Request) ([]byte, interface{}, error) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPost {
+		return nil, nil, webhookStatusError(http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+	if !h.validToken(HookEventToken(r)) {
+		return nil, nil, webhookStatusError(http.StatusUnauthorized, "invalid %s header", eventTokenHeader)
+	}
+
+	eventType := HookEventType(r)
+	if eventType == "" {
+		return nil, nil, webhookStatusError(http.StatusBadRequest, "missing %s header", eventTypeHeader)
+	}
+	if h.eventTypes != nil && !h.eventTypes[eventType] {
+		return nil, nil, nil
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, nil, webhookStatusError(http.StatusRequestEntityTooLarge, "payload exceeds %d bytes", h.maxBodySize)
+		}
+		return nil, nil, webhookStatusError(http.StatusBadRequest, "error reading payload: %v", err)
+	}
+
+	event, err := ParseHook(eventType, payload)
+	if err != nil {
+		return nil, nil, webhookStatusError(http.StatusBadRequest, "invalid %s payload: %v", eventType, err)
+	}
+
+	return payload, event, nil
+}
+
+// process dispatches the event, making sure each delivery is processed only
+// once if a delivery store is configured.
+func (h *WebhookHandler) process(ctx context.Context, delivery *WebhookDelivery, event interface{}) error {
+	ctx = WithWebhookDelivery(ctx, delivery)
+
+	key := delivery.Key()
+	if h.store == nil || key == "" {
+		return h.dispatch(ctx, delivery.EventType, event)
+	}
+
+	// Claim the delivery before processing it, so concurrent retries of the
+	// same delivery are not processed twice.
+	claimed, err := h.store.Claim(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error claiming delivery %s: %v", key, err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := h.dispatch(ctx, delivery.EventType, event); err != nil {
+		// Release the delivery so it is processed again when GitLab retries it.
+		if rerr := h.store.Release(ctx, key); rerr != nil {
+			return fmt.Errorf("%v (error releasing delivery %s: %v)", err, key, rerr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// validToken compares the token with the secret in constant time.
+func (h *WebhookHandler) validToken(token string) bool {
+	if len(h.secret) == 0 {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(token), h.secret) == 1
+}
+
+// dispatch calls the callback registered for the type of the given event.
+func (h *WebhookHandler) dispatch(ctx context.Context, eventType EventType, event interface{}) error {
+	h.mu.RLock()
+	callback := h.callbacks[reflect.TypeOf(event)]
+	fallback := h.fallback
+	h.mu.RUnlock()
+
+	switch {
+	case callback != nil:
+		return callback(ctx, event)
+	case fallback != nil:
+		return fallback(ctx, eventType, event)
+	default:
+		return nil
+	}
+}
+
+// onWebhookEvent registers the callback for events of type T.
+func onWebhookEvent[T any](h *WebhookHandler, fn func(context.Context, *T) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.callbacks[reflect.TypeOf((*T)(nil))] = func(ctx context.Context, event interface{}) error {
+		return fn(ctx, event.(*T))
+	}
+}
+
+// OnEvent registers a callback for all events without a more specific
+// callback registered.
+func (h *WebhookHandler) OnEvent(fn func(ctx context.Context, eventType EventType, event interface{}) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+// OnBuild registers a callback for build events.
+func (h *WebhookHandler) OnBuild(fn func(ctx context.Context, event *BuildEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnCommitComment registers a callback for comments on commits.
+func (h *WebhookHandler) OnCommitComment(fn func(ctx context.Context, event *CommitCommentEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnDeployment registers a callback for deployment events.
+func (h *WebhookHandler) OnDeployment(fn func(ctx context.Context, event *DeploymentEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnEmoji registers a callback for emoji events.
+func (h *WebhookHandler) OnEmoji(fn func(ctx context.Context, event *EmojiEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnFeatureFlag registers a callback for feature flag events.
+func (h *WebhookHandler) OnFeatureFlag(fn func(ctx context.Context, event *FeatureFlagEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnGroupResourceAccessToken registers a callback for group access token
+// events.
+func (h *WebhookHandler) OnGroupResourceAccessToken(fn func(ctx context.Context, event *GroupResourceAccessTokenEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnIssue registers a callback for issue events, including events of
+// confidential issues.
+func (h *WebhookHandler) OnIssue(fn func(ctx context.Context, event *IssueEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnIssueComment registers a callback for comments on issues.
+func (h *WebhookHandler) OnIssueComment(fn func(ctx context.Context, event *IssueCommentEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnJob registers a callback for job events.
+func (h *WebhookHandler) OnJob(fn func(ctx context.Context, event *JobEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnMember registers a callback for member events.
+func (h *WebhookHandler) OnMember(fn func(ctx context.Context, event *MemberEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnMergeRequest registers a callback for merge request events, which are
+// sent by both web- and system hooks.
+func (h *WebhookHandler) OnMergeRequest(fn func(ctx context.Context, event *MergeEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnMergeRequestComment registers a callback for comments on merge requests.
+func (h *WebhookHandler) OnMergeRequestComment(fn func(ctx context.Context, event *MergeCommentEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnPipeline registers a callback for pipeline events.
+func (h *WebhookHandler) OnPipeline(fn func(ctx context.Context, event *PipelineEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnProjectResourceAccessToken registers a callback for project access token
+// events.
+func (h *WebhookHandler) OnProjectResourceAccessToken(fn func(ctx context.Context, event *ProjectResourceAccessTokenEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnPush registers a callback for push events.
+func (h *WebhookHandler) OnPush(fn func(ctx context.Context, event *PushEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnRelease registers a callback for release events.
+func (h *WebhookHandler) OnRelease(fn func(ctx context.Context, event *ReleaseEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnSnippetComment registers a callback for comments on snippets.
+func (h *WebhookHandler) OnSnippetComment(fn func(ctx context.Context, event *SnippetCommentEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnSubGroup registers a callback for subgroup events.
+func (h *WebhookHandler) OnSubGroup(fn func(ctx context.Context, event *SubGroupEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnTag registers a callback for tag push events.
+func (h *WebhookHandler) OnTag(fn func(ctx context.Context, event *TagEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnUnknown registers a callback for events of a type this package doesn't
+// know about (yet).
+func (h *WebhookHandler) OnUnknown(fn func(ctx context.Context, event *UnknownEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnVulnerability registers a callback for vulnerability events.
+func (h *WebhookHandler) OnVulnerability(fn func(ctx context.Context, event *VulnerabilityEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnWikiPage registers a callback for wiki page events.
+func (h *WebhookHandler) OnWikiPage(fn func(ctx context.Context, event *WikiPageEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnGroupSystem registers a callback for group system hook events.
+func (h *WebhookHandler) OnGroupSystem(fn func(ctx context.Context, event *GroupSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnKeySystem registers a callback for key system hook events.
+func (h *WebhookHandler) OnKeySystem(fn func(ctx context.Context, event *KeySystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnProjectSystem registers a callback for project system hook events.
+func (h *WebhookHandler) OnProjectSystem(fn func(ctx context.Context, event *ProjectSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnPushSystem registers a callback for push system hook events.
+func (h *WebhookHandler) OnPushSystem(fn func(ctx context.Context, event *PushSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnRepositoryUpdateSystem registers a callback for repository update system
+// hook events.
+func (h *WebhookHandler) OnRepositoryUpdateSystem(fn func(ctx context.Context, event *RepositoryUpdateSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnTagPushSystem registers a callback for tag push system hook events.
+func (h *WebhookHandler) OnTagPushSystem(fn func(ctx context.Context, event *TagPushSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnUserSystem registers a callback for user system hook events.
+func (h *WebhookHandler) OnUserSystem(fn func(ctx context.Context, event *UserSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnUserGroupSystem registers a callback for group membership system hook
+// events.
+func (h *WebhookHandler) OnUserGroupSystem(fn func(ctx context.Context, event *UserGroupSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
+
+// OnUserTeamSystem registers a callback for project membership system hook
+// events.
+func (h *WebhookHandler) OnUserTeamSystem(fn func(ctx context.Context, event *UserTeamSystemEvent) error) {
+	onWebhookEvent(h, fn)
+}
@@ -0,0 +1,242 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookRequest(t *testing.T, eventType EventType, token, fixture string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(loadFixture(t, fixture)))
+	req.Header.Set(eventTypeHeader, string(eventType))
+	if token != "" {
+		req.Header.Set(eventTokenHeader, token)
+	}
+
+	return req
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	h := NewWebhookHandler("secret")
+
+	var push *PushEvent
+	h.OnPush(func(ctx context.Context, event *PushEvent) error {
+		push = event
+		return nil
+	})
+
+	var merge *MergeEvent
+	h.OnMergeRequest(func(ctx context.Context, event *MergeEvent) error {
+		merge = event
+		return nil
+	})
+
+	var pushSystem *PushSystemEvent
+	h.OnPushSystem(func(ctx context.Context, event *PushSystemEvent) error {
+		pushSystem = event
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newWebhookRequest(t, EventTypePush, "secret", "testdata/webhooks/push.json"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	require.NotNil(t, push)
+	assert.Equal(t, "push", push.ObjectKind)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newWebhookRequest(t, EventTypeMergeRequest, "secret", "testdata/webhooks/merge_request.json"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	require.NotNil(t, merge)
+	assert.Equal(t, "merge_request", merge.ObjectKind)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newWebhookRequest(t, EventTypeSystemHook, "secret", "testdata/systemhooks/push.json"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	require.NotNil(t, pushSystem)
+	assert.Equal(t, "push", pushSystem.EventName)
+}
+
+func TestWebhookHandlerFallback(t *testing.T) {
+	h := NewWebhookHandler("")
+
+	var eventType EventType
+	var event interface{}
+	h.OnEvent(func(ctx context.Context, et EventType, e interface{}) error {
+		eventType, event = et, e
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newWebhookRequest(t, EventTypePipeline, "", "testdata/webhooks/pipeline.json"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, EventTypePipeline, eventType)
+	assert.IsType(t, &PipelineEvent{}, event)
+}
+
+func TestWebhookHandlerStatusCodes(t *testing.T) {
+	var handled []error
+	h := NewWebhookHandler(
+		"secret",
+		WithWebhookMaxBodySize(4096),
+		WithWebhookEvents(EventTypeJob, EventTypeMergeRequest, EventTypePush, EventTypeServiceHook),
+		WithWebhookErrorHandler(func(r *http.Request, err error) {
+			handled = append(handled, err)
+		}),
+	)
+
+	h.OnJob(func(ctx context.Context, event *JobEvent) error {
+		return errors.New("failed to handle job")
+	})
+
+	tests := []struct {
+		name   string
+		req    func() *http.Request
+		status int
+		failed bool
+	}{
+		{
+			name: "method not allowed",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/webhook", nil)
+			},
+			status: http.StatusMethodNotAllowed,
+			failed: true,
+		},
+		{
+			name: "missing token",
+			req: func() *http.Request {
+				return newWebhookRequest(t, EventTypeJob, "", "testdata/webhooks/job.json")
+			},
+			status: http.StatusUnauthorized,
+			failed: true,
+		},
+		{
+			name: "invalid token",
+			req: func() *http.Request {
+				return newWebhookRequest(t, EventTypeJob, "secreT", "testdata/webhooks/job.json")
+			},
+			status: http.StatusUnauthorized,
+			failed: true,
+		},
+		{
+			name: "missing event type",
+			req: func() *http.Request {
+				req := newWebhookRequest(t, EventTypeJob, "secret", "testdata/webhooks/job.json")
+				req.Header.Del(eventTypeHeader)
+				return req
+			},
+			status: http.StatusBadRequest,
+			failed: true,
+		},
+		{
+			name: "payload too large",
+			req: func() *http.Request {
+				return newWebhookRequest(t, EventTypeMergeRequest, "secret", "testdata/webhooks/merge_request.json")
+			},
+			status: http.StatusRequestEntityTooLarge,
+			failed: true,
+		},
+		{
+			name: "malformed payload",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(`{"object_kind":`))
+				req.Header.Set(eventTypeHeader, string(EventTypePush))
+				req.Header.Set(eventTokenHeader, "secret")
+				return req
+			},
+			status: http.StatusBadRequest,
+			failed: true,
+		},
+		{
+			name: "callback error",
+			req: func() *http.Request {
+				return newWebhookRequest(t, EventTypeJob, "secret", "testdata/webhooks/job.json")
+			},
+			status: http.StatusInternalServerError,
+			failed: true,
+		},
+		{
+			name: "filtered event",
+			req: func() *http.Request {
+				return newWebhookRequest(t, EventTypePipeline, "secret", "testdata/webhooks/pipeline.json")
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name: "unhandled event",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(`{"object_kind":"push"}`))
+				req.Header.Set(eventTypeHeader, string(EventTypePush))
+				req.Header.Set(eventTokenHeader, "secret")
+				return req
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name: "unknown event",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(`{"object_kind":"unknown"}`))
+				req.Header.Set(eventTypeHeader, string(EventTypeServiceHook))
+				req.Header.Set(eventTokenHeader, "secret")
+				return req
+			},
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handled = nil
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, tc.req())
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, tc.failed, len(handled) == 1)
+		})
+	}
+}
+
+func TestWebhookHandlerUnknownEvent(t *testing.T) {
+	h := NewWebhookHandler("")
+
+	var unknown *UnknownEvent
+	h.OnUnknown(func(ctx context.Context, event *UnknownEvent) error {
+		unknown = event
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewBufferString(`{"object_kind":"milestone"}`))
+	req.Header.Set(eventTypeHeader, "Milestone Hook")
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	require.NotNil(t, unknown)
+	assert.Equal(t, "milestone", unknown.ObjectKind)
+}
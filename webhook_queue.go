@@ -0,0 +1,380 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWebhookQueueWorkers     = 4
+	defaultWebhookQueueMaxAttempts = 5
+)
+
+// WebhookQueueOptionFunc can be used to customize a new WebhookQueue.
+type WebhookQueueOptionFunc func(*WebhookQueue)
+
+// WithWebhookQueueSpool sets the spool used to persist deliveries. By default
+// an in-memory spool is used.
+func WithWebhookQueueSpool(spool WebhookSpool) WebhookQueueOptionFunc {
+	return func(q *WebhookQueue) {
+		q.spool = spool
+	}
+}
+
+// WithWebhookQueueWorkers sets the number of deliveries that are processed
+// concurrently.
+func WithWebhookQueueWorkers(workers int) WebhookQueueOptionFunc {
+	return func(q *WebhookQueue) {
+		q.workers = workers
+	}
+}
+
+// WithWebhookQueueMaxAttempts sets the number of times a delivery is
+// processed before it is moved to the dead-letter list.
+func WithWebhookQueueMaxAttempts(attempts int) WebhookQueueOptionFunc {
+	return func(q *WebhookQueue) {
+		q.maxAttempts = attempts
+	}
+}
+
+// WithWebhookQueueBackoff sets the function that returns how long to wait
+// before retrying a delivery that failed the given number of attempts.
+func WithWebhookQueueBackoff(backoff func(attempts int) time.Duration) WebhookQueueOptionFunc {
+	return func(q *WebhookQueue) {
+		q.backoff = backoff
+	}
+}
+
+// WithWebhookQueueErrorHandler sets a function that is called with every
+// error that occurs while processing a delivery, for example to log them.
+func WithWebhookQueueErrorHandler(fn func(webhook *SpooledWebhook, err error)) WebhookQueueOptionFunc {
+	return func(q *WebhookQueue) {
+		q.errorHandler = fn
+	}
+}
+
+// WebhookQueue is an http.Handler that spools incoming web- and system hooks
+// and acknowledges them immediately, before processing them in the
+// background using the callbacks of a WebhookHandler.
+//
+// Deliveries that fail are retried with an exponential backoff. Once a
+// delivery failed the maximum number of attempts, it is moved to the
+// dead-letter list, from which it can be re-driven after fixing the problem.
+//
+// Example usage:
+//
+//	h := gitlab.NewWebhookHandler("secret")
+//	h.OnPipeline(func(ctx context.Context, event *gitlab.PipelineEvent) error {
+//	    ...
+//	})
+//
+//	spool, err := gitlab.NewDirWebhookSpool("/var/spool/webhooks")
+//	if err != nil { ... }
+//
+//	q := gitlab.NewWebhookQueue(h, gitlab.WithWebhookQueueSpool(spool))
+//	go q.Run(ctx)
+//	http.Handle("/webhook", q)
+type WebhookQueue struct {
+	handler      *WebhookHandler
+	spool        WebhookSpool
+	workers      int
+	maxAttempts  int
+	backoff      func(attempts int) time.Duration
+	errorHandler func(webhook *SpooledWebhook, err error)
+
+	mu      sync.Mutex
+	pending []*SpooledWebhook
+	queued  map[string]bool
+	wake    chan struct{}
+}
+
+// NewWebhookQueue returns a new WebhookQueue processing deliveries using the
+// callbacks registered with the given handler. The token validation, size
+// limit and event filter of the handler are applied to incoming requests.
+func NewWebhookQueue(handler *WebhookHandler, options ...WebhookQueueOptionFunc) *WebhookQueue {
+	q := &WebhookQueue{
+		handler:     handler,
+		spool:       NewMemoryWebhookSpool(),
+		workers:     defaultWebhookQueueWorkers,
+		maxAttempts: defaultWebhookQueueMaxAttempts,
+		backoff:     webhookQueueBackoff,
+		queued:      make(map[string]bool),
+		wake:        make(chan struct{}, 1),
+	}
+
+	for _, fn := range options {
+		if fn != nil {
+			fn(q)
+		}
+	}
+
+	return q
+}
+
+// webhookQueueBackoff doubles the time between attempts, starting at one
+// second and capped at five minutes.
+func webhookQueueBackoff(attempts int) time.Duration {
+	if attempts > 9 {
+		return 5 * time.Minute
+	}
+	backoff := time.Second << (attempts - 1)
+	if backoff > 5*time.Minute {
+		backoff = 5 * time.Minute
+	}
+	return backoff
+}
+
+// ServeHTTP implements the http.Handler interface. Valid deliveries are
+// spooled and acknowledged with 202 Accepted.
+func (q *WebhookQueue) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q.handler.respond(w, r, http.StatusAccepted, q.enqueue(w, r))
+}
+
+// enqueue validates and spools a single request.
+func (q *WebhookQueue) enqueue(w http.ResponseWriter, r *http.Request) error {
+	payload, event, err := q.handler.read(w, r)
+	if err != nil || event == nil {
+		return err
+	}
+
+	id, err := newSpooledWebhookID()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	webhook := &SpooledWebhook{
+		ID:          id,
+		Delivery:    HookDelivery(r),
+		Payload:     payload,
+		ReceivedAt:  now,
+		NextAttempt: now,
+	}
+	if err := q.spool.Put(r.Context(), webhook); err != nil {
+		return fmt.Errorf("error spooling webhook: %v", err)
+	}
+	q.schedule(webhook)
+
+	return nil
+}
+
+// newSpooledWebhookID returns a new random ID.
+func newSpooledWebhookID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Run processes the spooled deliveries until ctx is canceled. It first loads
+// all pending deliveries from the spool, so deliveries received before a
+// restart are processed as well. Run must not be called concurrently.
+//
+// Deliveries that are still being processed when ctx is canceled remain in
+// the spool and are processed again the next time Run is called.
+func (q *WebhookQueue) Run(ctx context.Context) error {
+	webhooks, err := q.spool.List(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading spooled webhooks: %v", err)
+	}
+	for _, webhook := range webhooks {
+		if !webhook.Dead {
+			q.schedule(webhook)
+		}
+	}
+
+	jobs := make(chan *SpooledWebhook)
+
+	var wg sync.WaitGroup
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for webhook := range jobs {
+				q.process(ctx, webhook)
+			}
+		}()
+	}
+
+	q.dispatch(ctx, jobs)
+	close(jobs)
+	wg.Wait()
+
+	return nil
+}
+
+// dispatch sends deliveries to the workers once they are due.
+func (q *WebhookQueue) dispatch(ctx context.Context, jobs chan<- *SpooledWebhook) {
+	for {
+		webhook, wait := q.next()
+		if webhook != nil {
+			select {
+			case jobs <- webhook:
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-q.wake:
+		case <-ctx.Done():
+		}
+		timer.Stop()
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// next removes and returns the next delivery if it is due. Otherwise it
+// returns how long to wait for the next delivery.
+func (q *WebhookQueue) next() (*SpooledWebhook, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return nil, time.Hour
+	}
+
+	webhook := q.pending[0]
+	if wait := time.Until(webhook.NextAttempt); wait > 0 {
+		return nil, wait
+	}
+	q.pending = q.pending[1:]
+	delete(q.queued, webhook.ID)
+
+	return webhook, 0
+}
+
+// schedule adds the delivery to the pending deliveries, ordered by the time
+// of their next attempt.
+func (q *WebhookQueue) schedule(webhook *SpooledWebhook) {
+	q.mu.Lock()
+	if !q.queued[webhook.ID] {
+		i := sort.Search(len(q.pending), func(i int) bool {
+			return q.pending[i].NextAttempt.After(webhook.NextAttempt)
+		})
+		q.pending = append(q.pending, nil)
+		copy(q.pending[i+1:], q.pending[i:])
+		q.pending[i] = webhook
+		q.queued[webhook.ID] = true
+	}
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// process processes a single delivery, and either removes it from the spool
+// or schedules it to be retried.
+func (q *WebhookQueue) process(ctx context.Context, webhook *SpooledWebhook) {
+	event, err := ParseHook(webhook.Delivery.EventType, webhook.Payload)
+	if err == nil {
+		err = q.handler.process(ctx, webhook.Delivery, event)
+	}
+	if err == nil {
+		if err := q.spool.Delete(ctx, webhook.ID); err != nil {
+			q.handleError(webhook, fmt.Errorf("error removing webhook from spool: %v", err))
+		}
+		return
+	}
+
+	if ctx.Err() != nil {
+		// The queue is shutting down, the delivery is processed again by
+		// the next call to Run.
+		return
+	}
+	q.handleError(webhook, err)
+
+	webhook.Attempts++
+	webhook.LastError = err.Error()
+	if webhook.Attempts >= q.maxAttempts {
+		webhook.Dead = true
+	} else {
+		webhook.NextAttempt = time.Now().Add(q.backoff(webhook.Attempts))
+	}
+
+	if err := q.spool.Put(ctx, webhook); err != nil {
+		q.handleError(webhook, fmt.Errorf("error updating spooled webhook: %v", err))
+	}
+	if !webhook.Dead {
+		q.schedule(webhook)
+	}
+}
+
+func (q *WebhookQueue) handleError(webhook *SpooledWebhook, err error) {
+	if q.errorHandler != nil {
+		q.errorHandler(webhook, err)
+	}
+}
+
+// DeadLetters returns the deliveries that failed the maximum number of
+// attempts.
+func (q *WebhookQueue) DeadLetters(ctx context.Context) ([]*SpooledWebhook, error) {
+	webhooks, err := q.spool.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dead []*SpooledWebhook
+	for _, webhook := range webhooks {
+		if webhook.Dead {
+			dead = append(dead, webhook)
+		}
+	}
+
+	return dead, nil
+}
+
+// Redrive moves the delivery with the given ID from the dead-letter list back
+// to the queue, resetting its number of attempts.
+func (q *WebhookQueue) Redrive(ctx context.Context, id string) error {
+	webhook, err := q.spool.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !webhook.Dead {
+		return fmt.Errorf("webhook %s is not in the dead-letter list", id)
+	}
+
+	webhook.Dead = false
+	webhook.Attempts = 0
+	webhook.LastError = ""
+	webhook.NextAttempt = time.Now()
+
+	if err := q.spool.Put(ctx, webhook); err != nil {
+		return err
+	}
+	q.schedule(webhook)
+
+	return nil
+}
@@ -0,0 +1,238 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runWebhookQueue(t *testing.T, q *WebhookQueue) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- q.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func TestWebhookQueueRetries(t *testing.T) {
+	h := NewWebhookHandler("secret")
+
+	var mu sync.Mutex
+	var attempts int
+	processed := make(chan *PipelineEvent, 1)
+	h.OnPipeline(func(ctx context.Context, event *PipelineEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts++
+		if attempts < 3 {
+			return errors.New("temporary failure")
+		}
+		processed <- event
+		return nil
+	})
+
+	spool := NewMemoryWebhookSpool()
+	q := NewWebhookQueue(h,
+		WithWebhookQueueSpool(spool),
+		WithWebhookQueueBackoff(func(int) time.Duration { return time.Millisecond }),
+	)
+
+	w := httptest.NewRecorder()
+	q.ServeHTTP(w, newWebhookRequest(t, EventTypePipeline, "secret", "testdata/webhooks/pipeline.json"))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	webhooks, err := spool.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, EventTypePipeline, webhooks[0].Delivery.EventType)
+
+	runWebhookQueue(t, q)
+
+	select {
+	case event := <-processed:
+		assert.Equal(t, "pipeline", event.ObjectKind)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the webhook to be processed")
+	}
+
+	assert.Eventually(t, func() bool {
+		webhooks, err := spool.List(context.Background())
+		return err == nil && len(webhooks) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebhookQueueRejectsInvalidRequests(t *testing.T) {
+	q := NewWebhookQueue(NewWebhookHandler("secret"))
+
+	w := httptest.NewRecorder()
+	q.ServeHTTP(w, newWebhookRequest(t, EventTypePipeline, "invalid", "testdata/webhooks/pipeline.json"))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	webhooks, err := q.spool.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+}
+
+func TestWebhookQueueDeadLetters(t *testing.T) {
+	h := NewWebhookHandler("")
+
+	var mu sync.Mutex
+	fail := true
+	processed := make(chan struct{}, 1)
+	h.OnPush(func(ctx context.Context, event *PushEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if fail {
+			return errors.New("permanent failure")
+		}
+		processed <- struct{}{}
+		return nil
+	})
+
+	var errs []error
+	q := NewWebhookQueue(h,
+		WithWebhookQueueMaxAttempts(2),
+		WithWebhookQueueBackoff(func(int) time.Duration { return time.Millisecond }),
+		WithWebhookQueueErrorHandler(func(webhook *SpooledWebhook, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}),
+	)
+	runWebhookQueue(t, q)
+
+	w := httptest.NewRecorder()
+	q.ServeHTTP(w, newWebhookRequest(t, EventTypePush, "", "testdata/webhooks/push.json"))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var dead []*SpooledWebhook
+	require.Eventually(t, func() bool {
+		var err error
+		dead, err = q.DeadLetters(context.Background())
+		return err == nil && len(dead) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 2, dead[0].Attempts)
+	assert.Equal(t, "permanent failure", dead[0].LastError)
+
+	mu.Lock()
+	assert.Len(t, errs, 2)
+	fail = false
+	mu.Unlock()
+
+	require.NoError(t, q.Redrive(context.Background(), dead[0].ID))
+
+	select {
+	case <-processed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the webhook to be processed")
+	}
+
+	assert.Eventually(t, func() bool {
+		dead, err := q.DeadLetters(context.Background())
+		return err == nil && len(dead) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	err := q.Redrive(context.Background(), dead[0].ID)
+	assert.ErrorIs(t, err, ErrSpooledWebhookNotFound)
+}
+
+func TestWebhookQueueResumesFromSpool(t *testing.T) {
+	spool, err := NewDirWebhookSpool(t.TempDir())
+	require.NoError(t, err)
+
+	h := NewWebhookHandler("")
+	processed := make(chan *MergeEvent, 1)
+	h.OnMergeRequest(func(ctx context.Context, event *MergeEvent) error {
+		processed <- event
+		return nil
+	})
+
+	// Spool a delivery without processing it, as if the process exited.
+	w := httptest.NewRecorder()
+	NewWebhookQueue(h, WithWebhookQueueSpool(spool)).ServeHTTP(w, newWebhookRequest(t, EventTypeMergeRequest, "", "testdata/webhooks/merge_request.json"))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	runWebhookQueue(t, NewWebhookQueue(h, WithWebhookQueueSpool(spool)))
+
+	select {
+	case event := <-processed:
+		assert.Equal(t, "merge_request", event.ObjectKind)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the webhook to be processed")
+	}
+}
+
+func TestDirWebhookSpool(t *testing.T) {
+	ctx := context.Background()
+
+	spool, err := NewDirWebhookSpool(t.TempDir())
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	first := &SpooledWebhook{
+		ID:         "b",
+		Delivery:   &WebhookDelivery{EventType: EventTypePush, EventUUID: "uuid"},
+		Payload:    []byte(`{"object_kind":"push"}`),
+		ReceivedAt: now,
+	}
+	second := &SpooledWebhook{
+		ID:         "a",
+		Delivery:   &WebhookDelivery{EventType: EventTypeTagPush},
+		Payload:    []byte(`{"object_kind":"tag_push"}`),
+		ReceivedAt: now.Add(time.Second),
+		Attempts:   1,
+		LastError:  "failed",
+	}
+	require.NoError(t, spool.Put(ctx, first))
+	require.NoError(t, spool.Put(ctx, second))
+
+	got, err := spool.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Equal(t, first, got)
+
+	webhooks, err := spool.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*SpooledWebhook{first, second}, webhooks)
+
+	require.NoError(t, spool.Delete(ctx, "b"))
+	require.NoError(t, spool.Delete(ctx, "b"))
+
+	_, err = spool.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrSpooledWebhookNotFound)
+
+	assert.Error(t, spool.Put(ctx, &SpooledWebhook{ID: "../escape"}))
+	_, err = spool.Get(ctx, "../escape")
+	assert.EqualError(t, err, `invalid spooled webhook ID "../escape"`)
+	assert.EqualError(t, spool.Delete(ctx, "../escape"), `invalid spooled webhook ID "../escape"`)
+}
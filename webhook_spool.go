@@ -0,0 +1,256 @@
+//
+// Copyright 2024, Sander van Harmelen
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSpooledWebhookNotFound is returned by a WebhookSpool when the requested
+// webhook doesn't exist.
+var ErrSpooledWebhookNotFound = errors.New("spooled webhook not found")
+
+// SpooledWebhook represents a webhook delivery stored in a WebhookSpool.
+type SpooledWebhook struct {
+	ID          string           `json:"id"`
+	Delivery    *WebhookDelivery `json:"delivery"`
+	Payload     json.RawMessage  `json:"payload"`
+	ReceivedAt  time.Time        `json:"received_at"`
+	Attempts    int              `json:"attempts"`
+	NextAttempt time.Time        `json:"next_attempt"`
+	LastError   string           `json:"last_error,omitempty"`
+	Dead        bool             `json:"dead"`
+}
+
+// WebhookSpool describes the interface that all (custom) spools used by a
+// WebhookQueue to persist deliveries must implement. Implementations must be
+// safe for concurrent use.
+type WebhookSpool interface {
+	// Put stores the webhook, replacing any webhook with the same ID.
+	Put(ctx context.Context, webhook *SpooledWebhook) error
+
+	// Get returns the webhook with the given ID, or ErrSpooledWebhookNotFound
+	// if it doesn't exist.
+	Get(ctx context.Context, id string) (*SpooledWebhook, error)
+
+	// List returns all stored webhooks, ordered by the time they were
+	// received.
+	List(ctx context.Context) ([]*SpooledWebhook, error)
+
+	// Delete removes the webhook with the given ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// MemoryWebhookSpool is an in-memory WebhookSpool. Webhooks stored in it are
+// lost when the process exits.
+type MemoryWebhookSpool struct {
+	mu       sync.Mutex
+	webhooks map[string]*SpooledWebhook
+}
+
+// NewMemoryWebhookSpool returns a new in-memory spool.
+func NewMemoryWebhookSpool() *MemoryWebhookSpool {
+	return &MemoryWebhookSpool{webhooks: make(map[string]*SpooledWebhook)}
+}
+
+// Put stores the webhook.
+func (s *MemoryWebhookSpool) Put(_ context.Context, webhook *SpooledWebhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := *webhook
+	s.webhooks[w.ID] = &w
+
+	return nil
+}
+
+// Get returns the webhook with the given ID.
+func (s *MemoryWebhookSpool) Get(_ context.Context, id string) (*SpooledWebhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok {
+		return nil, ErrSpooledWebhookNotFound
+	}
+	w := *webhook
+
+	return &w, nil
+}
+
+// List returns all stored webhooks.
+func (s *MemoryWebhookSpool) List(_ context.Context) ([]*SpooledWebhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhooks := make([]*SpooledWebhook, 0, len(s.webhooks))
+	for _, webhook := range s.webhooks {
+		w := *webhook
+		webhooks = append(webhooks, &w)
+	}
+	sortSpooledWebhooks(webhooks)
+
+	return webhooks, nil
+}
+
+// Delete removes the webhook with the given ID.
+func (s *MemoryWebhookSpool) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.webhooks, id)
+
+	return nil
+}
+
+// DirWebhookSpool is a WebhookSpool storing each webhook as a JSON file in a
+// directory, so pending deliveries survive restarts.
+type DirWebhookSpool struct {
+	dir string
+}
+
+// NewDirWebhookSpool returns a new spool storing webhooks in the given
+// directory, which is created if it doesn't exist.
+func NewDirWebhookSpool(dir string) (*DirWebhookSpool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DirWebhookSpool{dir: dir}, nil
+}
+
+// path returns the path of the file storing the webhook with the given ID.
+// IDs are validated, so they can't refer to a file outside the directory.
+func (s *DirWebhookSpool) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid spooled webhook ID %q", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// Put stores the webhook. The file is written atomically, so a crash never
+// leaves a partially written webhook behind.
+func (s *DirWebhookSpool) Put(_ context.Context, webhook *SpooledWebhook) error {
+	path, err := s.path(webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Get returns the webhook with the given ID.
+func (s *DirWebhookSpool) Get(_ context.Context, id string) (*SpooledWebhook, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrSpooledWebhookNotFound
+		}
+		return nil, err
+	}
+
+	webhook := new(SpooledWebhook)
+	if err := json.Unmarshal(data, webhook); err != nil {
+		return nil, fmt.Errorf("error decoding spooled webhook %s: %v", id, err)
+	}
+
+	return webhook, nil
+}
+
+// List returns all stored webhooks.
+func (s *DirWebhookSpool) List(ctx context.Context) ([]*SpooledWebhook, error) {
+	names, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*SpooledWebhook, 0, len(names))
+	for _, name := range names {
+		webhook, err := s.Get(ctx, strings.TrimSuffix(filepath.Base(name), ".json"))
+		if err != nil {
+			if errors.Is(err, ErrSpooledWebhookNotFound) {
+				// Deleted while listing.
+				continue
+			}
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	sortSpooledWebhooks(webhooks)
+
+	return webhooks, nil
+}
+
+// Delete removes the webhook with the given ID.
+func (s *DirWebhookSpool) Delete(_ context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// sortSpooledWebhooks sorts the webhooks by the time they were received.
+func sortSpooledWebhooks(webhooks []*SpooledWebhook) {
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		if webhooks[i].ReceivedAt.Equal(webhooks[j].ReceivedAt) {
+			return webhooks[i].ID < webhooks[j].ID
+		}
+		return webhooks[i].ReceivedAt.Before(webhooks[j].ReceivedAt)
+	})
+}